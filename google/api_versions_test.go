@@ -228,3 +228,8 @@ func (d *ResourceDataMock) GetOk(key string) (interface{}, bool) {
 
 	return nil, false
 }
+
+func (d *ResourceDataMock) Get(key string) interface{} {
+	v, _ := d.GetOk(key)
+	return v
+}
@@ -0,0 +1,141 @@
+package google
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// auditRecord is a line of the API audit log file.
+type auditRecord struct {
+	Timestamp   string `json:"timestamp"`
+	Method      string `json:"method"`
+	Resource    string `json:"resource"`
+	RequestHash string `json:"request_hash"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// auditLog appends auditRecords to a file, one JSON object per line.
+type auditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func openAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening audit log file %q: %s", path, err)
+	}
+	return &auditLog{w: f}, nil
+}
+
+func (l *auditLog) write(record *auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// auditTransport records every mutating API call in an auditLog. Requests
+// are identified by the SHA-256 hash of their body rather than the body
+// itself, so that the log never holds secrets.
+type auditTransport struct {
+	log  *auditLog
+	base http.RoundTripper
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingMethod(req.Method) {
+		return t.base.RoundTrip(req)
+	}
+
+	record := &auditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Method:    req.Method,
+		Resource:  auditResource(req),
+	}
+
+	// RoundTrippers must not modify the request they are given
+	r := *req
+	hash, err := hashRequestBody(&r)
+	if err != nil {
+		return nil, err
+	}
+	record.RequestHash = hash
+
+	resp, err := t.base.RoundTrip(&r)
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.StatusCode = resp.StatusCode
+	}
+
+	if werr := t.log.write(record); werr != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return nil, fmt.Errorf("Error writing audit log: %s", werr)
+	}
+
+	return resp, err
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH", "DELETE":
+		return true
+	}
+	return false
+}
+
+// auditResource is the URL of req without its query string, which may hold
+// an API key.
+func auditResource(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// hashRequestBody returns the hex-encoded SHA-256 hash of the body of req,
+// making sure the body can still be sent afterwards.
+func hashRequestBody(req *http.Request) (string, error) {
+	var body []byte
+	switch {
+	case req.Body == nil:
+	case req.GetBody != nil:
+		rc, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+		if body, err = ioutil.ReadAll(rc); err != nil {
+			return "", err
+		}
+	default:
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,100 @@
+package google
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuditTransport(t *testing.T) {
+	t.Parallel()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, string(body))
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := &http.Client{
+		Transport: &auditTransport{
+			log:  &auditLog{w: &buf},
+			base: http.DefaultTransport,
+		},
+	}
+
+	body := `{"name": "bucket"}`
+	requests := []*http.Request{}
+	for _, method := range []string{"GET", "POST", "DELETE"} {
+		var req *http.Request
+		var err error
+		if method == "POST" {
+			req, err = http.NewRequest(method, server.URL+"/storage/v1/b?key=secret", strings.NewReader(body))
+		} else {
+			req, err = http.NewRequest(method, server.URL+"/storage/v1/b/bucket", nil)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		requests = append(requests, req)
+	}
+	// Bodies without GetBody have to be buffered before they are hashed.
+	requests[1].GetBody = nil
+
+	for _, req := range requests {
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Error sending %s request: %s", req.Method, err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(received) != 3 || received[1] != body {
+		t.Fatalf("Expected the POST body to be sent unchanged, got %q", received)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 audit records for the mutating calls, got %d:\n%s", len(lines), buf.String())
+	}
+
+	sum := sha256.Sum256([]byte(body))
+	emptySum := sha256.Sum256(nil)
+	expected := []auditRecord{
+		{
+			Method:      "POST",
+			Resource:    server.URL + "/storage/v1/b",
+			RequestHash: hex.EncodeToString(sum[:]),
+			StatusCode:  200,
+		},
+		{
+			Method:      "DELETE",
+			Resource:    server.URL + "/storage/v1/b/bucket",
+			RequestHash: hex.EncodeToString(emptySum[:]),
+			StatusCode:  404,
+		},
+	}
+	for i, line := range lines {
+		var record auditRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Audit record %q is not valid JSON: %s", line, err)
+		}
+		if record.Timestamp == "" {
+			t.Errorf("Expected audit record %q to have a timestamp", line)
+		}
+		record.Timestamp = ""
+		if record != expected[i] {
+			t.Errorf("Expected audit record %+v, got %+v", expected[i], record)
+		}
+	}
+}
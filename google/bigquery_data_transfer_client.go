@@ -0,0 +1,59 @@
+package google
+
+import (
+	"net/http"
+	"net/url"
+)
+
+const bigqueryDataTransferBasePath = "https://bigquerydatatransfer.googleapis.com/"
+
+// bigqueryDataTransferClient is a minimal client for the BigQuery Data
+// Transfer API v1, which has no generated client in the vendored
+// google.golang.org/api. It only covers the transfer config calls
+// google_bigquery_data_transfer_config needs.
+type bigqueryDataTransferClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type bigqueryDataTransferConfig struct {
+	Name                  string                 `json:"name,omitempty"`
+	DisplayName           string                 `json:"displayName,omitempty"`
+	DataSourceId          string                 `json:"dataSourceId,omitempty"`
+	DestinationDatasetId  string                 `json:"destinationDatasetId,omitempty"`
+	Schedule              string                 `json:"schedule,omitempty"`
+	DataRefreshWindowDays int                    `json:"dataRefreshWindowDays,omitempty"`
+	Disabled              bool                   `json:"disabled,omitempty"`
+	Params                map[string]interface{} `json:"params,omitempty"`
+	State                 string                 `json:"state,omitempty"`
+	UpdateTime            string                 `json:"updateTime,omitempty"`
+	NextRunTime           string                 `json:"nextRunTime,omitempty"`
+}
+
+// CreateTransferConfig creates config in parent, which is either
+// projects/{project} or projects/{project}/locations/{location}.
+func (c *bigqueryDataTransferClient) CreateTransferConfig(parent string, config *bigqueryDataTransferConfig) (*bigqueryDataTransferConfig, error) {
+	res := &bigqueryDataTransferConfig{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", c.BasePath+"v1/"+parent+"/transferConfigs", config, res)
+	return res, err
+}
+
+func (c *bigqueryDataTransferClient) GetTransferConfig(name string) (*bigqueryDataTransferConfig, error) {
+	res := &bigqueryDataTransferConfig{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+"v1/"+name, nil, res)
+	return res, err
+}
+
+// UpdateTransferConfig sets the fields of the config called name listed in
+// updateMask to their values in config.
+func (c *bigqueryDataTransferClient) UpdateTransferConfig(name string, config *bigqueryDataTransferConfig, updateMask string) (*bigqueryDataTransferConfig, error) {
+	u := c.BasePath + "v1/" + name + "?" + url.Values{"updateMask": {updateMask}}.Encode()
+	res := &bigqueryDataTransferConfig{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PATCH", u, config, res)
+	return res, err
+}
+
+func (c *bigqueryDataTransferClient) DeleteTransferConfig(name string) error {
+	return sendJsonRequest(c.client, c.UserAgent, "DELETE", c.BasePath+"v1/"+name, nil, nil)
+}
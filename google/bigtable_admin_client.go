@@ -0,0 +1,92 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const bigtableAdminBasePath = "https://bigtableadmin.googleapis.com/"
+
+// bigtableAdminClient is a minimal client for the cluster calls of the
+// Cloud Bigtable Admin API v2. The vendored cloud.google.com/go/bigtable
+// InstanceAdminClient can create clusters but not resize them.
+type bigtableAdminClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type bigtableCluster struct {
+	Name               string `json:"name,omitempty"`
+	Location           string `json:"location,omitempty"`
+	ServeNodes         int64  `json:"serveNodes,omitempty"`
+	DefaultStorageType string `json:"defaultStorageType,omitempty"`
+}
+
+type bigtableAdminOperation struct {
+	Name  string                       `json:"name,omitempty"`
+	Done  bool                         `json:"done,omitempty"`
+	Error *bigtableAdminOperationError `json:"error,omitempty"`
+}
+
+type bigtableAdminOperationError struct {
+	Code    int64  `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (c *bigtableAdminClient) GetCluster(name string) (*bigtableCluster, error) {
+	res := &bigtableCluster{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+"v2/"+name, nil, res)
+	return res, err
+}
+
+// UpdateCluster sets the number of nodes of the cluster called name.
+func (c *bigtableAdminClient) UpdateCluster(name string, serveNodes int64) (*bigtableAdminOperation, error) {
+	op := &bigtableAdminOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PUT", c.BasePath+"v2/"+name, &bigtableCluster{
+		ServeNodes: serveNodes,
+	}, op)
+	return op, err
+}
+
+func (c *bigtableAdminClient) GetOperation(name string) (*bigtableAdminOperation, error) {
+	op := &bigtableAdminOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+"v2/"+name, nil, op)
+	return op, err
+}
+
+func bigtableAdminOperationWait(config *Config, op *bigtableAdminOperation, activity string) error {
+	state := &resource.StateChangeConf{
+		Pending: []string{"false"},
+		Target:  []string{"true"},
+		Refresh: func() (interface{}, string, error) {
+			if op.Done {
+				return op, "true", nil
+			}
+
+			var err error
+			op, err = config.clientBigtableAdmin.GetOperation(op.Name)
+			if err != nil {
+				return nil, "", err
+			}
+
+			log.Printf("[DEBUG] Got %v while polling for operation %s's 'done' status", op.Done, op.Name)
+			return op, fmt.Sprint(op.Done), nil
+		},
+		Delay:      time.Second,
+		Timeout:    10 * time.Minute,
+		MinTimeout: 2 * time.Second,
+	}
+	if _, err := state.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for %s: %s", activity, err)
+	}
+
+	if op.Error != nil {
+		return fmt.Errorf("Error code %v, message: %s", op.Error.Code, op.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const cloudFunctionsBasePath = "https://cloudfunctions.googleapis.com/"
+
+// cloudFunctionsClient is a minimal client for the Cloud Functions API v1,
+// which has no generated client in the vendored google.golang.org/api.
+type cloudFunctionsClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type cloudFunction struct {
+	Name                 string                     `json:"name,omitempty"`
+	Description          string                     `json:"description,omitempty"`
+	SourceArchiveUrl     string                     `json:"sourceArchiveUrl,omitempty"`
+	EntryPoint           string                     `json:"entryPoint,omitempty"`
+	Runtime              string                     `json:"runtime,omitempty"`
+	Timeout              string                     `json:"timeout,omitempty"`
+	AvailableMemoryMb    int                        `json:"availableMemoryMb,omitempty"`
+	ServiceAccountEmail  string                     `json:"serviceAccountEmail,omitempty"`
+	Labels               map[string]string          `json:"labels,omitempty"`
+	EnvironmentVariables map[string]string          `json:"environmentVariables,omitempty"`
+	HttpsTrigger         *cloudFunctionHttpsTrigger `json:"httpsTrigger,omitempty"`
+	EventTrigger         *cloudFunctionEventTrigger `json:"eventTrigger,omitempty"`
+	Status               string                     `json:"status,omitempty"`
+}
+
+type cloudFunctionHttpsTrigger struct {
+	Url string `json:"url,omitempty"`
+}
+
+type cloudFunctionEventTrigger struct {
+	EventType     string                      `json:"eventType,omitempty"`
+	Resource      string                      `json:"resource,omitempty"`
+	FailurePolicy *cloudFunctionFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+type cloudFunctionFailurePolicy struct {
+	Retry *struct{} `json:"retry,omitempty"`
+}
+
+type cloudFunctionsOperation struct {
+	Name  string                        `json:"name,omitempty"`
+	Done  bool                          `json:"done,omitempty"`
+	Error *cloudFunctionsOperationError `json:"error,omitempty"`
+}
+
+type cloudFunctionsOperationError struct {
+	Code    int64  `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// CreateFunction creates function in parent, which has the form
+// projects/{project}/locations/{region}.
+func (c *cloudFunctionsClient) CreateFunction(parent string, function *cloudFunction) (*cloudFunctionsOperation, error) {
+	op := &cloudFunctionsOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", c.BasePath+"v1/"+parent+"/functions", function, op)
+	return op, err
+}
+
+func (c *cloudFunctionsClient) GetFunction(name string) (*cloudFunction, error) {
+	res := &cloudFunction{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+"v1/"+name, nil, res)
+	return res, err
+}
+
+// UpdateFunction sets the fields of the function called name listed in
+// updateMask to their values in function.
+func (c *cloudFunctionsClient) UpdateFunction(name string, function *cloudFunction, updateMask string) (*cloudFunctionsOperation, error) {
+	u := c.BasePath + "v1/" + name + "?" + url.Values{"updateMask": {updateMask}}.Encode()
+	op := &cloudFunctionsOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PATCH", u, function, op)
+	return op, err
+}
+
+func (c *cloudFunctionsClient) DeleteFunction(name string) (*cloudFunctionsOperation, error) {
+	op := &cloudFunctionsOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "DELETE", c.BasePath+"v1/"+name, nil, op)
+	return op, err
+}
+
+func (c *cloudFunctionsClient) GetOperation(name string) (*cloudFunctionsOperation, error) {
+	op := &cloudFunctionsOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+"v1/"+name, nil, op)
+	return op, err
+}
+
+func cloudFunctionsOperationWait(config *Config, op *cloudFunctionsOperation, activity string, timeoutMin int) error {
+	state := &resource.StateChangeConf{
+		Pending: []string{"false"},
+		Target:  []string{"true"},
+		Refresh: func() (interface{}, string, error) {
+			if op.Done {
+				return op, "true", nil
+			}
+
+			var err error
+			op, err = config.clientCloudFunctions.GetOperation(op.Name)
+			if err != nil {
+				return nil, "", err
+			}
+
+			log.Printf("[DEBUG] Got %v while polling for operation %s's 'done' status", op.Done, op.Name)
+			return op, fmt.Sprint(op.Done), nil
+		},
+		Delay:      time.Second,
+		Timeout:    time.Duration(timeoutMin) * time.Minute,
+		MinTimeout: 2 * time.Second,
+	}
+	if _, err := state.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for %s: %s", activity, err)
+	}
+
+	if op.Error != nil {
+		return fmt.Errorf("Error code %v, message: %s", op.Error.Code, op.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package google
+
+import (
+	"net/http"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// computeResourcePoliciesClient is a minimal client for the resource policy
+// calls of the Compute Engine API, which the vendored compute/v1 client
+// predates.
+type computeResourcePoliciesClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type computeResourcePolicy struct {
+	Description            string                                 `json:"description,omitempty"`
+	InstanceSchedulePolicy *computeResourcePolicyInstanceSchedule `json:"instanceSchedulePolicy,omitempty"`
+	Name                   string                                 `json:"name,omitempty"`
+	Region                 string                                 `json:"region,omitempty"`
+	SelfLink               string                                 `json:"selfLink,omitempty"`
+	SnapshotSchedulePolicy *computeResourcePolicySnapshotSchedule `json:"snapshotSchedulePolicy,omitempty"`
+	Status                 string                                 `json:"status,omitempty"`
+}
+
+type computeResourcePolicySnapshotSchedule struct {
+	RetentionPolicy    *computeResourcePolicySnapshotRetention  `json:"retentionPolicy,omitempty"`
+	Schedule           *computeResourcePolicySchedule           `json:"schedule,omitempty"`
+	SnapshotProperties *computeResourcePolicySnapshotProperties `json:"snapshotProperties,omitempty"`
+}
+
+type computeResourcePolicySchedule struct {
+	DailySchedule  *computeResourcePolicyDailyCycle  `json:"dailySchedule,omitempty"`
+	HourlySchedule *computeResourcePolicyHourlyCycle `json:"hourlySchedule,omitempty"`
+	WeeklySchedule *computeResourcePolicyWeeklyCycle `json:"weeklySchedule,omitempty"`
+}
+
+type computeResourcePolicyDailyCycle struct {
+	DaysInCycle int64  `json:"daysInCycle,omitempty"`
+	StartTime   string `json:"startTime,omitempty"`
+}
+
+type computeResourcePolicyHourlyCycle struct {
+	HoursInCycle int64  `json:"hoursInCycle,omitempty"`
+	StartTime    string `json:"startTime,omitempty"`
+}
+
+type computeResourcePolicyWeeklyCycle struct {
+	DayOfWeeks []*computeResourcePolicyWeeklyCycleDayOfWeek `json:"dayOfWeeks,omitempty"`
+}
+
+type computeResourcePolicyWeeklyCycleDayOfWeek struct {
+	Day       string `json:"day,omitempty"`
+	StartTime string `json:"startTime,omitempty"`
+}
+
+type computeResourcePolicySnapshotRetention struct {
+	MaxRetentionDays   int64  `json:"maxRetentionDays,omitempty"`
+	OnSourceDiskDelete string `json:"onSourceDiskDelete,omitempty"`
+}
+
+type computeResourcePolicySnapshotProperties struct {
+	GuestFlush       bool              `json:"guestFlush,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	StorageLocations []string          `json:"storageLocations,omitempty"`
+}
+
+type computeResourcePolicyInstanceSchedule struct {
+	ExpirationTime  string                                     `json:"expirationTime,omitempty"`
+	StartTime       string                                     `json:"startTime,omitempty"`
+	TimeZone        string                                     `json:"timeZone,omitempty"`
+	VmStartSchedule *computeResourcePolicyInstanceScheduleCron `json:"vmStartSchedule,omitempty"`
+	VmStopSchedule  *computeResourcePolicyInstanceScheduleCron `json:"vmStopSchedule,omitempty"`
+}
+
+type computeResourcePolicyInstanceScheduleCron struct {
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type computeResourcePolicyAttachments struct {
+	ResourcePolicies []string `json:"resourcePolicies,omitempty"`
+}
+
+func (c *computeResourcePoliciesClient) Insert(project, region string, policy *computeResourcePolicy) (*compute.Operation, error) {
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", c.BasePath+project+"/regions/"+region+"/resourcePolicies", policy, op)
+	return op, err
+}
+
+func (c *computeResourcePoliciesClient) Get(project, region, name string) (*computeResourcePolicy, error) {
+	res := &computeResourcePolicy{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.policyUrl(project, region, name), nil, res)
+	return res, err
+}
+
+func (c *computeResourcePoliciesClient) Delete(project, region, name string) (*compute.Operation, error) {
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "DELETE", c.policyUrl(project, region, name), nil, op)
+	return op, err
+}
+
+// GetAttached returns the URLs of the policies attached to resource, a zonal
+// disk or instance, e.g. "disks/my-disk".
+func (c *computeResourcePoliciesClient) GetAttached(project, zone, resource string) ([]string, error) {
+	res := &computeResourcePolicyAttachments{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+project+"/zones/"+zone+"/"+resource, nil, res)
+	return res.ResourcePolicies, err
+}
+
+func (c *computeResourcePoliciesClient) Attach(project, zone, resource, policyUrl string) (*compute.Operation, error) {
+	return c.changeAttached(project, zone, resource, "addResourcePolicies", policyUrl)
+}
+
+func (c *computeResourcePoliciesClient) Detach(project, zone, resource, policyUrl string) (*compute.Operation, error) {
+	return c.changeAttached(project, zone, resource, "removeResourcePolicies", policyUrl)
+}
+
+func (c *computeResourcePoliciesClient) changeAttached(project, zone, resource, method, policyUrl string) (*compute.Operation, error) {
+	body := &computeResourcePolicyAttachments{ResourcePolicies: []string{policyUrl}}
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", c.BasePath+project+"/zones/"+zone+"/"+resource+"/"+method, body, op)
+	return op, err
+}
+
+func (c *computeResourcePoliciesClient) policyUrl(project, region, name string) string {
+	return c.BasePath + project + "/regions/" + region + "/resourcePolicies/" + name
+}
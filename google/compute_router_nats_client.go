@@ -0,0 +1,62 @@
+package google
+
+import (
+	"net/http"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// computeRouterNatsClient reads and patches the Cloud NAT gateways of a
+// Cloud Router, which the vendored compute/v1 client predates.
+type computeRouterNatsClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type computeRouterNat struct {
+	Name                          string                        `json:"name,omitempty"`
+	NatIpAllocateOption           string                        `json:"natIpAllocateOption,omitempty"`
+	NatIps                        []string                      `json:"natIps,omitempty"`
+	SourceSubnetworkIpRangesToNat string                        `json:"sourceSubnetworkIpRangesToNat,omitempty"`
+	Subnetworks                   []*computeRouterNatSubnetwork `json:"subnetworks,omitempty"`
+	MinPortsPerVm                 int64                         `json:"minPortsPerVm,omitempty"`
+	UdpIdleTimeoutSec             int64                         `json:"udpIdleTimeoutSec,omitempty"`
+	IcmpIdleTimeoutSec            int64                         `json:"icmpIdleTimeoutSec,omitempty"`
+	TcpEstablishedIdleTimeoutSec  int64                         `json:"tcpEstablishedIdleTimeoutSec,omitempty"`
+	TcpTransitoryIdleTimeoutSec   int64                         `json:"tcpTransitoryIdleTimeoutSec,omitempty"`
+}
+
+type computeRouterNatSubnetwork struct {
+	Name                  string   `json:"name,omitempty"`
+	SourceIpRangesToNat   []string `json:"sourceIpRangesToNat,omitempty"`
+	SecondaryIpRangeNames []string `json:"secondaryIpRangeNames,omitempty"`
+}
+
+type computeRouterNats struct {
+	// Nats isn't omitted when empty, so that patching with no NATs removes
+	// the last one.
+	Nats []*computeRouterNat `json:"nats"`
+}
+
+// Get returns the NATs configured on router.
+func (c *computeRouterNatsClient) Get(project, region, router string) ([]*computeRouterNat, error) {
+	res := &computeRouterNats{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.routerUrl(project, region, router), nil, res)
+	return res.Nats, err
+}
+
+// Patch replaces the NATs configured on router, leaving its other fields as
+// they are.
+func (c *computeRouterNatsClient) Patch(project, region, router string, nats []*computeRouterNat) (*compute.Operation, error) {
+	if nats == nil {
+		nats = []*computeRouterNat{}
+	}
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PATCH", c.routerUrl(project, region, router), &computeRouterNats{Nats: nats}, op)
+	return op, err
+}
+
+func (c *computeRouterNatsClient) routerUrl(project, region, router string) string {
+	return c.BasePath + project + "/regions/" + region + "/routers/" + router
+}
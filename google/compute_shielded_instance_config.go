@@ -0,0 +1,178 @@
+package google
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	computeBeta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// The vendored compute clients predate Shielded VMs, so instances and
+// instance templates with a shielded_instance_config are inserted as raw
+// JSON, and read as raw JSON decoded into both the generated type and the
+// shielded config.
+
+type computeShieldedInstanceConfig struct {
+	EnableSecureBoot          bool `json:"enableSecureBoot"`
+	EnableVtpm                bool `json:"enableVtpm"`
+	EnableIntegrityMonitoring bool `json:"enableIntegrityMonitoring"`
+}
+
+func computeShieldedInstanceConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enable_secure_boot": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					ForceNew: true,
+					Default:  false,
+				},
+				"enable_vtpm": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					ForceNew: true,
+					Default:  true,
+				},
+				"enable_integrity_monitoring": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					ForceNew: true,
+					Default:  true,
+				},
+			},
+		},
+	}
+}
+
+func expandComputeShieldedInstanceConfig(configured []interface{}) *computeShieldedInstanceConfig {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+
+	raw := configured[0].(map[string]interface{})
+	return &computeShieldedInstanceConfig{
+		EnableSecureBoot:          raw["enable_secure_boot"].(bool),
+		EnableVtpm:                raw["enable_vtpm"].(bool),
+		EnableIntegrityMonitoring: raw["enable_integrity_monitoring"].(bool),
+	}
+}
+
+func flattenComputeShieldedInstanceConfig(c *computeShieldedInstanceConfig) []map[string]interface{} {
+	if c == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"enable_secure_boot":          c.EnableSecureBoot,
+			"enable_vtpm":                 c.EnableVtpm,
+			"enable_integrity_monitoring": c.EnableIntegrityMonitoring,
+		},
+	}
+}
+
+// insertComputeInstanceWithShieldedConfig inserts instance, one of the
+// generated Instance types, with shielded set. basePath picks the API
+// version.
+func insertComputeInstanceWithShieldedConfig(config *Config, basePath, project, zone string, instance interface{}, shielded *computeShieldedInstanceConfig) (*compute.Operation, error) {
+	body, err := toJsonMap(instance)
+	if err != nil {
+		return nil, err
+	}
+	body["shieldedInstanceConfig"] = shielded
+
+	op := &compute.Operation{}
+	err = sendJsonRequest(config.client, config.clientCompute.UserAgent, "POST", basePath+project+"/zones/"+zone+"/instances", body, op)
+	return op, err
+}
+
+// getComputeInstanceWithShieldedConfig gets an instance and its shielded
+// config in a single call. basePath picks the API version.
+func getComputeInstanceWithShieldedConfig(config *Config, basePath, project, zone, name string) (*computeBeta.Instance, *computeShieldedInstanceConfig, error) {
+	var raw json.RawMessage
+	err := sendJsonRequest(config.client, config.clientCompute.UserAgent, "GET", basePath+project+"/zones/"+zone+"/instances/"+name, nil, &raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instance := &computeBeta.Instance{}
+	if err := json.Unmarshal(raw, instance); err != nil {
+		return nil, nil, err
+	}
+	shielded := &struct {
+		ShieldedInstanceConfig *computeShieldedInstanceConfig `json:"shieldedInstanceConfig"`
+	}{}
+	if err := json.Unmarshal(raw, shielded); err != nil {
+		return nil, nil, err
+	}
+
+	return instance, shielded.ShieldedInstanceConfig, nil
+}
+
+// insertComputeInstanceTemplateWithShieldedConfig inserts template with
+// shielded set on its instance properties.
+func insertComputeInstanceTemplateWithShieldedConfig(config *Config, project string, template *compute.InstanceTemplate, shielded *computeShieldedInstanceConfig) (*compute.Operation, error) {
+	body, err := toJsonMap(template)
+	if err != nil {
+		return nil, err
+	}
+	properties, ok := body["properties"].(map[string]interface{})
+	if !ok {
+		properties = map[string]interface{}{}
+		body["properties"] = properties
+	}
+	properties["shieldedInstanceConfig"] = shielded
+
+	op := &compute.Operation{}
+	err = sendJsonRequest(config.client, config.clientCompute.UserAgent, "POST", config.clientCompute.BasePath+project+"/global/instanceTemplates", body, op)
+	return op, err
+}
+
+// getComputeInstanceTemplateWithShieldedConfig gets an instance template
+// and the shielded config of its instance properties in a single call.
+func getComputeInstanceTemplateWithShieldedConfig(config *Config, project, name string) (*compute.InstanceTemplate, *computeShieldedInstanceConfig, error) {
+	var raw json.RawMessage
+	err := sendJsonRequest(config.client, config.clientCompute.UserAgent, "GET", config.clientCompute.BasePath+project+"/global/instanceTemplates/"+name, nil, &raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &compute.InstanceTemplate{}
+	if err := json.Unmarshal(raw, template); err != nil {
+		return nil, nil, err
+	}
+	shielded := &struct {
+		Properties *struct {
+			ShieldedInstanceConfig *computeShieldedInstanceConfig `json:"shieldedInstanceConfig"`
+		} `json:"properties"`
+	}{}
+	if err := json.Unmarshal(raw, shielded); err != nil {
+		return nil, nil, err
+	}
+	if shielded.Properties == nil {
+		return template, nil, nil
+	}
+
+	return template, shielded.Properties.ShieldedInstanceConfig, nil
+}
+
+// toJsonMap returns v as it's sent to the API, so fields the generated
+// types don't have can be added to it.
+func toJsonMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,60 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func TestComputeShieldedInstanceConfigExpandFlatten(t *testing.T) {
+	t.Parallel()
+
+	if c := expandComputeShieldedInstanceConfig(nil); c != nil {
+		t.Errorf("Expected no config when unset, got %+v", c)
+	}
+
+	configured := []interface{}{
+		map[string]interface{}{
+			"enable_secure_boot":          true,
+			"enable_vtpm":                 false,
+			"enable_integrity_monitoring": true,
+		},
+	}
+	c := expandComputeShieldedInstanceConfig(configured)
+	expected := &computeShieldedInstanceConfig{EnableSecureBoot: true, EnableIntegrityMonitoring: true}
+	if !reflect.DeepEqual(c, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, c)
+	}
+
+	flattened := flattenComputeShieldedInstanceConfig(c)
+	if !reflect.DeepEqual(flattened[0], configured[0]) {
+		t.Errorf("Expected %v, got %v", configured[0], flattened[0])
+	}
+}
+
+func TestToJsonMap(t *testing.T) {
+	t.Parallel()
+
+	m, err := toJsonMap(&compute.InstanceTemplate{
+		Name: "template-1",
+		Properties: &compute.InstanceProperties{
+			MachineType: "n1-standard-1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if m["name"] != "template-1" {
+		t.Errorf("Expected name template-1, got %v", m["name"])
+	}
+	properties, ok := m["properties"].(map[string]interface{})
+	if !ok || properties["machineType"] != "n1-standard-1" {
+		t.Errorf("Expected properties with machineType n1-standard-1, got %v", m["properties"])
+	}
+	// Empty fields are omitted, as they are by the generated clients.
+	if _, ok := m["description"]; ok {
+		t.Errorf("Expected description to be omitted, got %v", m["description"])
+	}
+}
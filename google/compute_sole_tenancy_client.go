@@ -0,0 +1,104 @@
+package google
+
+import (
+	"net/http"
+	"strconv"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// computeSoleTenancyClient is a minimal client for the node template and
+// node group calls of the Compute Engine API, which the vendored compute/v1
+// client predates.
+type computeSoleTenancyClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type computeNodeTemplate struct {
+	CreationTimestamp   string                          `json:"creationTimestamp,omitempty"`
+	Description         string                          `json:"description,omitempty"`
+	Name                string                          `json:"name,omitempty"`
+	NodeAffinityLabels  map[string]string               `json:"nodeAffinityLabels,omitempty"`
+	NodeType            string                          `json:"nodeType,omitempty"`
+	NodeTypeFlexibility *computeNodeTemplateFlexibility `json:"nodeTypeFlexibility,omitempty"`
+	Region              string                          `json:"region,omitempty"`
+	SelfLink            string                          `json:"selfLink,omitempty"`
+	Status              string                          `json:"status,omitempty"`
+	StatusMessage       string                          `json:"statusMessage,omitempty"`
+}
+
+type computeNodeTemplateFlexibility struct {
+	Cpus     string `json:"cpus,omitempty"`
+	LocalSsd string `json:"localSsd,omitempty"`
+	Memory   string `json:"memory,omitempty"`
+}
+
+type computeNodeGroup struct {
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+	Description       string `json:"description,omitempty"`
+	Name              string `json:"name,omitempty"`
+	NodeTemplate      string `json:"nodeTemplate,omitempty"`
+	SelfLink          string `json:"selfLink,omitempty"`
+	Size              int64  `json:"size,omitempty"`
+	Status            string `json:"status,omitempty"`
+	Zone              string `json:"zone,omitempty"`
+}
+
+func (c *computeSoleTenancyClient) InsertNodeTemplate(project, region string, template *computeNodeTemplate) (*compute.Operation, error) {
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", c.BasePath+project+"/regions/"+region+"/nodeTemplates", template, op)
+	return op, err
+}
+
+func (c *computeSoleTenancyClient) GetNodeTemplate(project, region, name string) (*computeNodeTemplate, error) {
+	res := &computeNodeTemplate{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.nodeTemplateUrl(project, region, name), nil, res)
+	return res, err
+}
+
+func (c *computeSoleTenancyClient) DeleteNodeTemplate(project, region, name string) (*compute.Operation, error) {
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "DELETE", c.nodeTemplateUrl(project, region, name), nil, op)
+	return op, err
+}
+
+// InsertNodeGroup creates group with initialNodeCount nodes, which the API
+// takes as a query parameter rather than from the group's size.
+func (c *computeSoleTenancyClient) InsertNodeGroup(project, zone string, initialNodeCount int64, group *computeNodeGroup) (*compute.Operation, error) {
+	u := c.BasePath + project + "/zones/" + zone + "/nodeGroups?initialNodeCount=" + strconv.FormatInt(initialNodeCount, 10)
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", u, group, op)
+	return op, err
+}
+
+func (c *computeSoleTenancyClient) GetNodeGroup(project, zone, name string) (*computeNodeGroup, error) {
+	res := &computeNodeGroup{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.nodeGroupUrl(project, zone, name), nil, res)
+	return res, err
+}
+
+// SetNodeGroupNodeTemplate changes the template of group. Existing nodes keep
+// the old template until they're recreated.
+func (c *computeSoleTenancyClient) SetNodeGroupNodeTemplate(project, zone, name, nodeTemplate string) (*compute.Operation, error) {
+	body := map[string]string{"nodeTemplate": nodeTemplate}
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", c.nodeGroupUrl(project, zone, name)+"/setNodeTemplate", body, op)
+	return op, err
+}
+
+func (c *computeSoleTenancyClient) DeleteNodeGroup(project, zone, name string) (*compute.Operation, error) {
+	op := &compute.Operation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "DELETE", c.nodeGroupUrl(project, zone, name), nil, op)
+	return op, err
+}
+
+// nodeTemplateUrl is also the form node groups reference their template by.
+func (c *computeSoleTenancyClient) nodeTemplateUrl(project, region, name string) string {
+	return c.BasePath + project + "/regions/" + region + "/nodeTemplates/" + name
+}
+
+func (c *computeSoleTenancyClient) nodeGroupUrl(project, zone, name string) string {
+	return c.BasePath + project + "/zones/" + zone + "/nodeGroups/" + name
+}
@@ -8,8 +8,8 @@ import (
 	"net/http"
 	"runtime"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/hashicorp/terraform/helper/pathorcontents"
 	"github.com/hashicorp/terraform/terraform"
 
@@ -26,6 +26,7 @@ import (
 	"google.golang.org/api/container/v1"
 	"google.golang.org/api/dataproc/v1"
 	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iam/v1"
 	cloudlogging "google.golang.org/api/logging/v2"
 	"google.golang.org/api/pubsub/v1"
@@ -37,15 +38,70 @@ import (
 	"google.golang.org/api/storage/v1"
 )
 
+var defaultClientScopes = []string{
+	"https://www.googleapis.com/auth/compute",
+	"https://www.googleapis.com/auth/cloud-platform",
+	"https://www.googleapis.com/auth/ndev.clouddns.readwrite",
+	"https://www.googleapis.com/auth/devstorage.full_control",
+	"https://www.googleapis.com/auth/userinfo.email",
+}
+
 // Config is the configuration structure used to instantiate the Google
 // provider.
 type Config struct {
 	Credentials string
 	Project     string
 	Region      string
+	Zone        string
+
+	// RequestReason is sent as the X-Goog-Request-Reason header, which shows
+	// up in Cloud Audit Logs, and UserAgentSuffix is appended to the
+	// User-Agent of all API calls.
+	RequestReason   string
+	UserAgentSuffix string
+
+	// Scopes overrides the OAuth scopes requested for the credentials.
+	Scopes []string
+
+	// QuotaProject, if set, is sent as the X-Goog-User-Project header so
+	// that API calls are billed and quota'd against it.
+	QuotaProject string
+
+	// ImpersonateServiceAccount, if set, is the email of a service account
+	// whose short-lived access tokens are used for every API call instead of
+	// the credentials' own. ImpersonateServiceAccountDelegates is the chain
+	// of service accounts to go through to get there, if any.
+	ImpersonateServiceAccount          string
+	ImpersonateServiceAccountDelegates []string
+
+	// RetryPolicy is used to retry failed API calls for all clients. Configs
+	// without one use defaultRetryPolicy.
+	RetryPolicy *retryPolicy
+
+	// LogHTTPRequests logs all API requests and responses at INFO level,
+	// rather than only when TF_LOG is DEBUG or TRACE.
+	LogHTTPRequests bool
+
+	// AuditLogFile, if set, is a file every mutating API call is appended
+	// to as a line of JSON.
+	AuditLogFile string
+
+	// RequiredLabels must be set on every resource supporting them.
+	RequiredLabels []string
+
+	// dataprocClusterOperations, if set, limits how many Dataproc cluster
+	// operations run at once in each project and region.
+	dataprocClusterOperations *SemaphoreKV
+
+	// HonorSkipRefreshLabel makes resources labelled with
+	// terraform-refresh=skip keep their state as is on refresh.
+	HonorSkipRefreshLabel bool
 
 	clientBilling                *cloudbilling.Service
 	clientCompute                *compute.Service
+	clientComputeRouterNats      *computeRouterNatsClient
+	clientComputeSoleTenancy     *computeSoleTenancyClient
+	clientResourcePolicies       *computeResourcePoliciesClient
 	clientComputeBeta            *computeBeta.Service
 	clientContainer              *container.Service
 	clientDataproc               *dataproc.Service
@@ -53,6 +109,8 @@ type Config struct {
 	clientKms                    *cloudkms.Service
 	clientLogging                *cloudlogging.Service
 	clientPubsub                 *pubsub.Service
+	clientPubsubSubscriptions    *pubsubSubscriptionsClient
+	clientCloudFunctions         *cloudFunctionsClient
 	clientResourceManager        *cloudresourcemanager.Service
 	clientResourceManagerV2Beta1 *resourceManagerV2Beta1.Service
 	clientRuntimeconfig          *runtimeconfig.Service
@@ -62,18 +120,29 @@ type Config struct {
 	clientSqlAdmin               *sqladmin.Service
 	clientIAM                    *iam.Service
 	clientServiceMan             *servicemanagement.APIService
+	clientServiceUsage           *serviceUsageClient
 	clientBigQuery               *bigquery.Service
+	clientBigQueryDataTransfer   *bigqueryDataTransferClient
+	clientStorageTransfer        *storageTransferClient
+	clientStorageHmacKeys        *storageHmacKeysClient
+	clientBigtableAdmin          *bigtableAdminClient
+	clientMonitoring             *monitoringClient
 
 	bigtableClientFactory *BigtableClientFactory
+
+	// client is the authenticated HTTP client the API clients above are
+	// built on, for the few endpoints which have no generated client.
+	client *http.Client
+
+	// tokenSource issues the access tokens client authenticates with.
+	tokenSource oauth2.TokenSource
 }
 
 func (c *Config) loadAndValidate() error {
 	var account accountFile
-	clientScopes := []string{
-		"https://www.googleapis.com/auth/compute",
-		"https://www.googleapis.com/auth/cloud-platform",
-		"https://www.googleapis.com/auth/ndev.clouddns.readwrite",
-		"https://www.googleapis.com/auth/devstorage.full_control",
+	clientScopes := defaultClientScopes
+	if len(c.Scopes) > 0 {
+		clientScopes = c.Scopes
 	}
 
 	var client *http.Client
@@ -123,11 +192,68 @@ func (c *Config) loadAndValidate() error {
 		}
 	}
 
-	client.Transport = logging.NewTransport("Google", client.Transport)
+	if c.ImpersonateServiceAccount != "" {
+		log.Printf("[INFO] Impersonating service account %s", c.ImpersonateServiceAccount)
+		tokenSource = oauth2.ReuseTokenSource(nil, &impersonatedTokenSource{
+			client:    client,
+			endpoint:  iamCredentialsBasePath,
+			target:    c.ImpersonateServiceAccount,
+			delegates: c.ImpersonateServiceAccountDelegates,
+			scopes:    clientScopes,
+		})
+		client = oauth2.NewClient(context.Background(), tokenSource)
+	}
+
+	client.Transport = &loggingTransport{
+		name:  "Google",
+		force: c.LogHTTPRequests,
+		base:  client.Transport,
+	}
+
+	if c.AuditLogFile != "" {
+		auditLog, err := openAuditLog(c.AuditLogFile)
+		if err != nil {
+			return err
+		}
+		client.Transport = &auditTransport{
+			log:  auditLog,
+			base: client.Transport,
+		}
+	}
+
+	retry := defaultRetryPolicy()
+	if c.RetryPolicy != nil {
+		retry = *c.RetryPolicy
+	}
+	client.Transport = &retryTransport{
+		policy: retry,
+		base:   client.Transport,
+	}
+
+	headers := http.Header{}
+	if c.QuotaProject != "" {
+		log.Printf("[INFO] Using %s as the quota project", c.QuotaProject)
+		headers.Set("X-Goog-User-Project", c.QuotaProject)
+	}
+	if c.RequestReason != "" {
+		headers.Set("X-Goog-Request-Reason", c.RequestReason)
+	}
+	if len(headers) > 0 {
+		client.Transport = &headerTransport{
+			headers: headers,
+			base:    client.Transport,
+		}
+	}
+
+	c.client = client
+	c.tokenSource = tokenSource
 
 	versionString := terraform.VersionString()
 	userAgent := fmt.Sprintf(
 		"(%s %s) Terraform/%s", runtime.GOOS, runtime.GOARCH, versionString)
+	if c.UserAgentSuffix != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, c.UserAgentSuffix)
+	}
 
 	var err error
 
@@ -138,6 +264,24 @@ func (c *Config) loadAndValidate() error {
 	}
 	c.clientCompute.UserAgent = userAgent
 
+	c.clientComputeRouterNats = &computeRouterNatsClient{
+		client:    client,
+		BasePath:  c.clientCompute.BasePath,
+		UserAgent: userAgent,
+	}
+
+	c.clientComputeSoleTenancy = &computeSoleTenancyClient{
+		client:    client,
+		BasePath:  c.clientCompute.BasePath,
+		UserAgent: userAgent,
+	}
+
+	c.clientResourcePolicies = &computeResourcePoliciesClient{
+		client:    client,
+		BasePath:  c.clientCompute.BasePath,
+		UserAgent: userAgent,
+	}
+
 	log.Printf("[INFO] Instantiating GCE Beta client...")
 	c.clientComputeBeta, err = computeBeta.New(client)
 	if err != nil {
@@ -180,6 +324,19 @@ func (c *Config) loadAndValidate() error {
 	}
 	c.clientStorage.UserAgent = userAgent
 
+	c.clientStorageHmacKeys = &storageHmacKeysClient{
+		client:    client,
+		BasePath:  c.clientStorage.BasePath,
+		UserAgent: userAgent,
+	}
+
+	log.Printf("[INFO] Instantiating Google Storage Transfer Client...")
+	c.clientStorageTransfer = &storageTransferClient{
+		client:    client,
+		BasePath:  storageTransferBasePath,
+		UserAgent: userAgent,
+	}
+
 	log.Printf("[INFO] Instantiating Google SqlAdmin Client...")
 	c.clientSqlAdmin, err = sqladmin.New(client)
 	if err != nil {
@@ -194,6 +351,18 @@ func (c *Config) loadAndValidate() error {
 	}
 	c.clientPubsub.UserAgent = userAgent
 
+	c.clientPubsubSubscriptions = &pubsubSubscriptionsClient{
+		client:    client,
+		BasePath:  pubsubSubscriptionsBasePath,
+		UserAgent: userAgent,
+	}
+
+	c.clientCloudFunctions = &cloudFunctionsClient{
+		client:    client,
+		BasePath:  cloudFunctionsBasePath,
+		UserAgent: userAgent,
+	}
+
 	log.Printf("[INFO] Instantiating Google Cloud ResourceManager Client...")
 	c.clientResourceManager, err = cloudresourcemanager.New(client)
 	if err != nil {
@@ -229,6 +398,13 @@ func (c *Config) loadAndValidate() error {
 	}
 	c.clientServiceMan.UserAgent = userAgent
 
+	log.Printf("[INFO] Instantiating Google Cloud Service Usage Client...")
+	c.clientServiceUsage = &serviceUsageClient{
+		client:    client,
+		BasePath:  serviceUsageBasePath,
+		UserAgent: userAgent,
+	}
+
 	log.Printf("[INFO] Instantiating Google Cloud Billing Client...")
 	c.clientBilling, err = cloudbilling.New(client)
 	if err != nil {
@@ -243,11 +419,29 @@ func (c *Config) loadAndValidate() error {
 	}
 	c.clientBigQuery.UserAgent = userAgent
 
+	c.clientBigQueryDataTransfer = &bigqueryDataTransferClient{
+		client:    client,
+		BasePath:  bigqueryDataTransferBasePath,
+		UserAgent: userAgent,
+	}
+
 	c.bigtableClientFactory = &BigtableClientFactory{
 		UserAgent:   userAgent,
 		TokenSource: tokenSource,
 	}
 
+	c.clientBigtableAdmin = &bigtableAdminClient{
+		client:    client,
+		BasePath:  bigtableAdminBasePath,
+		UserAgent: userAgent,
+	}
+
+	c.clientMonitoring = &monitoringClient{
+		client:    client,
+		BasePath:  monitoringBasePath,
+		UserAgent: userAgent,
+	}
+
 	log.Printf("[INFO] Instantiating Google Cloud Source Repo Client...")
 	c.clientSourceRepo, err = sourcerepo.New(client)
 	if err != nil {
@@ -272,6 +466,130 @@ func (c *Config) loadAndValidate() error {
 	return nil
 }
 
+// preflightCheck verifies the credentials are able to make the calls most
+// resources depend on in the configured project, so permission problems
+// surface when the provider is configured instead of part way through an
+// apply.
+func (c *Config) preflightCheck() error {
+	if c.Project == "" {
+		log.Printf("[WARN] Skipping preflight check, no project is configured")
+		return nil
+	}
+
+	log.Printf("[INFO] Running preflight check against project %q", c.Project)
+	if _, err := c.clientDataproc.Projects.Regions.Clusters.List(c.Project, c.Region).PageSize(1).Do(); err != nil {
+		return preflightError(err, fmt.Sprintf("list Dataproc clusters in project %q, region %q", c.Project, c.Region), "roles/dataproc.viewer")
+	}
+
+	if _, err := c.clientStorage.Buckets.List(c.Project).MaxResults(1).Do(); err != nil {
+		return preflightError(err, fmt.Sprintf("list Storage buckets in project %q", c.Project), "roles/storage.admin")
+	}
+
+	return nil
+}
+
+func preflightError(err error, action, role string) error {
+	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == http.StatusForbidden {
+		return fmt.Errorf("Preflight check failed: the configured credentials are not allowed to %s. "+
+			"Make sure the API is enabled and grant the credentials %s (or a role containing the same permissions): %s", action, role, gerr.Message)
+	}
+	return fmt.Errorf("Preflight check failed: unable to %s: %s", action, err)
+}
+
+// headerTransport sets headers, such as X-Goog-User-Project, on every request.
+type headerTransport struct {
+	headers http.Header
+	base    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request they are given
+	r := *req
+	r.Header = make(http.Header, len(req.Header)+len(t.headers))
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+	for k, v := range t.headers {
+		r.Header[k] = v
+	}
+
+	return t.base.RoundTrip(&r)
+}
+
+const iamCredentialsBasePath = "https://iamcredentials.googleapis.com/"
+
+// impersonatedTokenSource gets access tokens for a service account from the
+// IAM Credentials generateAccessToken method, authenticating with client.
+type impersonatedTokenSource struct {
+	client    *http.Client
+	endpoint  string
+	target    string
+	delegates []string
+	scopes    []string
+	// lifetime of the tokens, as a duration such as "3600s". Defaults to an
+	// hour.
+	lifetime string
+}
+
+func (ts *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	delegates := make([]string, 0, len(ts.delegates))
+	for _, d := range ts.delegates {
+		delegates = append(delegates, serviceAccountFQN(d))
+	}
+
+	lifetime := ts.lifetime
+	if lifetime == "" {
+		lifetime = "3600s"
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"delegates": delegates,
+		"scope":     ts.scopes,
+		"lifetime":  lifetime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%sv1/%s:generateAccessToken", ts.endpoint, serviceAccountFQN(ts.target))
+	res, err := ts.client.Post(url, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("Error impersonating service account %s: %s", ts.target, err)
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return nil, fmt.Errorf("Error impersonating service account %s: %s", ts.target, err)
+	}
+
+	var token struct {
+		AccessToken string `json:"accessToken"`
+		ExpireTime  string `json:"expireTime"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("Error impersonating service account %s: %s", ts.target, err)
+	}
+
+	expiry, err := time.Parse(time.RFC3339, token.ExpireTime)
+	if err != nil {
+		return nil, fmt.Errorf("Error impersonating service account %s: invalid expireTime %q", ts.target, token.ExpireTime)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
+}
+
+// serviceAccountFQN returns the resource name IAM Credentials expects for a
+// service account given either its email or its full name.
+func serviceAccountFQN(account string) string {
+	if strings.HasPrefix(account, "projects/") {
+		return account
+	}
+	return "projects/-/serviceAccounts/" + account
+}
+
 // accountFile represents the structure of the account file JSON file.
 type accountFile struct {
 	PrivateKeyId string `json:"private_key_id"`
@@ -1,8 +1,16 @@
 package google
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
 )
 
 const testFakeCredentialsPath = "./test-fixtures/fake_account.json"
@@ -48,3 +56,160 @@ func TestConfigLoadAndValidate_accountFileJSONInvalid(t *testing.T) {
 		t.Fatalf("expected error, but got nil")
 	}
 }
+
+func TestConfigLoadAndValidate_customScopes(t *testing.T) {
+	config := Config{
+		Credentials: testFakeCredentialsPath,
+		Project:     "my-gce-project",
+		Region:      "us-central1",
+		Scopes:      []string{"https://www.googleapis.com/auth/compute"},
+	}
+
+	err := config.loadAndValidate()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
+
+func TestConfigLoadAndValidate_defaultRetryPolicy(t *testing.T) {
+	config := Config{
+		Credentials: testFakeCredentialsPath,
+		Project:     "my-gce-project",
+		Region:      "us-central1",
+	}
+
+	if err := config.loadAndValidate(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	transport, ok := config.client.Transport.(*retryTransport)
+	if !ok {
+		t.Fatalf("expected requests to be retried, got transport %T", config.client.Transport)
+	}
+	if transport.policy.MaxRetries != defaultRetryPolicy().MaxRetries {
+		t.Fatalf("expected the default retry policy, got %+v", transport.policy)
+	}
+}
+
+func TestConfigPreflightError(t *testing.T) {
+	err := preflightError(&googleapi.Error{Code: 403, Message: "Permission denied"}, "list Storage buckets", "roles/storage.admin")
+	if !strings.Contains(err.Error(), "grant the credentials roles/storage.admin") {
+		t.Fatalf("expected error to name the missing role, got %q", err)
+	}
+
+	err = preflightError(fmt.Errorf("connection refused"), "list Storage buckets", "roles/storage.admin")
+	if strings.Contains(err.Error(), "roles/storage.admin") {
+		t.Fatalf("expected non-permission error not to name a role, got %q", err)
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("X-Goog-User-Project", "my-quota-project")
+	headers.Set("X-Goog-Request-Reason", "CHANGE-1234")
+	client := &http.Client{
+		Transport: &headerTransport{
+			headers: headers,
+			base:    http.DefaultTransport,
+		},
+	}
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	resp.Body.Close()
+
+	for k, v := range map[string]string{
+		"X-Goog-User-Project":   "my-quota-project",
+		"X-Goog-Request-Reason": "CHANGE-1234",
+		"Accept":                "application/json",
+	} {
+		if header.Get(k) != v {
+			t.Fatalf("expected %s header to be %s, got %q", k, v, header.Get(k))
+		}
+	}
+	if req.Header.Get("X-Goog-User-Project") != "" {
+		t.Fatalf("expected the original request not to be modified")
+	}
+}
+
+func TestImpersonatedTokenSource(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := "/v1/projects/-/serviceAccounts/target@my-project.iam.gserviceaccount.com:generateAccessToken"
+		if r.URL.Path != expected {
+			t.Errorf("Expected request to %s, got %s", expected, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Error decoding request: %s", err)
+		}
+		fmt.Fprint(w, `{"accessToken": "impersonated-token", "expireTime": "2017-12-01T01:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	ts := &impersonatedTokenSource{
+		client:    server.Client(),
+		endpoint:  server.URL + "/",
+		target:    "target@my-project.iam.gserviceaccount.com",
+		delegates: []string{"delegate@my-project.iam.gserviceaccount.com"},
+		scopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+	}
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if token.AccessToken != "impersonated-token" {
+		t.Fatalf("Expected access token impersonated-token, got %q", token.AccessToken)
+	}
+	if expiry := token.Expiry.UTC().Format(time.RFC3339); expiry != "2017-12-01T01:00:00Z" {
+		t.Fatalf("Expected expiry 2017-12-01T01:00:00Z, got %s", expiry)
+	}
+
+	delegates := fmt.Sprint(body["delegates"])
+	if delegates != "[projects/-/serviceAccounts/delegate@my-project.iam.gserviceaccount.com]" {
+		t.Fatalf("Expected delegates to be sent as resource names, got %s", delegates)
+	}
+	if body["lifetime"] != "3600s" {
+		t.Fatalf("Expected the default lifetime of 3600s, got %v", body["lifetime"])
+	}
+
+	ts.lifetime = "300s"
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if body["lifetime"] != "300s" {
+		t.Fatalf("Expected lifetime 300s, got %v", body["lifetime"])
+	}
+}
+
+func TestImpersonatedTokenSource_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"code": 403, "message": "The caller does not have permission"}}`)
+	}))
+	defer server.Close()
+
+	ts := &impersonatedTokenSource{
+		client:   server.Client(),
+		endpoint: server.URL + "/",
+		target:   "target@my-project.iam.gserviceaccount.com",
+	}
+
+	_, err := ts.Token()
+	if err == nil || !strings.Contains(err.Error(), "The caller does not have permission") {
+		t.Fatalf("Expected permission error, got %v", err)
+	}
+}
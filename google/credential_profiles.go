@@ -0,0 +1,76 @@
+package google
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hashicorp/hcl"
+	"github.com/mitchellh/go-homedir"
+)
+
+// defaultProfilesFile is where credential profiles are read from unless the
+// provider is told otherwise.
+const defaultProfilesFile = "~/.config/terraform-provider-google/profiles"
+
+// credentialProfile is a named set of provider settings, read from a file of
+// blocks such as:
+//
+//	profile "dev" {
+//	  credentials = "~/.config/gcloud/dev.json"
+//	  project     = "my-dev-project"
+//	  region      = "us-central1"
+//	}
+type credentialProfile struct {
+	Name string `hcl:",key"`
+
+	Credentials               string `hcl:"credentials"`
+	Project                   string `hcl:"project"`
+	Region                    string `hcl:"region"`
+	ImpersonateServiceAccount string `hcl:"impersonate_service_account"`
+}
+
+// loadCredentialProfile reads the profile called name from the file at path.
+func loadCredentialProfile(path, name string) (*credentialProfile, error) {
+	if path == "" {
+		path = defaultProfilesFile
+	}
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading credential profiles: %s", err)
+	}
+
+	var file struct {
+		Profiles []*credentialProfile `hcl:"profile"`
+	}
+	if err := hcl.Decode(&file, string(contents)); err != nil {
+		return nil, fmt.Errorf("Error parsing credential profiles %s: %s", path, err)
+	}
+
+	for _, profile := range file.Profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+	return nil, fmt.Errorf("Credential profile %q not found in %s", name, path)
+}
+
+// apply fills in the settings of config which haven't been set explicitly.
+func (p *credentialProfile) apply(config *Config) {
+	if config.Credentials == "" {
+		config.Credentials = p.Credentials
+	}
+	if config.Project == "" {
+		config.Project = p.Project
+	}
+	if config.Region == "" {
+		config.Region = p.Region
+	}
+	if config.ImpersonateServiceAccount == "" {
+		config.ImpersonateServiceAccount = p.ImpersonateServiceAccount
+	}
+}
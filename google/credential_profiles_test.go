@@ -0,0 +1,81 @@
+package google
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCredentialProfiles = `
+profile "dev" {
+  credentials = "/keys/dev.json"
+  project     = "dev-project"
+  region      = "us-central1"
+}
+
+profile "prod" {
+  project                     = "prod-project"
+  region                      = "europe-west1"
+  impersonate_service_account = "deployer@prod-project.iam.gserviceaccount.com"
+}
+`
+
+func TestLoadCredentialProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-test-profiles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "profiles")
+	if err := ioutil.WriteFile(path, []byte(testCredentialProfiles), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := loadCredentialProfile(path, "prod")
+	if err != nil {
+		t.Fatalf("Error loading profile: %s", err)
+	}
+	expected := credentialProfile{
+		Name:                      "prod",
+		Project:                   "prod-project",
+		Region:                    "europe-west1",
+		ImpersonateServiceAccount: "deployer@prod-project.iam.gserviceaccount.com",
+	}
+	if *profile != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, *profile)
+	}
+
+	_, err = loadCredentialProfile(path, "stage")
+	if err == nil || !strings.Contains(err.Error(), `Credential profile "stage" not found`) {
+		t.Fatalf("Expected a missing profile error, got: %v", err)
+	}
+
+	_, err = loadCredentialProfile(filepath.Join(dir, "missing"), "dev")
+	if err == nil {
+		t.Fatal("Expected an error reading a missing profiles file")
+	}
+}
+
+func TestCredentialProfileApply(t *testing.T) {
+	profile := credentialProfile{
+		Credentials: "/keys/dev.json",
+		Project:     "dev-project",
+		Region:      "us-central1",
+	}
+
+	config := Config{Project: "override-project"}
+	profile.apply(&config)
+
+	if config.Credentials != "/keys/dev.json" {
+		t.Errorf("Expected credentials from the profile, got %q", config.Credentials)
+	}
+	if config.Project != "override-project" {
+		t.Errorf("Expected the configured project to win over the profile, got %q", config.Project)
+	}
+	if config.Region != "us-central1" {
+		t.Errorf("Expected region from the profile, got %q", config.Region)
+	}
+}
@@ -1,6 +1,7 @@
 package google
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -19,6 +20,17 @@ func dataSourceGoogleClientConfig() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
@@ -29,6 +41,13 @@ func dataSourceClientConfigRead(d *schema.ResourceData, meta interface{}) error
 	d.SetId(time.Now().UTC().String())
 	d.Set("project", config.Project)
 	d.Set("region", config.Region)
+	d.Set("zone", config.Zone)
+
+	token, err := config.tokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("Error getting an access token: %s", err)
+	}
+	d.Set("access_token", token.AccessToken)
 
 	return nil
 }
@@ -0,0 +1,49 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const openIDUserinfoUrl = "https://openidconnect.googleapis.com/v1/userinfo"
+
+func dataSourceGoogleClientOpenIDUserinfo() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleClientOpenIDUserinfoRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGoogleClientOpenIDUserinfoRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	resp, err := config.client.Get(openIDUserinfoUrl)
+	if err != nil {
+		return fmt.Errorf("Error retrieving userinfo for the provider credentials: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Error retrieving userinfo for the provider credentials: %s", resp.Status)
+	}
+
+	var userinfo struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&userinfo); err != nil {
+		return fmt.Errorf("Error parsing userinfo for the provider credentials: %s", err)
+	}
+
+	d.SetId(userinfo.Email)
+	d.Set("email", userinfo.Email)
+
+	return nil
+}
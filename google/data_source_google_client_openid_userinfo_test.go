@@ -0,0 +1,30 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceGoogleClientOpenIDUserinfo_basic(t *testing.T) {
+	t.Parallel()
+
+	resourceName := "data.google_client_openid_userinfo.me"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckGoogleClientOpenIDUserinfo_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "email"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckGoogleClientOpenIDUserinfo_basic = `
+data "google_client_openid_userinfo" "me" { }
+`
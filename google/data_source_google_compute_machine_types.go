@@ -0,0 +1,158 @@
+package google
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func dataSourceGoogleComputeMachineTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleComputeMachineTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"machine_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"guest_cpus": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory_mb": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"is_shared_cpu": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"maximum_persistent_disks": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"maximum_persistent_disks_size_gb": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"deprecated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"self_link": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGoogleComputeMachineTypesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+
+	var machineTypes []*compute.MachineType
+	call := config.clientCompute.MachineTypes.List(project, zone)
+	if filter, ok := d.GetOk("filter"); ok {
+		call = call.Filter(filter.(string))
+	}
+	pageToken := ""
+	for {
+		resp, err := call.PageToken(pageToken).Do()
+		if err != nil {
+			return fmt.Errorf("Error listing machine types in zone %q: %s", zone, err)
+		}
+		machineTypes = append(machineTypes, resp.Items...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	names, flattened := flattenComputeMachineTypes(machineTypes)
+
+	d.Set("project", project)
+	d.Set("names", names)
+	d.Set("machine_types", flattened)
+	d.SetId(time.Now().UTC().String())
+
+	return nil
+}
+
+// flattenComputeMachineTypes returns the machine types ordered by memory,
+// then CPUs, then name, so the first match for a requirement is also the
+// smallest.
+func flattenComputeMachineTypes(machineTypes []*compute.MachineType) ([]string, []map[string]interface{}) {
+	sort.Slice(machineTypes, func(i, j int) bool {
+		a, b := machineTypes[i], machineTypes[j]
+		if a.MemoryMb != b.MemoryMb {
+			return a.MemoryMb < b.MemoryMb
+		}
+		if a.GuestCpus != b.GuestCpus {
+			return a.GuestCpus < b.GuestCpus
+		}
+		return a.Name < b.Name
+	})
+
+	names := make([]string, 0, len(machineTypes))
+	flattened := make([]map[string]interface{}, 0, len(machineTypes))
+	for _, mt := range machineTypes {
+		names = append(names, mt.Name)
+		flattened = append(flattened, map[string]interface{}{
+			"name":                             mt.Name,
+			"description":                      mt.Description,
+			"guest_cpus":                       int(mt.GuestCpus),
+			"memory_mb":                        int(mt.MemoryMb),
+			"is_shared_cpu":                    mt.IsSharedCpu,
+			"maximum_persistent_disks":         int(mt.MaximumPersistentDisks),
+			"maximum_persistent_disks_size_gb": int(mt.MaximumPersistentDisksSizeGb),
+			"deprecated":                       mt.Deprecated != nil && mt.Deprecated.State != "",
+			"self_link":                        mt.SelfLink,
+		})
+	}
+
+	return names, flattened
+}
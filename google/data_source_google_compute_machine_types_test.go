@@ -0,0 +1,68 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func TestFlattenComputeMachineTypes(t *testing.T) {
+	t.Parallel()
+
+	names, flattened := flattenComputeMachineTypes([]*compute.MachineType{
+		{Name: "n1-highmem-2", GuestCpus: 2, MemoryMb: 13312},
+		{Name: "n1-standard-4", GuestCpus: 4, MemoryMb: 15360},
+		{Name: "n1-highcpu-16", GuestCpus: 16, MemoryMb: 14746},
+		{Name: "f1-micro", GuestCpus: 1, MemoryMb: 614, IsSharedCpu: true},
+		{Name: "n1-standard-1-old", GuestCpus: 1, MemoryMb: 614, Deprecated: &compute.DeprecationStatus{State: "DEPRECATED"}},
+	})
+
+	expected := []string{"f1-micro", "n1-standard-1-old", "n1-highmem-2", "n1-highcpu-16", "n1-standard-4"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected names %v, got %v", expected, names)
+	}
+
+	if flattened[0]["is_shared_cpu"] != true || flattened[0]["deprecated"] != false {
+		t.Errorf("Unexpected f1-micro: %v", flattened[0])
+	}
+	if flattened[1]["deprecated"] != true {
+		t.Errorf("Expected n1-standard-1-old to be deprecated: %v", flattened[1])
+	}
+	if flattened[4]["guest_cpus"] != 4 || flattened[4]["memory_mb"] != 15360 {
+		t.Errorf("Unexpected n1-standard-4: %v", flattened[4])
+	}
+}
+
+func TestAccDataSourceGoogleComputeMachineTypes_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleComputeMachineTypesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_compute_machine_types.highmem", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.google_compute_machine_types.highmem", "names.0", "n1-highmem-8"),
+					resource.TestCheckResourceAttr("data.google_compute_machine_types.highmem", "machine_types.0.guest_cpus", "8"),
+					resource.TestCheckResourceAttr("data.google_compute_machine_types.highmem", "machine_types.0.memory_mb", "53248"),
+					resource.TestCheckResourceAttrSet("data.google_compute_machine_types.all", "names.0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDataSourceGoogleComputeMachineTypesConfig = `
+data "google_compute_machine_types" "all" {
+	zone = "us-central1-a"
+}
+
+data "google_compute_machine_types" "highmem" {
+	zone   = "us-central1-a"
+	filter = "name eq n1-highmem-8"
+}
+`
@@ -0,0 +1,163 @@
+package google
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"google.golang.org/api/dataproc/v1"
+)
+
+func dataSourceGoogleDataprocClusters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleDataprocClustersRead,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "global",
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     schema.TypeString,
+			},
+
+			// ACTIVE and INACTIVE are groups of states, see
+			// https://cloud.google.com/dataproc/docs/reference/rest/v1/projects.regions.clusters/list
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ACTIVE", "INACTIVE", "CREATING", "RUNNING", "ERROR", "DELETING", "UPDATING",
+				}, false),
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+						},
+						"bucket": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGoogleDataprocClustersRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	region := d.Get("region").(string)
+	filter := dataprocClustersFilter(d.Get("state").(string), d.Get("labels").(map[string]interface{}))
+
+	var clusters []*dataproc.Cluster
+	call := config.clientDataproc.Projects.Regions.Clusters.List(project, region)
+	if filter != "" {
+		call = call.Filter(filter)
+	}
+	pageToken := ""
+	for {
+		resp, err := call.PageToken(pageToken).Do()
+		if err != nil {
+			return fmt.Errorf("Error listing Dataproc clusters in region %q: %s", region, err)
+		}
+		clusters = append(clusters, resp.Clusters...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ClusterName < clusters[j].ClusterName
+	})
+
+	names := make([]string, 0, len(clusters))
+	flattened := make([]map[string]interface{}, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.ClusterName)
+
+		data := map[string]interface{}{
+			"name":         cluster.ClusterName,
+			"cluster_uuid": cluster.ClusterUuid,
+			"labels":       cluster.Labels,
+		}
+		if cluster.Status != nil {
+			data["state"] = cluster.Status.State
+		}
+		if cluster.Config != nil {
+			data["bucket"] = cluster.Config.ConfigBucket
+		}
+		flattened = append(flattened, data)
+	}
+
+	d.Set("project", project)
+	d.Set("names", names)
+	d.Set("clusters", flattened)
+	d.SetId(time.Now().UTC().String())
+
+	return nil
+}
+
+// dataprocClustersFilter builds a Clusters.List filter expression matching
+// clusters in state carrying all of labels.
+func dataprocClustersFilter(state string, labels map[string]interface{}) string {
+	var terms []string
+	if state != "" {
+		terms = append(terms, fmt.Sprintf("status.state = %s", state))
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		terms = append(terms, fmt.Sprintf("labels.%s = %s", k, labels[k]))
+	}
+
+	return strings.Join(terms, " AND ")
+}
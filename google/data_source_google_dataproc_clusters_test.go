@@ -0,0 +1,85 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-google/google/fixtures"
+)
+
+func TestDataprocClustersFilter(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		State    string
+		Labels   map[string]interface{}
+		Expected string
+	}{
+		"none": {
+			Expected: "",
+		},
+		"state": {
+			State:    "ACTIVE",
+			Expected: "status.state = ACTIVE",
+		},
+		"labels": {
+			Labels:   map[string]interface{}{"env": "staging", "app": "etl"},
+			Expected: "labels.app = etl AND labels.env = staging",
+		},
+		"state and labels": {
+			State:    "ERROR",
+			Labels:   map[string]interface{}{"env": "staging"},
+			Expected: "status.state = ERROR AND labels.env = staging",
+		},
+	}
+
+	for tn, tc := range cases {
+		if actual := dataprocClustersFilter(tc.State, tc.Labels); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestAccDataSourceGoogleDataprocClusters(t *testing.T) {
+	t.Parallel()
+
+	rnd := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleDataprocClustersConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_dataproc_clusters.labelled", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.google_dataproc_clusters.labelled", "names.0", fmt.Sprintf("dproc-cluster-test-%s", rnd)),
+					resource.TestCheckResourceAttr("data.google_dataproc_clusters.labelled", "clusters.0.state", "RUNNING"),
+					resource.TestCheckResourceAttrPair(
+						"data.google_dataproc_clusters.labelled", "clusters.0.bucket",
+						"google_dataproc_cluster.with_labels", "cluster_config.0.bucket"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleDataprocClustersConfig(rnd string) string {
+	return fixtures.DataprocCluster{
+		ResourceName: "with_labels",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		Labels:       map[string]string{"test": rnd},
+		// GCP adds its own labels as well
+		IgnoreChanges: []string{"labels"},
+	}.Config() + fmt.Sprintf(`
+data "google_dataproc_clusters" "labelled" {
+	region = "${google_dataproc_cluster.with_labels.region}"
+	state  = "ACTIVE"
+	labels {
+		test = "%s"
+	}
+}
+`, rnd)
+}
@@ -0,0 +1,186 @@
+package google
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceGoogleDataprocJob() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleDataprocJobRead,
+
+		Schema: map[string]*schema.Schema{
+			"job_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "global",
+			},
+
+			// If set, up to this many bytes of the driver output are read from
+			// GCS into driver_output.
+			"driver_output_max_bytes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 1024*1024),
+			},
+
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state_details": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state_start_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"driver_output_resource_uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"driver_control_files_uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"driver_output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGoogleDataprocJobRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	region := d.Get("region").(string)
+	jobId := d.Get("job_id").(string)
+
+	job, err := config.clientDataproc.Projects.Regions.Jobs.Get(project, region, jobId).Do()
+	if err != nil {
+		return fmt.Errorf("Error reading Dataproc job %q: %s", jobId, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", project, region, jobId))
+	d.Set("project", project)
+	d.Set("labels", job.Labels)
+	d.Set("driver_output_resource_uri", job.DriverOutputResourceUri)
+	d.Set("driver_control_files_uri", job.DriverControlFilesUri)
+	if job.Placement != nil {
+		d.Set("cluster_name", job.Placement.ClusterName)
+	}
+	if job.Status != nil {
+		d.Set("state", job.Status.State)
+		d.Set("state_details", job.Status.Details)
+		d.Set("state_start_time", job.Status.StateStartTime)
+	}
+
+	maxBytes := d.Get("driver_output_max_bytes").(int)
+	if maxBytes > 0 && job.DriverOutputResourceUri != "" {
+		output, err := readDataprocDriverOutput(config, job.DriverOutputResourceUri, int64(maxBytes))
+		if err != nil {
+			return err
+		}
+		d.Set("driver_output", output)
+	}
+
+	return nil
+}
+
+// readDataprocDriverOutput reads up to maxBytes of the driver output of a job.
+// Dataproc writes the output as a sequence of objects sharing the prefix given
+// by driverOutputResourceUri, e.g. driveroutput.000000000, driveroutput.000000001.
+func readDataprocDriverOutput(config *Config, driverOutputResourceUri string, maxBytes int64) (string, error) {
+	bucket, prefix, err := parseGcsUri(driverOutputResourceUri)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	pageToken := ""
+	for {
+		res, err := config.clientStorage.Objects.List(bucket).Prefix(prefix).PageToken(pageToken).Do()
+		if err != nil {
+			return "", fmt.Errorf("Error listing driver output in %s: %s", driverOutputResourceUri, err)
+		}
+
+		for _, object := range res.Items {
+			remaining := maxBytes - int64(buf.Len())
+			if remaining <= 0 {
+				return buf.String(), nil
+			}
+
+			log.Printf("[DEBUG] Reading Dataproc driver output gs://%s/%s", bucket, object.Name)
+			resp, err := config.clientStorage.Objects.Get(bucket, object.Name).Download()
+			if err != nil {
+				return "", fmt.Errorf("Error reading driver output gs://%s/%s: %s", bucket, object.Name, err)
+			}
+			_, err = io.Copy(&buf, io.LimitReader(resp.Body, remaining))
+			resp.Body.Close()
+			if err != nil {
+				return "", fmt.Errorf("Error reading driver output gs://%s/%s: %s", bucket, object.Name, err)
+			}
+		}
+
+		if res.NextPageToken == "" {
+			return buf.String(), nil
+		}
+		pageToken = res.NextPageToken
+	}
+}
+
+// parseGcsUri splits a gs://bucket/object URI into its bucket and object.
+func parseGcsUri(uri string) (string, string, error) {
+	if !strings.HasPrefix(uri, "gs://") {
+		return "", "", fmt.Errorf("Invalid GCS URI %q, expected gs://{bucket}/{object}", uri)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(uri, "gs://"), "/", 2)
+	if parts[0] == "" {
+		return "", "", fmt.Errorf("Invalid GCS URI %q, expected gs://{bucket}/{object}", uri)
+	}
+	if len(parts) == 1 {
+		return parts[0], "", nil
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,50 @@
+package google
+
+import (
+	"testing"
+)
+
+func TestParseGcsUri(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Uri            string
+		ExpectedBucket string
+		ExpectedObject string
+		ExpectError    bool
+	}{
+		"driver output": {
+			Uri:            "gs://dataproc-1234-us/google-cloud-dataproc-metainfo/abcd/jobs/job-1/driveroutput",
+			ExpectedBucket: "dataproc-1234-us",
+			ExpectedObject: "google-cloud-dataproc-metainfo/abcd/jobs/job-1/driveroutput",
+		},
+		"bucket only": {
+			Uri:            "gs://my-bucket",
+			ExpectedBucket: "my-bucket",
+		},
+		"not a gcs uri": {
+			Uri:         "https://storage.googleapis.com/my-bucket/object",
+			ExpectError: true,
+		},
+		"no bucket": {
+			Uri:         "gs:///object",
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		bucket, object, err := parseGcsUri(tc.Uri)
+		if tc.ExpectError {
+			if err == nil {
+				t.Fatalf("bad: %s, expected an error", tn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("bad: %s, unexpected error: %s", tn, err)
+		}
+		if bucket != tc.ExpectedBucket || object != tc.ExpectedObject {
+			t.Fatalf("bad: %s, expected %s and %s, got %s and %s", tn, tc.ExpectedBucket, tc.ExpectedObject, bucket, object)
+		}
+	}
+}
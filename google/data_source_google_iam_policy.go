@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
-	"google.golang.org/api/cloudresourcemanager/v1"
 )
 
 var iamBinding *schema.Schema = &schema.Schema{
@@ -24,10 +23,90 @@ var iamBinding *schema.Schema = &schema.Schema{
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set:      schema.HashString,
 			},
+			"condition": iamConditionSchema(false),
 		},
 	},
 }
 
+// iamConditionSchema returns the schema of an IAM Condition block. The IAM
+// binding resources key bindings on their condition, so they need it to be
+// ForceNew; the data source doesn't.
+func iamConditionSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: forceNew,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"title": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: forceNew,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Optional: true,
+					ForceNew: forceNew,
+				},
+				"expression": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: forceNew,
+				},
+			},
+		},
+	}
+}
+
+// iamCondition is the JSON form of an IAM Condition. The vendored
+// cloudresourcemanager client predates conditions, so policies that may carry
+// them are marshalled through iamPolicy instead.
+type iamCondition struct {
+	Description string `json:"description,omitempty"`
+	Expression  string `json:"expression,omitempty"`
+	Title       string `json:"title,omitempty"`
+}
+
+type iamPolicyBinding struct {
+	Condition *iamCondition `json:"condition,omitempty"`
+	Members   []string      `json:"members,omitempty"`
+	Role      string        `json:"role,omitempty"`
+}
+
+type iamPolicy struct {
+	Bindings []*iamPolicyBinding `json:"bindings,omitempty"`
+	Etag     string              `json:"etag,omitempty"`
+	Version  int64               `json:"version,omitempty"`
+}
+
+// iamConditionalPolicyVersion is the policy version that IAM requires for
+// policies with conditional bindings, both to set them and to read them back
+// with their conditions.
+const iamConditionalPolicyVersion = 3
+
+func iamPolicyHasConditions(bindings []*iamPolicyBinding) bool {
+	for _, b := range bindings {
+		if b.Condition != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func expandIamCondition(v interface{}) *iamCondition {
+	l := v.([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	c := l[0].(map[string]interface{})
+	return &iamCondition{
+		Title:       c["title"].(string),
+		Description: c["description"].(string),
+		Expression:  c["expression"].(string),
+	}
+}
+
 // dataSourceGoogleIamPolicy returns a *schema.Resource that allows a customer
 // to express a Google Cloud IAM policy in a data resource. This is an example
 // of how the schema would be used in a config:
@@ -38,6 +117,11 @@ var iamBinding *schema.Schema = &schema.Schema{
 //     members = [
 //       "user:evanbrown@google.com",
 //     ]
+//
+//     condition {
+//       title      = "expires_after_2019_12_31"
+//       expression = "request.time < timestamp(\"2020-01-01T00:00:00Z\")"
+//     }
 //   }
 // }
 func dataSourceGoogleIamPolicy() *schema.Resource {
@@ -56,26 +140,29 @@ func dataSourceGoogleIamPolicy() *schema.Resource {
 // dataSourceGoogleIamPolicyRead reads a data source from config and writes it
 // to state.
 func dataSourceGoogleIamPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	var policy cloudresourcemanager.Policy
-	var bindings []*cloudresourcemanager.Binding
+	var policy iamPolicy
 
 	// The schema supports multiple binding{} blocks
 	bset := d.Get("binding").(*schema.Set)
 
 	// All binding{} blocks will be converted and stored in an array
-	bindings = make([]*cloudresourcemanager.Binding, bset.Len())
-	policy.Bindings = bindings
+	policy.Bindings = make([]*iamPolicyBinding, bset.Len())
 
-	// Convert each config binding into a cloudresourcemanager.Binding
+	// Convert each config binding into an iamPolicyBinding
 	for i, v := range bset.List() {
 		binding := v.(map[string]interface{})
-		policy.Bindings[i] = &cloudresourcemanager.Binding{
-			Role:    binding["role"].(string),
-			Members: convertStringSet(binding["members"].(*schema.Set)),
+		policy.Bindings[i] = &iamPolicyBinding{
+			Role:      binding["role"].(string),
+			Members:   convertStringSet(binding["members"].(*schema.Set)),
+			Condition: expandIamCondition(binding["condition"]),
 		}
 	}
 
-	// Marshal cloudresourcemanager.Policy to JSON suitable for storing in state
+	if iamPolicyHasConditions(policy.Bindings) {
+		policy.Version = iamConditionalPolicyVersion
+	}
+
+	// Marshal the policy to JSON suitable for storing in state
 	pjson, err := json.Marshal(&policy)
 	if err != nil {
 		// should never happen if the above code is correct
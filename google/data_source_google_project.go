@@ -0,0 +1,84 @@
+package google
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceGoogleProject() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleProjectRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"org_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"folder_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGoogleProjectRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	pid := d.Get("project_id").(string)
+	if pid == "" {
+		if config.Project == "" {
+			return fmt.Errorf("project_id: required field is not set and no project is configured on the provider")
+		}
+		pid = config.Project
+	}
+
+	p, err := config.clientResourceManager.Projects.Get(pid).Do()
+	if err != nil {
+		return fmt.Errorf("Error reading project %q: %s", pid, err)
+	}
+
+	d.SetId(pid)
+	d.Set("project_id", pid)
+	d.Set("number", strconv.FormatInt(p.ProjectNumber, 10))
+	d.Set("name", p.Name)
+	d.Set("labels", p.Labels)
+
+	d.Set("org_id", "")
+	d.Set("folder_id", "")
+	if p.Parent != nil {
+		switch p.Parent.Type {
+		case "organization":
+			d.Set("org_id", p.Parent.Id)
+		case "folder":
+			d.Set("folder_id", p.Parent.Id)
+		}
+	}
+
+	return nil
+}
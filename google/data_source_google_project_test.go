@@ -0,0 +1,74 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceGoogleProject_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleProject_default,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_project.project", "project_id", getTestProjectFromEnv()),
+					resource.TestMatchResourceAttr("data.google_project.project", "number", regexp.MustCompile(`^[0-9]+$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGoogleProject_named(t *testing.T) {
+	t.Parallel()
+
+	skipIfEnvNotSet(t, "GOOGLE_ORG")
+
+	pid := "terraform-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleProject_named(pid, org),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_project.project", "name", "tf-test data source"),
+					resource.TestCheckResourceAttr("data.google_project.project", "org_id", org),
+					resource.TestCheckResourceAttr("data.google_project.project", "labels.team", "data"),
+					resource.TestCheckResourceAttrPair("data.google_project.project", "number", "google_project.project", "number"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceGoogleProject_default = `
+data "google_project" "project" {}
+`
+
+func testAccDataSourceGoogleProject_named(pid, org string) string {
+	return fmt.Sprintf(`
+resource "google_project" "project" {
+  project_id = "%s"
+  name       = "tf-test data source"
+  org_id     = "%s"
+
+  labels {
+    team = "data"
+  }
+}
+
+data "google_project" "project" {
+  project_id = "${google_project.project.project_id}"
+}
+`, pid, org)
+}
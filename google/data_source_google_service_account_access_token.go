@@ -0,0 +1,78 @@
+package google
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceGoogleServiceAccountAccessToken() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleServiceAccountAccessTokenRead,
+		Schema: map[string]*schema.Schema{
+			"target_service_account": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"scopes": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(v interface{}) string {
+						return canonicalizeServiceScope(v.(string))
+					},
+				},
+			},
+			"delegates": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"lifetime": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "3600s",
+				ValidateFunc: validateDuration,
+			},
+			"access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"expire_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGoogleServiceAccountAccessTokenRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	target := d.Get("target_service_account").(string)
+	scopes := make([]string, 0)
+	for _, scope := range convertStringSet(d.Get("scopes").(*schema.Set)) {
+		scopes = append(scopes, canonicalizeServiceScope(scope))
+	}
+
+	ts := &impersonatedTokenSource{
+		client:    config.client,
+		endpoint:  iamCredentialsBasePath,
+		target:    target,
+		delegates: convertStringArr(d.Get("delegates").([]interface{})),
+		scopes:    scopes,
+		lifetime:  d.Get("lifetime").(string),
+	}
+	token, err := ts.Token()
+	if err != nil {
+		return err
+	}
+
+	d.SetId(serviceAccountFQN(target))
+	d.Set("access_token", token.AccessToken)
+	d.Set("expire_time", token.Expiry.Format(time.RFC3339))
+
+	return nil
+}
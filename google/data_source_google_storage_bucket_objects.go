@@ -0,0 +1,126 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/storage/v1"
+)
+
+func dataSourceGoogleStorageBucketObjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleStorageBucketObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"delimiter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"self_link": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"media_link": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"content_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"md5hash": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"generation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceGoogleStorageBucketObjectsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	bucket := d.Get("bucket").(string)
+	call := config.clientStorage.Objects.List(bucket)
+	if v, ok := d.GetOk("prefix"); ok {
+		call = call.Prefix(v.(string))
+	}
+	if v, ok := d.GetOk("delimiter"); ok {
+		call = call.Delimiter(v.(string))
+	}
+
+	names := []string{}
+	objects := []map[string]interface{}{}
+	prefixes := []string{}
+	err := call.Pages(context.Background(), func(res *storage.Objects) error {
+		for _, object := range res.Items {
+			names = append(names, object.Name)
+			objects = append(objects, map[string]interface{}{
+				"name":         object.Name,
+				"self_link":    object.SelfLink,
+				"media_link":   object.MediaLink,
+				"content_type": object.ContentType,
+				"size":         strconv.FormatUint(object.Size, 10),
+				"md5hash":      object.Md5Hash,
+				"generation":   strconv.FormatInt(object.Generation, 10),
+			})
+		}
+		prefixes = append(prefixes, res.Prefixes...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing objects in bucket %q: %s", bucket, err)
+	}
+
+	d.Set("names", names)
+	d.Set("objects", objects)
+	d.Set("prefixes", prefixes)
+	d.SetId(time.Now().UTC().String())
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceGoogleStorageBucketObjects(t *testing.T) {
+	t.Parallel()
+
+	bucketName := testBucketName()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleStorageBucketObjectsConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_storage_bucket_objects.parts", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.google_storage_bucket_objects.parts", "names.0", "output/part-00000"),
+					resource.TestCheckResourceAttr("data.google_storage_bucket_objects.parts", "names.1", "output/part-00001"),
+					resource.TestCheckResourceAttr("data.google_storage_bucket_objects.parts", "objects.0.size", "5"),
+					resource.TestCheckResourceAttrPair(
+						"data.google_storage_bucket_objects.parts", "objects.1.md5hash",
+						"google_storage_bucket_object.part_1", "md5hash"),
+					resource.TestCheckResourceAttr("data.google_storage_bucket_objects.top", "names.#", "0"),
+					resource.TestCheckResourceAttr("data.google_storage_bucket_objects.top", "prefixes.#", "1"),
+					resource.TestCheckResourceAttr("data.google_storage_bucket_objects.top", "prefixes.0", "output/"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleStorageBucketObjectsConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+}
+
+resource "google_storage_bucket_object" "part_0" {
+	bucket  = "${google_storage_bucket.bucket.name}"
+	name    = "output/part-00000"
+	content = "a,b,c"
+}
+
+resource "google_storage_bucket_object" "part_1" {
+	bucket  = "${google_storage_bucket.bucket.name}"
+	name    = "output/part-00001"
+	content = "d,e,f"
+}
+
+data "google_storage_bucket_objects" "parts" {
+	bucket = "${google_storage_bucket.bucket.name}"
+	prefix = "output/part-"
+
+	depends_on = ["google_storage_bucket_object.part_0", "google_storage_bucket_object.part_1"]
+}
+
+data "google_storage_bucket_objects" "top" {
+	bucket    = "${google_storage_bucket.bucket.name}"
+	delimiter = "/"
+
+	depends_on = ["google_storage_bucket_object.part_0", "google_storage_bucket_object.part_1"]
+}
+`, bucketName)
+}
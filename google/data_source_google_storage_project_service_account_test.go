@@ -0,0 +1,36 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceGoogleStorageProjectServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleStorageProjectServiceAccount(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_storage_project_service_account.gcs", "project", getTestProjectFromEnv()),
+					resource.TestMatchResourceAttr("data.google_storage_project_service_account.gcs", "email_address",
+						regexp.MustCompile(`@gs-project-accounts\.iam\.gserviceaccount\.com$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleStorageProjectServiceAccount() string {
+	return fmt.Sprintf(`
+data "google_storage_project_service_account" "gcs" {
+	project = "%s"
+}
+`, getTestProjectFromEnv())
+}
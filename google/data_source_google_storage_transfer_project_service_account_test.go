@@ -0,0 +1,36 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceGoogleStorageTransferProjectServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleStorageTransferProjectServiceAccount(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_storage_transfer_project_service_account.transfer", "project", getTestProjectFromEnv()),
+					resource.TestMatchResourceAttr("data.google_storage_transfer_project_service_account.transfer", "email",
+						regexp.MustCompile(`@storage-transfer-service\.iam\.gserviceaccount\.com$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleStorageTransferProjectServiceAccount() string {
+	return fmt.Sprintf(`
+data "google_storage_transfer_project_service_account" "transfer" {
+	project = "%s"
+}
+`, getTestProjectFromEnv())
+}
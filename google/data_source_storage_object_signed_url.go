@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -31,6 +32,11 @@ import (
 const gcsBaseUrl = "https://storage.googleapis.com"
 const googleCredentialsEnvVar = "GOOGLE_APPLICATION_CREDENTIALS"
 
+const signedUrlV4Algorithm = "GOOG4-RSA-SHA256"
+
+// V4 signed URLs can be valid for at most 7 days.
+const signedUrlV4MaxDuration = 7 * 24 * time.Hour
+
 func dataSourceGoogleSignedUrl() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceGoogleSignedUrlRead,
@@ -79,6 +85,12 @@ func dataSourceGoogleSignedUrl() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "v2",
+				ValidateFunc: validation.StringInSlice([]string{"v2", "v4"}, false),
+			},
 		},
 	}
 }
@@ -148,6 +160,33 @@ func dataSourceGoogleSignedUrlRead(d *schema.ResourceData, meta interface{}) err
 	}
 	urlData.JwtConfig = jwtConfig
 
+	if d.Get("version").(string) == "v4" {
+		if duration > signedUrlV4MaxDuration {
+			return fmt.Errorf("duration %q is too long, V4 signed URLs can be valid for at most %s", durationString, signedUrlV4MaxDuration)
+		}
+
+		urlDataV4 := &UrlDataV4{
+			JwtConfig:   urlData.JwtConfig,
+			ContentMd5:  urlData.ContentMd5,
+			ContentType: urlData.ContentType,
+			HttpMethod:  urlData.HttpMethod,
+			Timestamp:   time.Now().UTC(),
+			ExpiresIn:   int(duration.Seconds()),
+			HttpHeaders: urlData.HttpHeaders,
+			Path:        urlData.Path,
+		}
+
+		signedUrl, signature, err := urlDataV4.SignedUrl()
+		if err != nil {
+			return err
+		}
+
+		d.Set("signed_url", signedUrl)
+		d.SetId(signature)
+
+		return nil
+	}
+
 	// Construct URL
 	signedUrl, err := urlData.SignedUrl()
 	if err != nil {
@@ -358,3 +397,135 @@ func SignString(toSign []byte, cfg *jwt.Config) ([]byte, error) {
 
 	return signed, nil
 }
+
+// UrlDataV4 stores the values required to create a V4 Signed Url:
+// see https://cloud.google.com/storage/docs/access-control/signing-urls-manually
+type UrlDataV4 struct {
+	JwtConfig   *jwt.Config
+	ContentMd5  string
+	ContentType string
+	HttpMethod  string
+	Timestamp   time.Time
+	ExpiresIn   int
+	HttpHeaders map[string]string
+	Path        string
+}
+
+func (u *UrlDataV4) credentialScope() string {
+	return u.Timestamp.Format("20060102") + "/auto/storage/goog4_request"
+}
+
+// canonicalHeaders returns the headers the client must send, as the
+// canonical headers block and the list of signed header names.
+func (u *UrlDataV4) canonicalHeaders() (string, string) {
+	headers := map[string]string{
+		"host": strings.TrimPrefix(gcsBaseUrl, "https://"),
+	}
+	if u.ContentMd5 != "" {
+		headers["content-md5"] = u.ContentMd5
+	}
+	if u.ContentType != "" {
+		headers["content-type"] = u.ContentType
+	}
+	for k, v := range u.HttpHeaders {
+		headers[strings.ToLower(k)] = strings.Join(strings.Fields(v), " ")
+	}
+
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		buf.WriteString(fmt.Sprintf("%s:%s\n", k, headers[k]))
+	}
+
+	return buf.String(), strings.Join(keys, ";")
+}
+
+// CanonicalQueryString returns the query parameters of the signed URL,
+// excluding the signature itself.
+func (u *UrlDataV4) CanonicalQueryString() string {
+	_, signedHeaders := u.canonicalHeaders()
+
+	params := url.Values{}
+	params.Set("X-Goog-Algorithm", signedUrlV4Algorithm)
+	params.Set("X-Goog-Credential", u.JwtConfig.Email+"/"+u.credentialScope())
+	params.Set("X-Goog-Date", u.Timestamp.Format("20060102T150405Z"))
+	params.Set("X-Goog-Expires", strconv.Itoa(u.ExpiresIn))
+	params.Set("X-Goog-SignedHeaders", signedHeaders)
+
+	// Encode sorts by key; V4 signing wants spaces as %20 rather than +
+	return strings.Replace(params.Encode(), "+", "%20", -1)
+}
+
+// CanonicalRequest creates the canonical form of the request the URL allows.
+// Example output:
+// -------------------
+// GET
+// /bucket/objectname
+// X-Goog-Algorithm=GOOG4-RSA-SHA256&X-Goog-Credential=...
+// host:storage.googleapis.com
+//
+// host
+// UNSIGNED-PAYLOAD
+// -------------------
+func (u *UrlDataV4) CanonicalRequest() []byte {
+	canonicalHeaders, signedHeaders := u.canonicalHeaders()
+
+	var buf bytes.Buffer
+	buf.WriteString(u.HttpMethod + "\n")
+	buf.WriteString(escapeSignedUrlV4Path(u.Path) + "\n")
+	buf.WriteString(u.CanonicalQueryString() + "\n")
+	buf.WriteString(canonicalHeaders + "\n")
+	buf.WriteString(signedHeaders + "\n")
+	buf.WriteString("UNSIGNED-PAYLOAD")
+
+	return buf.Bytes()
+}
+
+// SigningString creates the string to sign from the hash of the canonical request.
+func (u *UrlDataV4) SigningString() []byte {
+	hash := sha256.Sum256(u.CanonicalRequest())
+
+	var buf bytes.Buffer
+	buf.WriteString(signedUrlV4Algorithm + "\n")
+	buf.WriteString(u.Timestamp.Format("20060102T150405Z") + "\n")
+	buf.WriteString(u.credentialScope() + "\n")
+	buf.WriteString(hex.EncodeToString(hash[:]))
+
+	return buf.Bytes()
+}
+
+// SignedUrl constructs the final signed URL, and returns it along with its
+// hex encoded signature.
+func (u *UrlDataV4) SignedUrl() (string, string, error) {
+	signature, err := SignString(u.SigningString(), u.JwtConfig)
+	if err != nil {
+		return "", "", err
+	}
+	encodedSig := hex.EncodeToString(signature)
+
+	signedUrl := fmt.Sprintf("%s%s?%s&X-Goog-Signature=%s",
+		gcsBaseUrl, escapeSignedUrlV4Path(u.Path), u.CanonicalQueryString(), encodedSig)
+
+	return signedUrl, encodedSig, nil
+}
+
+// escapeSignedUrlV4Path percent-encodes every byte of an object path except
+// the unreserved characters of RFC 3986 and '/'.
+func escapeSignedUrlV4Path(path string) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' || c == '/' {
+			buf.WriteByte(c)
+		} else {
+			buf.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+	return buf.String()
+}
@@ -4,11 +4,17 @@ import (
 	"testing"
 
 	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -99,6 +105,84 @@ func TestUrlData_SignedUrl(t *testing.T) {
 	}
 }
 
+func TestUrlDataV4_SignedUrl(t *testing.T) {
+	cfg, err := google.JWTConfigFromJSON([]byte(fakeCredentials), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	urlData := &UrlDataV4{
+		HttpMethod:  "GET",
+		ContentType: "text/plain",
+		Timestamp:   time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC),
+		ExpiresIn:   3600,
+		HttpHeaders: map[string]string{"X-Goog-Test": " foo  bar "},
+		Path:        "/tf-test-bucket/path/to/init action.sh",
+		JwtConfig:   cfg,
+	}
+
+	expectedQuery := "X-Goog-Algorithm=GOOG4-RSA-SHA256" +
+		"&X-Goog-Credential=user%40gcp-project.iam.gserviceaccount.com%2F20180102%2Fauto%2Fstorage%2Fgoog4_request" +
+		"&X-Goog-Date=20180102T030405Z" +
+		"&X-Goog-Expires=3600" +
+		"&X-Goog-SignedHeaders=content-type%3Bhost%3Bx-goog-test"
+	expectedRequest := "GET\n" +
+		"/tf-test-bucket/path/to/init%20action.sh\n" +
+		expectedQuery + "\n" +
+		"content-type:text/plain\nhost:storage.googleapis.com\nx-goog-test:foo bar\n\n" +
+		"content-type;host;x-goog-test\n" +
+		"UNSIGNED-PAYLOAD"
+	if got := string(urlData.CanonicalRequest()); got != expectedRequest {
+		t.Fatalf("Canonical request does not match expected value:\n%s\n%s", expectedRequest, got)
+	}
+
+	requestHash := sha256.Sum256([]byte(expectedRequest))
+	expectedSigningString := "GOOG4-RSA-SHA256\n20180102T030405Z\n20180102/auto/storage/goog4_request\n" +
+		hex.EncodeToString(requestHash[:])
+	if got := string(urlData.SigningString()); got != expectedSigningString {
+		t.Fatalf("Signing string does not match expected value:\n%s\n%s", expectedSigningString, got)
+	}
+
+	result, signature, err := urlData.SignedUrl()
+	if err != nil {
+		t.Fatalf("Could not generate signed url: %+v", err)
+	}
+	expectedPrefix := "https://storage.googleapis.com/tf-test-bucket/path/to/init%20action.sh?" + expectedQuery + "&X-Goog-Signature="
+	if !strings.HasPrefix(result, expectedPrefix) || !strings.HasSuffix(result, signature) {
+		t.Errorf("URL does not match expected value:\n%s<signature>\n%s", expectedPrefix, result)
+	}
+
+	// check the signature against the public half of the fake credentials
+	pk, err := parsePrivateKey(cfg.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingHash := sha256.Sum256([]byte(expectedSigningString))
+	if err := rsa.VerifyPKCS1v15(&pk.PublicKey, crypto.SHA256, signingHash[:], sig); err != nil {
+		t.Errorf("Signature does not verify: %s", err)
+	}
+}
+
+func TestEscapeSignedUrlV4Path(t *testing.T) {
+	cases := map[string]string{
+		"/bucket/path/to/file":    "/bucket/path/to/file",
+		"/bucket/a b+c":           "/bucket/a%20b%2Bc",
+		"/bucket/~user/f_1-2.txt": "/bucket/~user/f_1-2.txt",
+		"/bucket/q?x=1&y=$":       "/bucket/q%3Fx%3D1%26y%3D%24",
+		"/bucket/caf\u00e9":       "/bucket/caf%C3%A9",
+	}
+
+	for path, expected := range cases {
+		if got := escapeSignedUrlV4Path(path); got != expected {
+			t.Errorf("expected %q to be escaped as %q, got %q", path, expected, got)
+		}
+	}
+}
+
 func TestAccStorageSignedUrl_basic(t *testing.T) {
 	t.Parallel()
 
@@ -137,6 +221,8 @@ func TestAccStorageSignedUrl_accTest(t *testing.T) {
 					testAccGoogleSignedUrlRetrieval("data.google_storage_object_signed_url.story_url_w_headers", headers),
 					testAccGoogleSignedUrlRetrieval("data.google_storage_object_signed_url.story_url_w_content_type", nil),
 					testAccGoogleSignedUrlRetrieval("data.google_storage_object_signed_url.story_url_w_md5", nil),
+					testAccGoogleSignedUrlRetrieval("data.google_storage_object_signed_url.story_url_v4", nil),
+					testAccGoogleSignedUrlRetrieval("data.google_storage_object_signed_url.story_url_v4_w_headers", headers),
 				),
 			},
 		},
@@ -242,6 +328,22 @@ data "google_storage_object_signed_url" "story_url" {
 
 }
 
+data "google_storage_object_signed_url" "story_url_v4" {
+  bucket  = "${google_storage_bucket.bucket.name}"
+  path    = "${google_storage_bucket_object.story.name}"
+  version = "v4"
+}
+
+data "google_storage_object_signed_url" "story_url_v4_w_headers" {
+  bucket  = "${google_storage_bucket.bucket.name}"
+  path    = "${google_storage_bucket_object.story.name}"
+  version = "v4"
+  extension_headers {
+  	x-goog-test = "foo"
+  	x-goog-if-generation-match = 1
+  }
+}
+
 data "google_storage_object_signed_url" "story_url_w_headers" {
   bucket = "${google_storage_bucket.bucket.name}"
   path   = "${google_storage_bucket_object.story.name}"
@@ -0,0 +1,558 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tfconfig "github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"google.golang.org/api/dataproc/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// dataprocMockServer is a minimal in-memory stand-in for the Dataproc API,
+// enough to exercise the create/read/delete paths of google_dataproc_cluster
+// (and so the expand and flatten functions) without a real project.
+//
+// Clusters are stored as posted, and every operation completes immediately
+// unless OperationError is set, in which case it completes with that error,
+// or OperationsPending is set, in which case it never completes.
+type dataprocMockServer struct {
+	*httptest.Server
+
+	OperationError *dataproc.Status
+
+	// OperationsPending leaves operations, and clusters, forever in progress.
+	OperationsPending bool
+
+	// JobStatus, if set, is the status submitted jobs finish with rather
+	// than DONE.
+	JobStatus *dataproc.JobStatus
+
+	mu       sync.Mutex
+	clusters map[string]*dataproc.Cluster
+	jobs     map[string]*dataproc.Job
+}
+
+func newDataprocMockServer() *dataprocMockServer {
+	s := &dataprocMockServer{
+		clusters: make(map[string]*dataproc.Cluster),
+		jobs:     make(map[string]*dataproc.Job),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Config returns a provider Config whose Dataproc client talks to the mock.
+func (s *dataprocMockServer) Config(t *testing.T) *Config {
+	client, err := dataproc.New(s.Client())
+	if err != nil {
+		t.Fatalf("Error creating Dataproc client: %s", err)
+	}
+	client.BasePath = s.URL + "/"
+
+	return &Config{
+		Project:        "mock-project",
+		Region:         "us-central1",
+		clientDataproc: client,
+	}
+}
+
+func (s *dataprocMockServer) Cluster(region, name string) *dataproc.Cluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clusters[region+"/"+name]
+}
+
+func (s *dataprocMockServer) Jobs() []*dataproc.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*dataproc.Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+func (s *dataprocMockServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// v1/projects/{project}/regions/{region}/{collection}[/{name}]
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 6 || parts[0] != "v1" || parts[1] != "projects" || parts[3] != "regions" {
+		writeDataprocMockError(w, http.StatusNotFound, fmt.Sprintf("Unexpected path %s", r.URL.Path))
+		return
+	}
+	region, collection := parts[4], parts[5]
+
+	switch {
+	case collection == "operations" && len(parts) == 7 && r.Method == "GET":
+		op := &dataproc.Operation{
+			Name:  strings.Join(parts[1:], "/"),
+			Done:  !s.OperationsPending,
+			Error: s.OperationError,
+		}
+		writeDataprocMockResponse(w, op)
+
+	case collection == "clusters" && len(parts) == 6 && r.Method == "POST":
+		cluster := &dataproc.Cluster{}
+		if err := json.NewDecoder(r.Body).Decode(cluster); err != nil {
+			writeDataprocMockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		key := region + "/" + cluster.ClusterName
+		if _, ok := s.clusters[key]; ok {
+			writeDataprocMockError(w, http.StatusConflict, fmt.Sprintf("Already exists: %s", cluster.ClusterName))
+			return
+		}
+		if cluster.Config.ConfigBucket == "" {
+			cluster.Config.ConfigBucket = fmt.Sprintf("dataproc-mock-%s", region)
+		}
+		if cluster.Config.SoftwareConfig == nil {
+			cluster.Config.SoftwareConfig = &dataproc.SoftwareConfig{ImageVersion: "1.2"}
+		}
+		cluster.Status = &dataproc.ClusterStatus{State: "RUNNING"}
+		if s.OperationsPending {
+			cluster.Status.State = "CREATING"
+		}
+		s.clusters[key] = cluster
+		writeDataprocMockOperation(w, parts, "create")
+
+	case collection == "clusters" && len(parts) == 7 && r.Method == "GET":
+		cluster, ok := s.clusters[region+"/"+parts[6]]
+		if !ok {
+			writeDataprocMockError(w, http.StatusNotFound, fmt.Sprintf("Not found: Cluster %s", parts[6]))
+			return
+		}
+		writeDataprocMockResponse(w, cluster)
+
+	case collection == "clusters" && len(parts) == 7 && r.Method == "PATCH":
+		cluster, ok := s.clusters[region+"/"+parts[6]]
+		if !ok {
+			writeDataprocMockError(w, http.StatusNotFound, fmt.Sprintf("Not found: Cluster %s", parts[6]))
+			return
+		}
+		patch := &dataproc.Cluster{}
+		if err := json.NewDecoder(r.Body).Decode(patch); err != nil {
+			writeDataprocMockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, path := range strings.Split(r.URL.Query().Get("updateMask"), ",") {
+			switch path {
+			case "labels":
+				cluster.Labels = patch.Labels
+			case "config.worker_config.num_instances":
+				if cluster.Config.WorkerConfig == nil {
+					cluster.Config.WorkerConfig = &dataproc.InstanceGroupConfig{}
+				}
+				cluster.Config.WorkerConfig.NumInstances = patch.Config.WorkerConfig.NumInstances
+			default:
+				writeDataprocMockError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported update mask %s", path))
+				return
+			}
+		}
+		writeDataprocMockOperation(w, parts, "update")
+
+	case collection == "clusters" && len(parts) == 7 && r.Method == "DELETE":
+		key := region + "/" + parts[6]
+		if _, ok := s.clusters[key]; !ok {
+			writeDataprocMockError(w, http.StatusNotFound, fmt.Sprintf("Not found: Cluster %s", parts[6]))
+			return
+		}
+		delete(s.clusters, key)
+		writeDataprocMockOperation(w, parts, "delete")
+
+	case collection == "jobs:submit" && len(parts) == 6 && r.Method == "POST":
+		req := &dataproc.SubmitJobRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeDataprocMockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		job := req.Job
+		job.Status = &dataproc.JobStatus{State: "DONE"}
+		if s.JobStatus != nil {
+			job.Status = s.JobStatus
+		}
+		job.DriverOutputResourceUri = fmt.Sprintf("gs://dataproc-mock-%s/driveroutput", region)
+		s.jobs[region+"/"+job.Reference.JobId] = job
+		writeDataprocMockResponse(w, job)
+
+	case collection == "jobs" && len(parts) == 7 && r.Method == "GET":
+		job, ok := s.jobs[region+"/"+parts[6]]
+		if !ok {
+			writeDataprocMockError(w, http.StatusNotFound, fmt.Sprintf("Not found: Job %s", parts[6]))
+			return
+		}
+		writeDataprocMockResponse(w, job)
+
+	default:
+		writeDataprocMockError(w, http.StatusNotImplemented, fmt.Sprintf("Unexpected request %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func writeDataprocMockOperation(w http.ResponseWriter, parts []string, verb string) {
+	writeDataprocMockResponse(w, &dataproc.Operation{
+		Name: fmt.Sprintf("projects/%s/regions/%s/operations/%s-%s", parts[2], parts[4], verb, parts[len(parts)-1]),
+	})
+}
+
+func writeDataprocMockResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeDataprocMockError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": &googleapi.Error{Code: code, Message: message},
+	})
+}
+
+// dataprocMockApply runs a create (state is nil) or destroy (raw is nil) of a
+// google_dataproc_cluster through Resource.Apply, as Terraform core would.
+func dataprocMockApply(t *testing.T, config *Config, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, error) {
+	r := resourceDataprocCluster()
+
+	diff := &terraform.InstanceDiff{Destroy: true}
+	if raw != nil {
+		c, err := tfconfig.NewRawConfig(raw)
+		if err != nil {
+			t.Fatalf("Error parsing config: %s", err)
+		}
+		diff, err = r.Diff(state, terraform.NewResourceConfig(c))
+		if err != nil {
+			t.Fatalf("Error diffing config: %s", err)
+		}
+	}
+
+	return r.Apply(state, diff, config)
+}
+
+func TestDataprocClusterMock_createReadDelete(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+	config := server.Config(t)
+
+	state, err := dataprocMockApply(t, config, nil, map[string]interface{}{
+		"name":   "mock-cluster",
+		"region": "us-central1",
+		"labels": map[string]interface{}{
+			"env": "test",
+		},
+		"cluster_config": []interface{}{
+			map[string]interface{}{
+				"master_config": []interface{}{
+					map[string]interface{}{
+						"num_instances": 1,
+						"machine_type":  "n1-standard-2",
+						"disk_config": []interface{}{
+							map[string]interface{}{
+								"boot_disk_size_gb": 20,
+							},
+						},
+					},
+				},
+				"software_config": []interface{}{
+					map[string]interface{}{
+						"image_version": "1.2",
+						"override_properties": map[string]interface{}{
+							"dataproc:dataproc.allow.zero.workers": "true",
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating cluster: %s", err)
+	}
+
+	posted := server.Cluster("us-central1", "mock-cluster")
+	if posted == nil {
+		t.Fatalf("Expected cluster to have been created")
+	}
+	if posted.ProjectId != "mock-project" {
+		t.Fatalf("Expected project mock-project, got %q", posted.ProjectId)
+	}
+	if posted.Config.MasterConfig.MachineTypeUri != "n1-standard-2" {
+		t.Fatalf("Expected master machine type n1-standard-2, got %q", posted.Config.MasterConfig.MachineTypeUri)
+	}
+
+	expected := map[string]string{
+		"id":                      "mock-cluster",
+		"name":                    "mock-cluster",
+		"labels.env":              "test",
+		"cluster_config.0.bucket": "dataproc-mock-us-central1",
+		"cluster_config.0.master_config.0.num_instances":                                              "1",
+		"cluster_config.0.master_config.0.machine_type":                                               "n1-standard-2",
+		"cluster_config.0.master_config.0.disk_config.0.boot_disk_size_gb":                            "20",
+		"cluster_config.0.software_config.0.image_version":                                            "1.2",
+		"cluster_config.0.software_config.0.override_properties.dataproc:dataproc.allow.zero.workers": "true",
+	}
+	for k, v := range expected {
+		if actual := state.Attributes[k]; actual != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+
+	state, err = dataprocMockApply(t, config, state, nil)
+	if err != nil {
+		t.Fatalf("Error deleting cluster: %s", err)
+	}
+	if state != nil {
+		t.Fatalf("Expected no state after delete, got %s", state)
+	}
+	if server.Cluster("us-central1", "mock-cluster") != nil {
+		t.Fatalf("Expected cluster to have been deleted")
+	}
+}
+
+func TestDataprocClusterMock_readNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceDataprocCluster().Schema, map[string]interface{}{
+		"name": "missing",
+	})
+	d.SetId("missing")
+
+	if err := resourceDataprocClusterRead(d, server.Config(t)); err != nil {
+		t.Fatalf("Expected no error reading a missing cluster, got %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected missing cluster to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestDataprocClusterOperationWait_error(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+	server.OperationError = &dataproc.Status{
+		Code:    9,
+		Message: "Initialization action failed",
+	}
+
+	op := &dataproc.Operation{Name: "projects/mock-project/regions/global/operations/op"}
+	err := dataprocClusterOperationWait(server.Config(t), op, "creating Dataproc cluster", 1, 1)
+	if err == nil {
+		t.Fatalf("Expected the operation error to be returned")
+	}
+	if !strings.Contains(err.Error(), "Initialization action failed") {
+		t.Fatalf("Expected the operation error message, got %s", err)
+	}
+}
+
+func TestDataprocClusterMock_honorSkipRefreshLabel(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+	config := server.Config(t)
+
+	// The cluster doesn't exist in the mock, so a real refresh removes it.
+	state := &terraform.InstanceState{
+		ID: "autoscaled",
+		Attributes: map[string]string{
+			"name":                     "autoscaled",
+			"region":                   "global",
+			"labels.%":                 "1",
+			"labels.terraform-refresh": "skip",
+		},
+	}
+
+	refreshed, err := resourceDataprocCluster().Refresh(state, config)
+	if err != nil {
+		t.Fatalf("Error refreshing cluster: %s", err)
+	}
+	if refreshed != nil {
+		t.Fatalf("Expected cluster to be refreshed when the label isn't honored, got %s", refreshed)
+	}
+
+	config.HonorSkipRefreshLabel = true
+	refreshed, err = resourceDataprocCluster().Refresh(state, config)
+	if err != nil {
+		t.Fatalf("Error refreshing cluster: %s", err)
+	}
+	if refreshed == nil || refreshed.ID != "autoscaled" {
+		t.Fatalf("Expected refresh to be skipped, got %v", refreshed)
+	}
+}
+
+func TestDataprocClusterMock_honorSkipRefreshLabelUpdate(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+	config := server.Config(t)
+	config.HonorSkipRefreshLabel = true
+
+	raw := func(env string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":   "autoscaled",
+			"region": "us-central1",
+			"labels": map[string]interface{}{
+				"env":               env,
+				"terraform-refresh": "skip",
+			},
+		}
+	}
+
+	state, err := dataprocMockApply(t, config, nil, raw("test"))
+	if err != nil {
+		t.Fatalf("Error creating cluster: %s", err)
+	}
+	if actual := state.Attributes["effective_labels.env"]; actual != "test" {
+		t.Fatalf("Expected effective_labels.env to be read after create, got %q", actual)
+	}
+
+	// The read at the end of an update isn't a refresh, so it isn't skipped.
+	state, err = dataprocMockApply(t, config, state, raw("prod"))
+	if err != nil {
+		t.Fatalf("Error updating cluster: %s", err)
+	}
+	if actual := server.Cluster("us-central1", "autoscaled").Labels["env"]; actual != "prod" {
+		t.Fatalf("Expected the cluster to be updated, got label env=%q", actual)
+	}
+	if actual := state.Attributes["effective_labels.env"]; actual != "prod" {
+		t.Fatalf("Expected effective_labels.env to be read after update, got %q", actual)
+	}
+
+	// Whereas a refresh still is.
+	server.Cluster("us-central1", "autoscaled").Labels["env"] = "changed"
+	refreshed, err := resourceDataprocCluster().Refresh(state, config)
+	if err != nil {
+		t.Fatalf("Error refreshing cluster: %s", err)
+	}
+	if actual := refreshed.Attributes["effective_labels.env"]; actual != "prod" {
+		t.Fatalf("Expected refresh to be skipped, got effective_labels.env %q", actual)
+	}
+}
+
+func TestDataprocClusterMock_requiredLabels(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+	config := server.Config(t)
+	config.RequiredLabels = []string{"owner"}
+
+	_, err := dataprocMockApply(t, config, nil, map[string]interface{}{
+		"name": "unlabelled",
+		"labels": map[string]interface{}{
+			"env": "test",
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "missing labels required by the provider configuration: owner") {
+		t.Fatalf("Expected missing label error, got %v", err)
+	}
+	if server.Cluster("global", "unlabelled") != nil {
+		t.Fatalf("Expected cluster not to be created")
+	}
+}
+
+func TestDataprocClusterMock_createTimeoutKeepsState(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+	server.OperationsPending = true
+
+	state, err := dataprocMockApply(t, server.Config(t), nil, map[string]interface{}{
+		"name":   "slow-cluster",
+		"region": "us-central1",
+		"timeouts": []map[string]interface{}{
+			{
+				// Rounded down to 0 minutes, so the wait times out straight away
+				"create": "1s",
+			},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "operation projects/mock-project/regions/us-central1/operations/create-clusters") {
+		t.Fatalf("Expected timeout error naming the operation, got %v", err)
+	}
+	if state == nil || state.ID != "slow-cluster" {
+		t.Fatalf("Expected cluster to be kept in state, got %v", state)
+	}
+}
+
+func TestDataprocClusterMock_verificationJob(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+
+	state, err := dataprocMockApply(t, server.Config(t), nil, map[string]interface{}{
+		"name":   "verified",
+		"region": "us-central1",
+		"verification_job": []interface{}{
+			map[string]interface{}{
+				"args": []interface{}{"10"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating cluster: %s", err)
+	}
+	if state == nil || state.ID != "verified" {
+		t.Fatalf("Expected cluster to be in state, got %v", state)
+	}
+
+	jobs := server.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 verification job, got %d", len(jobs))
+	}
+	job := jobs[0]
+	if job.Placement.ClusterName != "verified" {
+		t.Errorf("Expected job to run on cluster verified, got %q", job.Placement.ClusterName)
+	}
+	if job.SparkJob.MainClass != "org.apache.spark.examples.SparkPi" {
+		t.Errorf("Expected the SparkPi main class by default, got %q", job.SparkJob.MainClass)
+	}
+	if len(job.SparkJob.JarFileUris) != 1 || job.SparkJob.JarFileUris[0] != dataprocVerificationJobJar {
+		t.Errorf("Expected the Spark examples jar by default, got %v", job.SparkJob.JarFileUris)
+	}
+	if len(job.SparkJob.Args) != 1 || job.SparkJob.Args[0] != "10" {
+		t.Errorf("Expected args [10], got %v", job.SparkJob.Args)
+	}
+}
+
+func TestDataprocClusterMock_verificationJobFailure(t *testing.T) {
+	t.Parallel()
+
+	server := newDataprocMockServer()
+	defer server.Close()
+	server.JobStatus = &dataproc.JobStatus{
+		State:   "ERROR",
+		Details: "Google Cloud Dataproc Agent reports job failure",
+	}
+
+	state, err := dataprocMockApply(t, server.Config(t), nil, map[string]interface{}{
+		"name":             "broken",
+		"region":           "us-central1",
+		"verification_job": []interface{}{map[string]interface{}{}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "finished in state ERROR: Google Cloud Dataproc Agent reports job failure") {
+		t.Fatalf("Expected verification job error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "gs://dataproc-mock-us-central1/driveroutput") {
+		t.Fatalf("Expected the error to point at the driver output, got %s", err)
+	}
+	if state == nil || state.ID != "broken" {
+		t.Fatalf("Expected cluster to be kept in state so it gets tainted, got %v", state)
+	}
+}
@@ -0,0 +1,187 @@
+// Package fixtures renders the HCL configs shared by the provider's
+// acceptance tests, so new tests can describe only what is specific to them
+// instead of copy-pasting whole resource blocks.
+package fixtures
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// DataprocCluster holds the parameters of a google_dataproc_cluster config.
+// Only ResourceName and Name are required, all other fields fall back to
+// the cheapest settings we can get away with: a single n1-standard-1 master
+// with a 10GB boot disk and no workers.
+type DataprocCluster struct {
+	ResourceName string
+	Name         string
+	Project      string
+	Region       string
+	DependsOn    []string
+	Labels       map[string]string
+
+	// IgnoreChanges is rendered into the resource's lifecycle block.
+	IgnoreChanges []string
+
+	StagingBucket string
+	ImageVersion  string
+
+	Master DataprocInstanceGroup
+
+	// Worker and PreemptibleWorker are omitted when nil. Without workers the
+	// cluster is created in single node mode.
+	Worker            *DataprocInstanceGroup
+	PreemptibleWorker *DataprocInstanceGroup
+
+	// Network and Subnetwork are rendered verbatim into the
+	// gce_cluster_config block, so they may be interpolations.
+	Network    string
+	Subnetwork string
+
+	// GceClusterConfig is appended verbatim inside the gce_cluster_config
+	// block, for settings such as tags or service accounts.
+	GceClusterConfig string
+
+	// ClusterConfig is appended verbatim inside the cluster_config block,
+	// for settings such as initialization_action which are test specific.
+	ClusterConfig string
+}
+
+// DataprocInstanceGroup holds the parameters of a master, worker or
+// preemptible worker config. NumInstances and NumLocalSsds are omitted when
+// zero so that the API defaults apply.
+type DataprocInstanceGroup struct {
+	NumInstances   int
+	MachineType    string
+	BootDiskSizeGb int
+	NumLocalSsds   int
+}
+
+var dataprocClusterTemplate = template.Must(template.New("google_dataproc_cluster").Parse(`
+{{- define "instance_group" -}}
+{{- if .NumInstances}}
+			num_instances     = {{.NumInstances}}
+{{- end}}
+{{- if .MachineType}}
+			machine_type      = "{{.MachineType}}"
+{{- end}}
+			disk_config {
+				boot_disk_size_gb = {{.BootDiskSizeGb}}
+{{- if .NumLocalSsds}}
+				num_local_ssds    = {{.NumLocalSsds}}
+{{- end}}
+			}
+{{- end}}
+resource "google_dataproc_cluster" "{{.ResourceName}}" {
+{{- if .Project}}
+	project = "{{.Project}}"
+{{- end}}
+	name   = "{{.Name}}"
+	region = "{{.Region}}"
+{{- if .DependsOn}}
+	depends_on = [{{range $i, $dep := .DependsOn}}{{if $i}}, {{end}}"{{$dep}}"{{end}}]
+{{- end}}
+{{- if .Labels}}
+
+	labels {
+{{- range $k, $v := .Labels}}
+		{{$k}} = "{{$v}}"
+{{- end}}
+	}
+{{- end}}
+
+	cluster_config {
+{{- if .StagingBucket}}
+		staging_bucket = "{{.StagingBucket}}"
+{{- end}}
+{{- if or .ImageVersion (not .Worker)}}
+
+		software_config {
+{{- if .ImageVersion}}
+			image_version = "{{.ImageVersion}}"
+{{- end}}
+{{- if not .Worker}}
+			# Keep the costs down with smallest config we can get away with
+			override_properties = {
+				"dataproc:dataproc.allow.zero.workers" = "true"
+			}
+{{- end}}
+		}
+{{- end}}
+
+		master_config {
+{{- template "instance_group" .Master}}
+		}
+{{- with .Worker}}
+
+		worker_config {
+{{- template "instance_group" .}}
+		}
+{{- end}}
+{{- with .PreemptibleWorker}}
+
+		preemptible_worker_config {
+{{- template "instance_group" .}}
+		}
+{{- end}}
+{{- if or .Network .Subnetwork .GceClusterConfig}}
+
+		gce_cluster_config {
+{{- if .Network}}
+			network = "{{.Network}}"
+{{- end}}
+{{- if .Subnetwork}}
+			subnetwork = "{{.Subnetwork}}"
+{{- end}}
+{{- if .GceClusterConfig}}
+{{.GceClusterConfig}}
+{{- end}}
+		}
+{{- end}}
+{{- if .ClusterConfig}}
+{{.ClusterConfig}}
+{{- end}}
+	}
+{{- if .IgnoreChanges}}
+
+	lifecycle {
+		ignore_changes = [{{range $i, $attr := .IgnoreChanges}}{{if $i}}, {{end}}"{{$attr}}"{{end}}]
+	}
+{{- end}}
+}
+`))
+
+// Config renders the fixture as HCL.
+func (f DataprocCluster) Config() string {
+	if f.Region == "" {
+		f.Region = "us-central1"
+	}
+	f.Master = f.Master.withDefaults("n1-standard-1")
+	if f.Worker != nil {
+		w := f.Worker.withDefaults("n1-standard-1")
+		f.Worker = &w
+	}
+	if f.PreemptibleWorker != nil {
+		// Preemptible workers always use the same machine type as the
+		// workers, so it can't be set.
+		p := f.PreemptibleWorker.withDefaults("")
+		f.PreemptibleWorker = &p
+	}
+
+	var buf bytes.Buffer
+	if err := dataprocClusterTemplate.Execute(&buf, f); err != nil {
+		// The template is static, so this can only be a programming error.
+		panic(err)
+	}
+	return buf.String()
+}
+
+func (g DataprocInstanceGroup) withDefaults(machineType string) DataprocInstanceGroup {
+	if g.MachineType == "" {
+		g.MachineType = machineType
+	}
+	if g.BootDiskSizeGb == 0 {
+		g.BootDiskSizeGb = 10
+	}
+	return g
+}
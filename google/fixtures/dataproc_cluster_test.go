@@ -0,0 +1,127 @@
+package fixtures
+
+import (
+	"testing"
+)
+
+func TestDataprocClusterConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Fixture  DataprocCluster
+		Expected string
+	}{
+		"single node": {
+			Fixture: DataprocCluster{
+				ResourceName: "with_net",
+				Name:         "dproc-cluster-test-foo",
+				Region:       "europe-west1",
+				Network:      "${google_compute_network.net.name}",
+				DependsOn:    []string{"google_compute_firewall.a", "google_compute_firewall.b"},
+			},
+			Expected: `
+resource "google_dataproc_cluster" "with_net" {
+	name   = "dproc-cluster-test-foo"
+	region = "europe-west1"
+	depends_on = ["google_compute_firewall.a", "google_compute_firewall.b"]
+
+	cluster_config {
+
+		software_config {
+			# Keep the costs down with smallest config we can get away with
+			override_properties = {
+				"dataproc:dataproc.allow.zero.workers" = "true"
+			}
+		}
+
+		master_config {
+			machine_type      = "n1-standard-1"
+			disk_config {
+				boot_disk_size_gb = 10
+			}
+		}
+
+		gce_cluster_config {
+			network = "${google_compute_network.net.name}"
+		}
+	}
+}
+`,
+		},
+		"with workers": {
+			Fixture: DataprocCluster{
+				ResourceName: "with_workers",
+				Name:         "dproc-cluster-test-foo",
+				Project:      "my-project",
+				Labels:       map[string]string{"b": "2", "a": "1"},
+				ImageVersion: "preview",
+				Master:       DataprocInstanceGroup{NumInstances: 3},
+				Worker: &DataprocInstanceGroup{
+					NumInstances:   2,
+					BootDiskSizeGb: 11,
+					NumLocalSsds:   1,
+				},
+				PreemptibleWorker: &DataprocInstanceGroup{NumInstances: 1},
+				GceClusterConfig:  `			tags = ["foo"]`,
+				IgnoreChanges:     []string{"labels"},
+			},
+			Expected: `
+resource "google_dataproc_cluster" "with_workers" {
+	project = "my-project"
+	name   = "dproc-cluster-test-foo"
+	region = "us-central1"
+
+	labels {
+		a = "1"
+		b = "2"
+	}
+
+	cluster_config {
+
+		software_config {
+			image_version = "preview"
+		}
+
+		master_config {
+			num_instances     = 3
+			machine_type      = "n1-standard-1"
+			disk_config {
+				boot_disk_size_gb = 10
+			}
+		}
+
+		worker_config {
+			num_instances     = 2
+			machine_type      = "n1-standard-1"
+			disk_config {
+				boot_disk_size_gb = 11
+				num_local_ssds    = 1
+			}
+		}
+
+		preemptible_worker_config {
+			num_instances     = 1
+			disk_config {
+				boot_disk_size_gb = 10
+			}
+		}
+
+		gce_cluster_config {
+			tags = ["foo"]
+		}
+	}
+
+	lifecycle {
+		ignore_changes = ["labels"]
+	}
+}
+`,
+		},
+	}
+
+	for tn, tc := range cases {
+		if actual := tc.Fixture.Config(); actual != tc.Expected {
+			t.Errorf("bad: %s, Expected:\n%s\nGot:\n%s", tn, tc.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// Fields which only exist in the Terraform configuration and so can't be
+// populated when a cluster is imported.
+var dataprocClusterImportStateVerifyIgnore = []string{
+	"cluster_config.0.delete_autogen_bucket",
+	"cluster_config.0.gce_cluster_config.0.recreate_on_tag_change",
+	"cluster_config.0.gce_cluster_config.0.required_firewall_tags",
+	"cluster_config.0.staging_bucket",
+	"cluster_config.0.software_config.0.override_properties",
+	"cluster_config.0.software_config.0.properties_filter",
+	"cluster_config.0.software_config.0.wire_encryption",
+}
+
+var dataprocClusterResourceRegexp = regexp.MustCompile(`resource "google_dataproc_cluster" "([^"]+)"`)
+
+// testAccDataprocClusterWithImportSteps appends an ImportState step verifying
+// every google_dataproc_cluster declared in a config after each successful
+// step, so that regressions in the read path are caught by all acceptance
+// tests rather than just the dedicated import ones.
+func testAccDataprocClusterWithImportSteps(region string, steps []resource.TestStep) []resource.TestStep {
+	result := make([]resource.TestStep, 0, len(steps))
+	for _, step := range steps {
+		result = append(result, step)
+		if step.ImportState || step.ExpectError != nil {
+			continue
+		}
+
+		for _, m := range dataprocClusterResourceRegexp.FindAllStringSubmatch(step.Config, -1) {
+			result = append(result, resource.TestStep{
+				ResourceName:            fmt.Sprintf("google_dataproc_cluster.%s", m[1]),
+				ImportState:             true,
+				ImportStateIdPrefix:     region + "/",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: dataprocClusterImportStateVerifyIgnore,
+			})
+		}
+	}
+	return result
+}
+
+func TestAccDataprocCluster_importBasic(t *testing.T) {
+	t.Parallel()
+
+	rnd := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataprocCluster_basic(rnd),
+			},
+			{
+				ResourceName:            "google_dataproc_cluster.basic",
+				ImportStateId:           fmt.Sprintf("%s/us-central1/dproc-cluster-test-%s", getTestProjectFromEnv(), rnd),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: append(dataprocClusterImportStateVerifyIgnore, "project"),
+			},
+		},
+	})
+}
+
+func TestDataprocClusterWithImportSteps(t *testing.T) {
+	t.Parallel()
+
+	steps := testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
+		{
+			Config:      testAccCheckDataproc_missingZoneGlobalRegion1("foo"),
+			ExpectError: regexp.MustCompile("zone is mandatory"),
+		},
+		{
+			Config: testAccDataprocCluster_withNetworkRefs("foo", "net"),
+		},
+		{
+			Config: testAccDataprocCluster_withStagingBucketOnly("bucket"),
+		},
+	})
+
+	if len(steps) != 5 {
+		t.Fatalf("Expected 5 steps, got %d", len(steps))
+	}
+	for i, name := range []string{"google_dataproc_cluster.with_net_ref_by_name", "google_dataproc_cluster.with_net_ref_by_url"} {
+		step := steps[2+i]
+		if !step.ImportState || !step.ImportStateVerify || step.ResourceName != name || step.ImportStateIdPrefix != "us-central1/" {
+			t.Fatalf("Expected import step for %s, got %+v", name, step)
+		}
+	}
+}
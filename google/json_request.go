@@ -0,0 +1,47 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// sendJsonRequest sends body, if any, as JSON to url and decodes the
+// response into result, if any. It's used for the APIs that have no
+// generated client in the vendored google.golang.org/api. Error responses
+// are returned as *googleapi.Error, as the generated clients do.
+func sendJsonRequest(client *http.Client, userAgent, method, url string, body, result interface{}) error {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, url, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return fmt.Errorf("Error decoding response from %s: %s", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package google
+
+import (
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+// loggingTransport dumps API requests and responses to the log, with
+// credentials redacted. Unless force is set, this only happens when
+// TF_LOG is DEBUG or TRACE.
+type loggingTransport struct {
+	name  string
+	force bool
+	base  http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	enabled := t.force || logging.IsDebugOrHigher()
+	level := "[DEBUG]"
+	if t.force {
+		level = "[INFO]"
+	}
+
+	if enabled {
+		reqData, err := httputil.DumpRequestOut(req, true)
+		if err == nil {
+			log.Printf("%s %s API Request Details:\n---[ REQUEST ]---------------------------------------\n%s\n-----------------------------------------------------", level, t.name, sanitizeHTTPDump(reqData))
+		} else {
+			log.Printf("[ERROR] %s API Request error: %#v", t.name, err)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if enabled {
+		respData, err := httputil.DumpResponse(resp, true)
+		if err == nil {
+			log.Printf("%s %s API Response Details:\n---[ RESPONSE ]--------------------------------------\n%s\n-----------------------------------------------------", level, t.name, sanitizeHTTPDump(respData))
+		} else {
+			log.Printf("[ERROR] %s API Response error: %#v", t.name, err)
+		}
+	}
+
+	return resp, nil
+}
+
+var (
+	sensitiveHeaderRegexp = regexp.MustCompile(`(?im)^(Authorization|Proxy-Authorization|X-Goog-Api-Key):[^\r\n]*`)
+	sensitiveFieldRegexp  = regexp.MustCompile(`"(private_key|privateKey|privateKeyData|password|access_token|accessToken|refresh_token|client_secret|secret|plaintext|secretAccessKey)"(\s*):(\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// sanitizeHTTPDump redacts credentials from a dumped request or response:
+// authorization headers, and secret fields of JSON bodies such as service
+// account keys, access tokens, SQL user passwords, HMAC key secrets, KMS
+// plaintexts and AWS secret access keys.
+func sanitizeHTTPDump(dump []byte) string {
+	dump = sensitiveHeaderRegexp.ReplaceAll(dump, []byte("$1: REDACTED"))
+	dump = sensitiveFieldRegexp.ReplaceAll(dump, []byte(`"$1"$2:$3"REDACTED"`))
+	return string(dump)
+}
@@ -0,0 +1,58 @@
+package google
+
+import (
+	"testing"
+)
+
+func TestSanitizeHTTPDump(t *testing.T) {
+	t.Parallel()
+
+	dump := "POST /v1/projects/p/serviceAccounts/sa/keys HTTP/1.1\r\n" +
+		"Host: iam.googleapis.com\r\n" +
+		"Authorization: Bearer ya29.secret\r\n" +
+		"Content-Type: application/json\r\n" +
+		"\r\n" +
+		`{"name": "key", "privateKeyData": "c2VjcmV0", "password":"p\"w", "accessToken" : "ya29.other"}`
+
+	expected := "POST /v1/projects/p/serviceAccounts/sa/keys HTTP/1.1\r\n" +
+		"Host: iam.googleapis.com\r\n" +
+		"Authorization: REDACTED\r\n" +
+		"Content-Type: application/json\r\n" +
+		"\r\n" +
+		`{"name": "key", "privateKeyData": "REDACTED", "password":"REDACTED", "accessToken" : "REDACTED"}`
+
+	if actual := sanitizeHTTPDump([]byte(dump)); actual != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
+
+func TestSanitizeHTTPDump_bodies(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Body, Expected string
+	}{
+		"hmac key create response": {
+			Body:     `{"kind": "storage#hmacKey", "secret": "bGlrZSBhIHBhc3N3b3Jk", "metadata": {"accessId": "GOOG1E"}}`,
+			Expected: `{"kind": "storage#hmacKey", "secret": "REDACTED", "metadata": {"accessId": "GOOG1E"}}`,
+		},
+		"kms encrypt request": {
+			Body:     `{"plaintext": "c2VjcmV0"}`,
+			Expected: `{"plaintext": "REDACTED"}`,
+		},
+		"transfer job aws access key": {
+			Body:     `{"transferSpec": {"awsS3DataSource": {"bucketName": "b", "awsAccessKey": {"accessKeyId": "AKIA", "secretAccessKey": "wJalrXUtnFEMI/K7MDENG"}}}}`,
+			Expected: `{"transferSpec": {"awsS3DataSource": {"bucketName": "b", "awsAccessKey": {"accessKeyId": "AKIA", "secretAccessKey": "REDACTED"}}}}`,
+		},
+		"kms decrypt response": {
+			Body:     `{"plaintext":"c2VjcmV0"}`,
+			Expected: `{"plaintext":"REDACTED"}`,
+		},
+	}
+
+	for tn, tc := range cases {
+		if actual := sanitizeHTTPDump([]byte(tc.Body)); actual != tc.Expected {
+			t.Errorf("bad: %s, expected:\n%s\ngot:\n%s", tn, tc.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package google
+
+import (
+	"net/http"
+	"net/url"
+)
+
+const monitoringBasePath = "https://monitoring.googleapis.com/"
+
+// monitoringClient is a minimal client for the Stackdriver Monitoring API
+// v3, which has no generated client in the vendored google.golang.org/api.
+// It only covers the alert policy calls google_monitoring_alert_policy
+// needs.
+type monitoringClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type monitoringAlertPolicy struct {
+	Name                 string                   `json:"name,omitempty"`
+	DisplayName          string                   `json:"displayName,omitempty"`
+	Combiner             string                   `json:"combiner,omitempty"`
+	Enabled              bool                     `json:"enabled"`
+	Conditions           []*monitoringCondition   `json:"conditions,omitempty"`
+	NotificationChannels []string                 `json:"notificationChannels,omitempty"`
+	Documentation        *monitoringDocumentation `json:"documentation,omitempty"`
+	UserLabels           map[string]string        `json:"userLabels,omitempty"`
+}
+
+type monitoringDocumentation struct {
+	Content  string `json:"content,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+type monitoringCondition struct {
+	Name               string                     `json:"name,omitempty"`
+	DisplayName        string                     `json:"displayName,omitempty"`
+	ConditionThreshold *monitoringMetricThreshold `json:"conditionThreshold,omitempty"`
+	ConditionAbsent    *monitoringMetricAbsence   `json:"conditionAbsent,omitempty"`
+}
+
+type monitoringMetricThreshold struct {
+	Filter                  string                   `json:"filter,omitempty"`
+	Aggregations            []*monitoringAggregation `json:"aggregations,omitempty"`
+	DenominatorFilter       string                   `json:"denominatorFilter,omitempty"`
+	DenominatorAggregations []*monitoringAggregation `json:"denominatorAggregations,omitempty"`
+	Comparison              string                   `json:"comparison,omitempty"`
+	ThresholdValue          float64                  `json:"thresholdValue,omitempty"`
+	Duration                string                   `json:"duration,omitempty"`
+	Trigger                 *monitoringTrigger       `json:"trigger,omitempty"`
+}
+
+type monitoringMetricAbsence struct {
+	Filter       string                   `json:"filter,omitempty"`
+	Aggregations []*monitoringAggregation `json:"aggregations,omitempty"`
+	Duration     string                   `json:"duration,omitempty"`
+	Trigger      *monitoringTrigger       `json:"trigger,omitempty"`
+}
+
+type monitoringAggregation struct {
+	AlignmentPeriod    string   `json:"alignmentPeriod,omitempty"`
+	PerSeriesAligner   string   `json:"perSeriesAligner,omitempty"`
+	CrossSeriesReducer string   `json:"crossSeriesReducer,omitempty"`
+	GroupByFields      []string `json:"groupByFields,omitempty"`
+}
+
+type monitoringTrigger struct {
+	Count   int64   `json:"count,omitempty"`
+	Percent float64 `json:"percent,omitempty"`
+}
+
+// CreateAlertPolicy creates policy in project, the API assigns its name.
+func (c *monitoringClient) CreateAlertPolicy(project string, policy *monitoringAlertPolicy) (*monitoringAlertPolicy, error) {
+	res := &monitoringAlertPolicy{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", c.BasePath+"v3/projects/"+project+"/alertPolicies", policy, res)
+	return res, err
+}
+
+func (c *monitoringClient) GetAlertPolicy(name string) (*monitoringAlertPolicy, error) {
+	res := &monitoringAlertPolicy{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+"v3/"+name, nil, res)
+	return res, err
+}
+
+// UpdateAlertPolicy sets the fields of the policy called name listed in
+// updateMask to their values in policy.
+func (c *monitoringClient) UpdateAlertPolicy(name string, policy *monitoringAlertPolicy, updateMask string) (*monitoringAlertPolicy, error) {
+	u := c.BasePath + "v3/" + name + "?" + url.Values{"updateMask": {updateMask}}.Encode()
+	res := &monitoringAlertPolicy{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PATCH", u, policy, res)
+	return res, err
+}
+
+func (c *monitoringClient) DeleteAlertPolicy(name string) error {
+	return sendJsonRequest(c.client, c.UserAgent, "DELETE", c.BasePath+"v3/"+name, nil, nil)
+}
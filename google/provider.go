@@ -3,9 +3,12 @@ package google
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -32,6 +35,7 @@ func Provider() terraform.ResourceProvider {
 				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"GOOGLE_PROJECT",
+					"GOOGLE_CLOUD_PROJECT",
 					"GCLOUD_PROJECT",
 					"CLOUDSDK_CORE_PROJECT",
 				}, nil),
@@ -39,36 +43,177 @@ func Provider() terraform.ResourceProvider {
 
 			"region": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"GOOGLE_REGION",
 					"GCLOUD_REGION",
 					"CLOUDSDK_COMPUTE_REGION",
 				}, nil),
 			},
+
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"GOOGLE_ZONE",
+					"GCLOUD_ZONE",
+					"CLOUDSDK_COMPUTE_ZONE",
+				}, nil),
+			},
+
+			"request_reason": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDSDK_CORE_REQUEST_REASON", nil),
+			},
+
+			"user_agent_suffix": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_TERRAFORM_USERAGENT_EXTENSION", nil),
+			},
+
+			"scopes": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"billing_project": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_BILLING_PROJECT", nil),
+			},
+
+			"user_project_override": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("USER_PROJECT_OVERRIDE", false),
+			},
+
+			"profile": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_PROFILE", nil),
+			},
+
+			"profiles_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_PROFILES_FILE", defaultProfilesFile),
+			},
+
+			"impersonate_service_account": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_IMPERSONATE_SERVICE_ACCOUNT", nil),
+			},
+
+			"impersonate_service_account_delegates": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"honor_skip_refresh_label": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_max_backoff": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"retry_status_codes": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"retry_rate_limit_exceeded": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"required_labels": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"log_http_requests": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				DefaultFunc: func() (interface{}, error) {
+					return os.Getenv("TF_LOG_PROVIDER_GOOGLE") != "", nil
+				},
+			},
+
+			"audit_log_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_AUDIT_LOG_FILE", nil),
+			},
+
+			"dataproc_cluster_operation_concurrency": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"preflight_check": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"google_dns_managed_zone":          dataSourceDnsManagedZone(),
-			"google_client_config":             dataSourceGoogleClientConfig(),
-			"google_compute_address":           dataSourceGoogleComputeAddress(),
-			"google_compute_global_address":    dataSourceGoogleComputeGlobalAddress(),
-			"google_compute_lb_ip_ranges":      dataSourceGoogleComputeLbIpRanges(),
-			"google_compute_network":           dataSourceGoogleComputeNetwork(),
-			"google_compute_subnetwork":        dataSourceGoogleComputeSubnetwork(),
-			"google_compute_zones":             dataSourceGoogleComputeZones(),
-			"google_compute_instance_group":    dataSourceGoogleComputeInstanceGroup(),
-			"google_container_engine_versions": dataSourceGoogleContainerEngineVersions(),
-			"google_active_folder":             dataSourceGoogleActiveFolder(),
-			"google_iam_policy":                dataSourceGoogleIamPolicy(),
-			"google_storage_object_signed_url": dataSourceGoogleSignedUrl(),
+			"google_dns_managed_zone":                         dataSourceDnsManagedZone(),
+			"google_client_config":                            dataSourceGoogleClientConfig(),
+			"google_client_openid_userinfo":                   dataSourceGoogleClientOpenIDUserinfo(),
+			"google_compute_address":                          dataSourceGoogleComputeAddress(),
+			"google_compute_global_address":                   dataSourceGoogleComputeGlobalAddress(),
+			"google_compute_lb_ip_ranges":                     dataSourceGoogleComputeLbIpRanges(),
+			"google_compute_network":                          dataSourceGoogleComputeNetwork(),
+			"google_compute_machine_types":                    dataSourceGoogleComputeMachineTypes(),
+			"google_compute_subnetwork":                       dataSourceGoogleComputeSubnetwork(),
+			"google_compute_zones":                            dataSourceGoogleComputeZones(),
+			"google_compute_instance_group":                   dataSourceGoogleComputeInstanceGroup(),
+			"google_container_engine_versions":                dataSourceGoogleContainerEngineVersions(),
+			"google_dataproc_clusters":                        dataSourceGoogleDataprocClusters(),
+			"google_dataproc_job":                             dataSourceGoogleDataprocJob(),
+			"google_active_folder":                            dataSourceGoogleActiveFolder(),
+			"google_kms_secret":                               dataSourceGoogleKmsSecret(),
+			"google_project":                                  dataSourceGoogleProject(),
+			"google_iam_policy":                               dataSourceGoogleIamPolicy(),
+			"google_service_account_access_token":             dataSourceGoogleServiceAccountAccessToken(),
+			"google_storage_bucket_objects":                   dataSourceGoogleStorageBucketObjects(),
+			"google_storage_object_signed_url":                dataSourceGoogleSignedUrl(),
+			"google_storage_project_service_account":          dataSourceGoogleStorageProjectServiceAccount(),
+			"google_storage_transfer_project_service_account": dataSourceGoogleStorageTransferProjectServiceAccount(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"google_bigquery_dataset":                      resourceBigQueryDataset(),
+			"google_bigquery_data_transfer_config":         resourceBigQueryDataTransferConfig(),
 			"google_bigquery_table":                        resourceBigQueryTable(),
 			"google_bigtable_instance":                     resourceBigtableInstance(),
 			"google_bigtable_table":                        resourceBigtableTable(),
+			"google_cloudfunctions_function":               resourceCloudFunctionsFunction(),
 			"google_compute_autoscaler":                    resourceComputeAutoscaler(),
 			"google_compute_address":                       resourceComputeAddress(),
 			"google_compute_backend_bucket":                resourceComputeBackendBucket(),
@@ -94,12 +239,17 @@ func Provider() terraform.ResourceProvider {
 			"google_compute_region_autoscaler":             resourceComputeRegionAutoscaler(),
 			"google_compute_region_backend_service":        resourceComputeRegionBackendService(),
 			"google_compute_region_instance_group_manager": resourceComputeRegionInstanceGroupManager(),
+			"google_compute_resource_policy":               resourceComputeResourcePolicy(),
+			"google_compute_resource_policy_attachment":    resourceComputeResourcePolicyAttachment(),
 			"google_compute_route":                         resourceComputeRoute(),
 			"google_compute_router":                        resourceComputeRouter(),
 			"google_compute_router_interface":              resourceComputeRouterInterface(),
+			"google_compute_router_nat":                    resourceComputeRouterNat(),
 			"google_compute_router_peer":                   resourceComputeRouterPeer(),
 			"google_compute_shared_vpc_host_project":       resourceComputeSharedVpcHostProject(),
 			"google_compute_shared_vpc_service_project":    resourceComputeSharedVpcServiceProject(),
+			"google_compute_sole_tenant_node_group":        resourceComputeSoleTenantNodeGroup(),
+			"google_compute_sole_tenant_node_template":     resourceComputeSoleTenantNodeTemplate(),
 			"google_compute_ssl_certificate":               resourceComputeSslCertificate(),
 			"google_compute_subnetwork":                    resourceComputeSubnetwork(),
 			"google_compute_target_http_proxy":             resourceComputeTargetHttpProxy(),
@@ -113,13 +263,18 @@ func Provider() terraform.ResourceProvider {
 			"google_container_cluster":                     resourceContainerCluster(),
 			"google_container_node_pool":                   resourceContainerNodePool(),
 			"google_dataproc_cluster":                      resourceDataprocCluster(),
+			"google_dataproc_cluster_name":                 resourceDataprocClusterName(),
 			"google_dns_managed_zone":                      resourceDnsManagedZone(),
 			"google_dns_record_set":                        resourceDnsRecordSet(),
 			"google_folder":                                resourceGoogleFolder(),
+			"google_folder_iam_binding":                    resourceGoogleFolderIamBinding(),
+			"google_folder_iam_member":                     resourceGoogleFolderIamMember(),
 			"google_folder_iam_policy":                     resourceGoogleFolderIamPolicy(),
 			"google_logging_billing_account_sink":          resourceLoggingBillingAccountSink(),
 			"google_logging_folder_sink":                   resourceLoggingFolderSink(),
+			"google_logging_project_exclusion":             resourceLoggingProjectExclusion(),
 			"google_logging_project_sink":                  resourceLoggingProjectSink(),
+			"google_monitoring_alert_policy":               resourceMonitoringAlertPolicy(),
 			"google_kms_key_ring":                          resourceKmsKeyRing(),
 			"google_kms_crypto_key":                        resourceKmsCryptoKey(),
 			"google_sourcerepo_repository":                 resourceSourceRepoRepository(),
@@ -137,16 +292,27 @@ func Provider() terraform.ResourceProvider {
 			"google_project_service":                       resourceGoogleProjectService(),
 			"google_project_iam_custom_role":               resourceGoogleProjectIamCustomRole(),
 			"google_project_services":                      resourceGoogleProjectServices(),
+			"google_project_organization_policy":           resourceGoogleProjectOrganizationPolicy(),
 			"google_pubsub_topic":                          resourcePubsubTopic(),
 			"google_pubsub_subscription":                   resourcePubsubSubscription(),
 			"google_runtimeconfig_config":                  resourceRuntimeconfigConfig(),
 			"google_runtimeconfig_variable":                resourceRuntimeconfigVariable(),
 			"google_service_account":                       resourceGoogleServiceAccount(),
+			"google_service_account_iam_binding":           resourceGoogleServiceAccountIamBinding(),
+			"google_service_account_iam_member":            resourceGoogleServiceAccountIamMember(),
+			"google_service_account_iam_policy":            resourceGoogleServiceAccountIamPolicy(),
 			"google_service_account_key":                   resourceGoogleServiceAccountKey(),
 			"google_storage_bucket":                        resourceStorageBucket(),
 			"google_storage_bucket_acl":                    resourceStorageBucketAcl(),
+			"google_storage_bucket_iam_binding":            resourceStorageBucketIamBinding(),
+			"google_storage_bucket_iam_member":             resourceStorageBucketIamMember(),
+			"google_storage_bucket_iam_policy":             resourceStorageBucketIamPolicy(),
 			"google_storage_bucket_object":                 resourceStorageBucketObject(),
+			"google_storage_default_object_access_control": resourceStorageDefaultObjectAccessControl(),
+			"google_storage_hmac_key":                      resourceStorageHmacKey(),
 			"google_storage_object_acl":                    resourceStorageObjectAcl(),
+			"google_storage_notification":                  resourceStorageNotification(),
+			"google_storage_transfer_job":                  resourceStorageTransferJob(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -159,15 +325,89 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Credentials: credentials,
 		Project:     d.Get("project").(string),
 		Region:      d.Get("region").(string),
+		Zone:        d.Get("zone").(string),
+
+		QuotaProject: quotaProject(d),
+
+		RequestReason:   d.Get("request_reason").(string),
+		UserAgentSuffix: d.Get("user_agent_suffix").(string),
+
+		ImpersonateServiceAccount: d.Get("impersonate_service_account").(string),
+
+		HonorSkipRefreshLabel: d.Get("honor_skip_refresh_label").(bool),
+		LogHTTPRequests:       d.Get("log_http_requests").(bool),
+		AuditLogFile:          d.Get("audit_log_file").(string),
+	}
+
+	if name := d.Get("profile").(string); name != "" {
+		profile, err := loadCredentialProfile(d.Get("profiles_file").(string), name)
+		if err != nil {
+			return nil, err
+		}
+		profile.apply(&config)
+
+		if config.QuotaProject == "" && d.Get("user_project_override").(bool) {
+			config.QuotaProject = config.Project
+		}
+	}
+
+	if config.Region == "" {
+		return nil, fmt.Errorf("region must be set, either in the provider configuration or in the selected profile")
+	}
+
+	for _, scope := range d.Get("scopes").([]interface{}) {
+		config.Scopes = append(config.Scopes, scope.(string))
+	}
+
+	for _, delegate := range d.Get("impersonate_service_account_delegates").([]interface{}) {
+		config.ImpersonateServiceAccountDelegates = append(config.ImpersonateServiceAccountDelegates, delegate.(string))
+	}
+
+	for _, label := range d.Get("required_labels").([]interface{}) {
+		config.RequiredLabels = append(config.RequiredLabels, label.(string))
+	}
+
+	if v := d.Get("dataproc_cluster_operation_concurrency").(int); v > 0 {
+		config.dataprocClusterOperations = NewSemaphoreKV(v)
+	}
+
+	retry := defaultRetryPolicy()
+	retry.MaxRetries = d.Get("max_retries").(int)
+	retry.MaxBackoff = time.Duration(d.Get("retry_max_backoff").(int)) * time.Second
+	retry.RetryRateLimitExceeded = d.Get("retry_rate_limit_exceeded").(bool)
+	config.RetryPolicy = &retry
+	if v, ok := d.GetOk("retry_status_codes"); ok {
+		config.RetryPolicy.StatusCodes = nil
+		for _, code := range v.([]interface{}) {
+			config.RetryPolicy.StatusCodes = append(config.RetryPolicy.StatusCodes, code.(int))
+		}
 	}
 
 	if err := config.loadAndValidate(); err != nil {
 		return nil, err
 	}
 
+	if d.Get("preflight_check").(bool) {
+		if err := config.preflightCheck(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &config, nil
 }
 
+// quotaProject returns the project API calls should be billed and quota'd
+// against, if it isn't the project the credentials belong to.
+func quotaProject(d *schema.ResourceData) string {
+	if d.Get("user_project_override").(bool) {
+		if v := d.Get("billing_project").(string); v != "" {
+			return v
+		}
+		return d.Get("project").(string)
+	}
+	return os.Getenv("GOOGLE_CLOUD_QUOTA_PROJECT")
+}
+
 func validateCredentials(v interface{}, k string) (warnings []string, errors []error) {
 	if v == nil || v.(string) == "" {
 		return
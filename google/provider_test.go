@@ -23,6 +23,7 @@ var credsEnvVars = []string{
 
 var projectEnvVars = []string{
 	"GOOGLE_PROJECT",
+	"GOOGLE_CLOUD_PROJECT",
 	"GCLOUD_PROJECT",
 	"CLOUDSDK_CORE_PROJECT",
 }
@@ -80,6 +81,36 @@ func TestProvider_getRegionFromZone(t *testing.T) {
 	}
 }
 
+func TestProvider_quotaProject(t *testing.T) {
+	cases := map[string]struct {
+		Raw      map[string]interface{}
+		Expected string
+	}{
+		"billing project": {
+			Raw: map[string]interface{}{
+				"project":               "my-project",
+				"billing_project":       "my-billing-project",
+				"user_project_override": true,
+			},
+			Expected: "my-billing-project",
+		},
+		"defaults to the provider project": {
+			Raw: map[string]interface{}{
+				"project":               "my-project",
+				"user_project_override": true,
+			},
+			Expected: "my-project",
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, tc.Raw)
+		if actual := quotaProject(d); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, actual)
+		}
+	}
+}
+
 // getTestRegion has the same logic as the provider's getRegion, to be used in tests.
 func getTestRegion(is *terraform.InstanceState, config *Config) (string, error) {
 	if res, ok := is.Attributes["region"]; ok {
@@ -0,0 +1,74 @@
+package google
+
+import (
+	"net/http"
+)
+
+const pubsubSubscriptionsBasePath = "https://pubsub.googleapis.com/"
+
+// pubsubSubscriptionsClient is a minimal client for the Pub/Sub v1
+// subscription calls. The vendored google.golang.org/api predates message
+// retention, OIDC push authentication, dead letter policies and the
+// subscription PATCH method, so google_pubsub_subscription uses this instead.
+type pubsubSubscriptionsClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type pubsubSubscription struct {
+	Name                     string                  `json:"name,omitempty"`
+	Topic                    string                  `json:"topic,omitempty"`
+	PushConfig               *pubsubPushConfig       `json:"pushConfig,omitempty"`
+	AckDeadlineSeconds       int64                   `json:"ackDeadlineSeconds,omitempty"`
+	MessageRetentionDuration string                  `json:"messageRetentionDuration,omitempty"`
+	RetainAckedMessages      bool                    `json:"retainAckedMessages,omitempty"`
+	DeadLetterPolicy         *pubsubDeadLetterPolicy `json:"deadLetterPolicy,omitempty"`
+}
+
+type pubsubPushConfig struct {
+	PushEndpoint string            `json:"pushEndpoint,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	OidcToken    *pubsubOidcToken  `json:"oidcToken,omitempty"`
+}
+
+type pubsubOidcToken struct {
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+	Audience            string `json:"audience,omitempty"`
+}
+
+type pubsubDeadLetterPolicy struct {
+	DeadLetterTopic     string `json:"deadLetterTopic,omitempty"`
+	MaxDeliveryAttempts int64  `json:"maxDeliveryAttempts,omitempty"`
+}
+
+type pubsubUpdateSubscriptionRequest struct {
+	Subscription *pubsubSubscription `json:"subscription"`
+	UpdateMask   string              `json:"updateMask"`
+}
+
+// CreateSubscription creates subscription under name, which has the form
+// projects/{project}/subscriptions/{subscription}.
+func (c *pubsubSubscriptionsClient) CreateSubscription(name string, subscription *pubsubSubscription) (*pubsubSubscription, error) {
+	res := &pubsubSubscription{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PUT", c.BasePath+"v1/"+name, subscription, res)
+	return res, err
+}
+
+func (c *pubsubSubscriptionsClient) GetSubscription(name string) (*pubsubSubscription, error) {
+	res := &pubsubSubscription{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+"v1/"+name, nil, res)
+	return res, err
+}
+
+// UpdateSubscription sets the fields of the subscription called name listed
+// in updateMask to their values in subscription.
+func (c *pubsubSubscriptionsClient) UpdateSubscription(name string, subscription *pubsubSubscription, updateMask string) (*pubsubSubscription, error) {
+	subscription.Name = name
+	res := &pubsubSubscription{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PATCH", c.BasePath+"v1/"+name, &pubsubUpdateSubscriptionRequest{
+		Subscription: subscription,
+		UpdateMask:   updateMask,
+	}, res)
+	return res, err
+}
@@ -0,0 +1,220 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceBigQueryDataTransferConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigQueryDataTransferConfigCreate,
+		Read:   resourceBigQueryDataTransferConfigRead,
+		Update: resourceBigQueryDataTransferConfigUpdate,
+		Delete: resourceBigQueryDataTransferConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigQueryDataTransferConfigImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// DataSourceId: The data source of the transfer, such as
+			// scheduled_query.
+			"data_source_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"destination_dataset_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Params: The data source specific parameters. For scheduled
+			// queries, query, destination_table_name_template and
+			// write_disposition.
+			"params": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// Schedule: When the transfer runs, e.g. "every 24 hours" or
+			// "first sunday of quarter 00:00". Defaults to the data source's
+			// default schedule.
+			"schedule": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"data_refresh_window_days": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// Location: The location of the destination dataset. Transfer
+			// configs of datasets in the US multi-region live in the project
+			// itself rather than in a location.
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "US",
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBigQueryDataTransferConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	parent := "projects/" + project
+	if location := d.Get("location").(string); location != "US" {
+		parent += "/locations/" + strings.ToLower(location)
+	}
+
+	transferConfig := expandBigQueryDataTransferConfig(d)
+	transferConfig.DataSourceId = d.Get("data_source_id").(string)
+
+	log.Printf("[DEBUG] Creating BigQuery data transfer config %+v", transferConfig)
+	res, err := config.clientBigQueryDataTransfer.CreateTransferConfig(parent, transferConfig)
+	if err != nil {
+		return fmt.Errorf("Error creating BigQuery data transfer config: %s", err)
+	}
+
+	d.SetId(res.Name)
+
+	return resourceBigQueryDataTransferConfigRead(d, meta)
+}
+
+func resourceBigQueryDataTransferConfigRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	res, err := config.clientBigQueryDataTransfer.GetTransferConfig(d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("BigQuery data transfer config %q", d.Id()))
+	}
+
+	params := make(map[string]string, len(res.Params))
+	for k, v := range res.Params {
+		params[k] = fmt.Sprint(v)
+	}
+
+	d.Set("name", res.Name)
+	d.Set("display_name", res.DisplayName)
+	d.Set("data_source_id", res.DataSourceId)
+	d.Set("destination_dataset_id", res.DestinationDatasetId)
+	d.Set("params", params)
+	d.Set("schedule", res.Schedule)
+	d.Set("data_refresh_window_days", res.DataRefreshWindowDays)
+	d.Set("disabled", res.Disabled)
+
+	return nil
+}
+
+func resourceBigQueryDataTransferConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var fields []string
+	for field, mask := range map[string]string{
+		"display_name":             "display_name",
+		"destination_dataset_id":   "destination_dataset_id",
+		"params":                   "params",
+		"schedule":                 "schedule",
+		"data_refresh_window_days": "data_refresh_window_days",
+		"disabled":                 "disabled",
+	} {
+		if d.HasChange(field) {
+			fields = append(fields, mask)
+		}
+	}
+
+	if len(fields) > 0 {
+		_, err := config.clientBigQueryDataTransfer.UpdateTransferConfig(d.Id(), expandBigQueryDataTransferConfig(d), strings.Join(fields, ","))
+		if err != nil {
+			return fmt.Errorf("Error updating BigQuery data transfer config %q: %s", d.Id(), err)
+		}
+	}
+
+	return resourceBigQueryDataTransferConfigRead(d, meta)
+}
+
+func resourceBigQueryDataTransferConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if err := config.clientBigQueryDataTransfer.DeleteTransferConfig(d.Id()); err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("BigQuery data transfer config %q", d.Id()))
+	}
+
+	return nil
+}
+
+var bigqueryDataTransferConfigNameRegex = regexp.MustCompile("^projects/([^/]+)(?:/locations/([^/]+))?/transferConfigs/[^/]+$")
+
+func resourceBigQueryDataTransferConfigImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := bigqueryDataTransferConfigNameRegex.FindStringSubmatch(d.Id())
+	if parts == nil {
+		return nil, fmt.Errorf("Invalid transfer config name %q, expected projects/{project}/locations/{location}/transferConfigs/{id}", d.Id())
+	}
+
+	// Names use lower case locations, the multi-regions are configured in
+	// upper case like the datasets'.
+	d.Set("project", parts[1])
+	switch parts[2] {
+	case "", "us":
+		d.Set("location", "US")
+	case "eu":
+		d.Set("location", "EU")
+	default:
+		d.Set("location", parts[2])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandBigQueryDataTransferConfig(d *schema.ResourceData) *bigqueryDataTransferConfig {
+	params := make(map[string]interface{})
+	for k, v := range d.Get("params").(map[string]interface{}) {
+		params[k] = v.(string)
+	}
+
+	return &bigqueryDataTransferConfig{
+		DisplayName:           d.Get("display_name").(string),
+		DestinationDatasetId:  d.Get("destination_dataset_id").(string),
+		Schedule:              d.Get("schedule").(string),
+		DataRefreshWindowDays: d.Get("data_refresh_window_days").(int),
+		Disabled:              d.Get("disabled").(bool),
+		Params:                params,
+	}
+}
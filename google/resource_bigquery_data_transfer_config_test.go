@@ -0,0 +1,75 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBigQueryDataTransferConfig_scheduledQuery(t *testing.T) {
+	t.Parallel()
+
+	datasetID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	configName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigQueryDataTransferConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigQueryDataTransferConfig_scheduledQuery(datasetID, configName, "every 24 hours", "SELECT 1 AS one"),
+			},
+			{
+				Config: testAccBigQueryDataTransferConfig_scheduledQuery(datasetID, configName, "every 12 hours", "SELECT 2 AS two"),
+				Check:  resource.TestCheckResourceAttr("google_bigquery_data_transfer_config.query", "schedule", "every 12 hours"),
+			},
+			{
+				ResourceName:      "google_bigquery_data_transfer_config.query",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBigQueryDataTransferConfigDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_bigquery_data_transfer_config" {
+			continue
+		}
+
+		if _, err := config.clientBigQueryDataTransfer.GetTransferConfig(rs.Primary.ID); err == nil {
+			return fmt.Errorf("BigQuery data transfer config %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccBigQueryDataTransferConfig_scheduledQuery(datasetID, configName, schedule, query string) string {
+	return fmt.Sprintf(`
+resource "google_bigquery_dataset" "dataset" {
+  dataset_id                 = "%s"
+  delete_contents_on_destroy = true
+}
+
+resource "google_bigquery_data_transfer_config" "query" {
+  display_name           = "%s"
+  data_source_id         = "scheduled_query"
+  destination_dataset_id = "${google_bigquery_dataset.dataset.dataset_id}"
+  schedule               = "%s"
+
+  params {
+    destination_table_name_template = "results"
+    write_disposition               = "WRITE_TRUNCATE"
+    query                           = "%s"
+  }
+}
+`, datasetID, configName, schedule, query)
+}
@@ -18,7 +18,7 @@ func resourceBigQueryDataset() *schema.Resource {
 		Update: resourceBigQueryDatasetUpdate,
 		Delete: resourceBigQueryDatasetDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceBigQueryDatasetImportState,
 		},
 		Schema: map[string]*schema.Schema{
 			// DatasetId: [Required] A unique ID for this dataset, without the
@@ -107,6 +107,77 @@ func resourceBigQueryDataset() *schema.Resource {
 				Elem:     schema.TypeString,
 			},
 
+			// Access: [Optional] An array of objects that define dataset access
+			// for one or more entities. If unspecified at dataset creation time,
+			// BigQuery adds default dataset access for the project's owners,
+			// writers and readers and the dataset creator.
+			"access": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Role: The role (rights) granted to the entity. Not set
+						// for view entries.
+						"role": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"OWNER", "WRITER", "READER"}, false),
+						},
+						"user_by_email": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"group_by_email": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"domain": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						// SpecialGroup: projectOwners, projectReaders,
+						// projectWriters or allAuthenticatedUsers.
+						"special_group": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						// View: An authorized view from another dataset that can
+						// query this dataset's tables.
+						"view": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"project_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"dataset_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"table_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			// DeleteContentsOnDestroy: Terraform-only. Whether the tables in the
+			// dataset are deleted along with it; otherwise deleting a dataset that
+			// still has tables fails.
+			"delete_contents_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			// SelfLink: [Output-only] A URL that can be used to access the resource
 			// again. You can use this URL in Get or Update requests to the
 			// resource.
@@ -179,9 +250,61 @@ func resourceDataset(d *schema.ResourceData, meta interface{}) (*bigquery.Datase
 		dataset.Labels = labels
 	}
 
+	if v, ok := d.GetOk("access"); ok {
+		dataset.Access = expandBigQueryDatasetAccess(v.(*schema.Set).List())
+	}
+
 	return dataset, nil
 }
 
+func expandBigQueryDatasetAccess(configured []interface{}) []*bigquery.DatasetAccess {
+	access := make([]*bigquery.DatasetAccess, 0, len(configured))
+	for _, raw := range configured {
+		data := raw.(map[string]interface{})
+		a := &bigquery.DatasetAccess{
+			Role:         data["role"].(string),
+			UserByEmail:  data["user_by_email"].(string),
+			GroupByEmail: data["group_by_email"].(string),
+			Domain:       data["domain"].(string),
+			SpecialGroup: data["special_group"].(string),
+		}
+		if v := data["view"].([]interface{}); len(v) > 0 {
+			view := v[0].(map[string]interface{})
+			a.View = &bigquery.TableReference{
+				ProjectId: view["project_id"].(string),
+				DatasetId: view["dataset_id"].(string),
+				TableId:   view["table_id"].(string),
+			}
+		}
+		access = append(access, a)
+	}
+	return access
+}
+
+func flattenBigQueryDatasetAccess(access []*bigquery.DatasetAccess) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(access))
+	for _, a := range access {
+		data := map[string]interface{}{
+			"role":           a.Role,
+			"user_by_email":  a.UserByEmail,
+			"group_by_email": a.GroupByEmail,
+			"domain":         a.Domain,
+			"special_group":  a.SpecialGroup,
+		}
+		if a.View != nil {
+			data["view"] = []map[string]interface{}{
+				{
+					"project_id": a.View.ProjectId,
+					"dataset_id": a.View.DatasetId,
+					"table_id":   a.View.TableId,
+				},
+			}
+		}
+		result = append(result, data)
+	}
+	return result
+}
+
 func resourceBigQueryDatasetCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -236,6 +359,9 @@ func resourceBigQueryDatasetRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("last_modified_time", res.LastModifiedTime)
 	d.Set("dataset_id", res.DatasetReference.DatasetId)
 	d.Set("default_table_expiration_ms", res.DefaultTableExpirationMs)
+	if err := d.Set("access", flattenBigQueryDatasetAccess(res.Access)); err != nil {
+		return fmt.Errorf("Error reading access: %s", err)
+	}
 
 	// Older Tables in BigQuery have no Location set in the API response. This may be an issue when importing
 	// tables created before BigQuery was available in multiple zones. We can safely assume that these tables
@@ -268,6 +394,11 @@ func resourceBigQueryDatasetUpdate(d *schema.ResourceData, meta interface{}) err
 	return resourceBigQueryDatasetRead(d, meta)
 }
 
+func resourceBigQueryDatasetImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("delete_contents_on_destroy", false)
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceBigQueryDatasetDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -275,7 +406,8 @@ func resourceBigQueryDatasetDelete(d *schema.ResourceData, meta interface{}) err
 
 	projectID, datasetID := resourceBigQueryDatasetParseID(d.Id())
 
-	if err := config.clientBigQuery.Datasets.Delete(projectID, datasetID).Do(); err != nil {
+	deleteContents := d.Get("delete_contents_on_destroy").(bool)
+	if err := config.clientBigQuery.Datasets.Delete(projectID, datasetID).DeleteContents(deleteContents).Do(); err != nil {
 		return err
 	}
 
@@ -38,6 +38,34 @@ func TestAccBigQueryDataset_basic(t *testing.T) {
 	})
 }
 
+func TestAccBigQueryDataset_access(t *testing.T) {
+	t.Parallel()
+
+	datasetID := fmt.Sprintf("tf_test_access_%s", acctest.RandString(10))
+	otherDatasetID := fmt.Sprintf("tf_test_other_%s", acctest.RandString(10))
+	tableID := fmt.Sprintf("tf_test_view_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigQueryDatasetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigQueryDatasetWithAccess(datasetID, otherDatasetID, tableID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBigQueryDatasetExists("google_bigquery_dataset.access_test"),
+					resource.TestCheckResourceAttr("google_bigquery_dataset.access_test", "access.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "google_bigquery_dataset.access_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckBigQueryDatasetDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -112,3 +140,44 @@ resource "google_bigquery_dataset" "test" {
   }
 }`, datasetID)
 }
+
+func testAccBigQueryDatasetWithAccess(datasetID, otherDatasetID, tableID string) string {
+	return fmt.Sprintf(`
+resource "google_bigquery_dataset" "other_dataset" {
+  dataset_id = "%s"
+}
+
+resource "google_bigquery_table" "table_with_view" {
+  table_id   = "%s"
+  dataset_id = "${google_bigquery_dataset.other_dataset.dataset_id}"
+
+  view {
+    query          = "SELECT state FROM [lookerdata:cdc.project_tycho_reports]"
+    use_legacy_sql = true
+  }
+}
+
+resource "google_bigquery_dataset" "access_test" {
+  dataset_id                 = "%s"
+  delete_contents_on_destroy = true
+
+  access {
+    role          = "OWNER"
+    special_group = "projectOwners"
+  }
+
+  access {
+    role          = "READER"
+    special_group = "projectReaders"
+  }
+
+  access {
+    view {
+      project_id = "%s"
+      dataset_id = "${google_bigquery_dataset.other_dataset.dataset_id}"
+      table_id   = "${google_bigquery_table.table_with_view.table_id}"
+    }
+  }
+}
+`, otherDatasetID, tableID, datasetID, getTestProjectFromEnv())
+}
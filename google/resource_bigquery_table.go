@@ -80,6 +80,67 @@ func resourceBigQueryTable() *schema.Resource {
 				Elem:     schema.TypeString,
 			},
 
+			// ExternalDataConfiguration: [Optional] If specified, configures
+			// this table as an external table over data stored outside of
+			// BigQuery, such as files in Google Cloud Storage.
+			"external_data_configuration": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Autodetect: [Optional] Try to detect the schema and format
+						// options automatically.
+						"autodetect": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						// Compression: [Optional] The compression type of the data
+						// source, GZIP or NONE.
+						"compression": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "GZIP"}, false),
+						},
+
+						// IgnoreUnknownValues: [Optional] Whether values which aren't
+						// represented in the table schema are ignored rather than
+						// treated as bad records.
+						"ignore_unknown_values": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						// MaxBadRecords: [Optional] The maximum number of bad records
+						// BigQuery ignores when reading the data.
+						"max_bad_records": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						// SourceFormat: [Required] The data format.
+						"source_format": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"CSV", "GOOGLE_SHEETS", "NEWLINE_DELIMITED_JSON", "AVRO", "PARQUET", "DATASTORE_BACKUP", "BIGTABLE",
+							}, false),
+						},
+
+						// SourceUris: [Required] The fully-qualified URIs of the data,
+						// each Google Cloud Storage URI may contain one '*' wildcard
+						// after the bucket name.
+						"source_uris": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
 			// Schema: [Optional] Describes the schema of this table.
 			"schema": {
 				Type:         schema.TypeString,
@@ -253,6 +314,10 @@ func resourceTable(d *schema.ResourceData, meta interface{}) (*bigquery.Table, e
 		table.Labels = labels
 	}
 
+	if v, ok := d.GetOk("external_data_configuration"); ok {
+		table.ExternalDataConfiguration = expandExternalDataConfiguration(v)
+	}
+
 	if v, ok := d.GetOk("schema"); ok {
 		schema, err := expandSchema(v)
 		if err != nil {
@@ -337,6 +402,12 @@ func resourceBigQueryTableRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if res.ExternalDataConfiguration != nil {
+		if err := d.Set("external_data_configuration", flattenExternalDataConfiguration(res.ExternalDataConfiguration)); err != nil {
+			return err
+		}
+	}
+
 	if res.Schema != nil {
 		schema, err := flattenSchema(res.Schema)
 		if err != nil {
@@ -408,6 +479,39 @@ func flattenSchema(tableSchema *bigquery.TableSchema) (string, error) {
 	return string(schema), nil
 }
 
+func expandExternalDataConfiguration(configured interface{}) *bigquery.ExternalDataConfiguration {
+	raw := configured.([]interface{})[0].(map[string]interface{})
+	edc := &bigquery.ExternalDataConfiguration{
+		Autodetect:          raw["autodetect"].(bool),
+		Compression:         raw["compression"].(string),
+		IgnoreUnknownValues: raw["ignore_unknown_values"].(bool),
+		MaxBadRecords:       int64(raw["max_bad_records"].(int)),
+		SourceFormat:        raw["source_format"].(string),
+		SourceUris:          convertStringArr(raw["source_uris"].([]interface{})),
+	}
+
+	return edc
+}
+
+func flattenExternalDataConfiguration(edc *bigquery.ExternalDataConfiguration) []map[string]interface{} {
+	// The API leaves out the default compression.
+	compression := edc.Compression
+	if compression == "" {
+		compression = "NONE"
+	}
+
+	result := map[string]interface{}{
+		"autodetect":            edc.Autodetect,
+		"compression":           compression,
+		"ignore_unknown_values": edc.IgnoreUnknownValues,
+		"max_bad_records":       edc.MaxBadRecords,
+		"source_format":         edc.SourceFormat,
+		"source_uris":           edc.SourceUris,
+	}
+
+	return []map[string]interface{}{result}
+}
+
 func expandTimePartitioning(configured interface{}) *bigquery.TimePartitioning {
 	raw := configured.([]interface{})[0].(map[string]interface{})
 	tp := &bigquery.TimePartitioning{Type: raw["type"].(string)}
@@ -62,6 +62,34 @@ func TestAccBigQueryTable_View(t *testing.T) {
 	})
 }
 
+func TestAccBigQueryTable_ExternalData(t *testing.T) {
+	t.Parallel()
+
+	bucketName := testBucketName()
+	datasetID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	tableID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigQueryTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigQueryTableWithExternalData(bucketName, datasetID, tableID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigQueryTableExists("google_bigquery_table.test"),
+					resource.TestCheckResourceAttr("google_bigquery_table.test", "type", "EXTERNAL"),
+				),
+			},
+			{
+				ResourceName:      "google_bigquery_table.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccBigQueryTable_ViewWithLegacySQL(t *testing.T) {
 	t.Parallel()
 
@@ -247,6 +275,37 @@ resource "google_bigquery_table" "test" {
 }`, datasetID, tableID)
 }
 
+func testAccBigQueryTableWithExternalData(bucketName, datasetID, tableID string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "test" {
+  name          = "%s"
+  force_destroy = true
+}
+
+resource "google_storage_bucket_object" "part" {
+  bucket  = "${google_storage_bucket.test.name}"
+  name    = "wordcount/part-00000"
+  content = "word,count\nhello,1\n"
+}
+
+resource "google_bigquery_dataset" "test" {
+  dataset_id = "%s"
+}
+
+resource "google_bigquery_table" "test" {
+  table_id   = "%s"
+  dataset_id = "${google_bigquery_dataset.test.dataset_id}"
+
+  external_data_configuration {
+    autodetect    = true
+    source_format = "CSV"
+    source_uris   = ["gs://${google_storage_bucket.test.name}/wordcount/part-*"]
+  }
+
+  depends_on = ["google_storage_bucket_object.part"]
+}`, bucketName, datasetID, tableID)
+}
+
 func testAccBigQueryTableWithNewSqlView(datasetID, tableID string) string {
 	return fmt.Sprintf(`
 resource "google_bigquery_dataset" "test" {
@@ -15,6 +15,7 @@ func resourceBigtableInstance() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigtableInstanceCreate,
 		Read:   resourceBigtableInstanceRead,
+		Update: resourceBigtableInstanceUpdate,
 		Delete: resourceBigtableInstanceDestroy,
 
 		Schema: map[string]*schema.Schema{
@@ -46,7 +47,7 @@ func resourceBigtableInstance() *schema.Resource {
 			"num_nodes": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
+				Computed: true,
 			},
 
 			"instance_type": {
@@ -163,9 +164,46 @@ func resourceBigtableInstanceRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("name", instance.Name)
 	d.Set("display_name", instance.DisplayName)
 
+	// DEVELOPMENT instances don't have a configurable number of nodes.
+	if d.Get("instance_type").(string) == "PRODUCTION" {
+		cluster, err := config.clientBigtableAdmin.GetCluster(bigtableClusterName(project, d.Id(), d.Get("cluster_id").(string)))
+		if err != nil {
+			return fmt.Errorf("Error retrieving cluster of instance %s. %s", d.Id(), err)
+		}
+		d.Set("num_nodes", cluster.ServeNodes)
+	}
+
 	return nil
 }
 
+func resourceBigtableInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("num_nodes") {
+		numNodes := d.Get("num_nodes").(int)
+		if d.Get("instance_type").(string) == "DEVELOPMENT" {
+			return fmt.Errorf("Can't specify a non-zero number of nodes: %d for DEVELOPMENT Bigtable instance: %s", numNodes, d.Id())
+		}
+
+		op, err := config.clientBigtableAdmin.UpdateCluster(bigtableClusterName(project, d.Id(), d.Get("cluster_id").(string)), int64(numNodes))
+		if err != nil {
+			return fmt.Errorf("Error resizing cluster of instance %s. %s", d.Id(), err)
+		}
+
+		err = bigtableAdminOperationWait(config, op, "resizing Bigtable cluster")
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceBigtableInstanceRead(d, meta)
+}
+
 func resourceBigtableInstanceDestroy(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	ctx := context.Background()
@@ -192,3 +230,7 @@ func resourceBigtableInstanceDestroy(d *schema.ResourceData, meta interface{}) e
 
 	return nil
 }
+
+func bigtableClusterName(project, instance, cluster string) string {
+	return fmt.Sprintf("projects/%s/instances/%s/clusters/%s", project, instance, cluster)
+}
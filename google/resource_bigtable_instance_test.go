@@ -31,6 +31,36 @@ func TestAccBigtableInstance_basic(t *testing.T) {
 	})
 }
 
+func TestAccBigtableInstance_resize(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigtableInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigtableInstance(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigtableInstanceExists(
+						"google_bigtable_instance.instance"),
+					resource.TestCheckResourceAttr("google_bigtable_instance.instance", "num_nodes", "3"),
+				),
+			},
+			{
+				Config: testAccBigtableInstance_numNodes(instanceName, 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigtableInstanceExists(
+						"google_bigtable_instance.instance"),
+					resource.TestCheckResourceAttr("google_bigtable_instance.instance", "num_nodes", "4"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccBigtableInstance_development(t *testing.T) {
 	t.Parallel()
 
@@ -116,6 +146,18 @@ resource "google_bigtable_instance" "instance" {
 `, instanceName, instanceName)
 }
 
+func testAccBigtableInstance_numNodes(instanceName string, numNodes int) string {
+	return fmt.Sprintf(`
+resource "google_bigtable_instance" "instance" {
+	name         = "%s"
+	cluster_id   = "%s"
+	zone         = "us-central1-b"
+	num_nodes    = %d
+	storage_type = "HDD"
+}
+`, instanceName, instanceName, numNodes)
+}
+
 func testAccBigtableInstance_development(instanceName string) string {
 	return fmt.Sprintf(`
 resource "google_bigtable_instance" "instance" {
@@ -13,6 +13,7 @@ func resourceBigtableTable() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigtableTableCreate,
 		Read:   resourceBigtableTableRead,
+		Update: resourceBigtableTableUpdate,
 		Delete: resourceBigtableTableDestroy,
 
 		Schema: map[string]*schema.Schema{
@@ -35,6 +36,19 @@ func resourceBigtableTable() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			"column_family": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"family": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
 			"project": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -79,6 +93,13 @@ func resourceBigtableTableCreate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	for _, family := range d.Get("column_family").(*schema.Set).List() {
+		f := family.(map[string]interface{})["family"].(string)
+		if err := c.CreateColumnFamily(ctx, name, f); err != nil {
+			return fmt.Errorf("Error creating column family %s. %s", f, err)
+		}
+	}
+
 	d.SetId(name)
 
 	return resourceBigtableTableRead(d, meta)
@@ -102,16 +123,67 @@ func resourceBigtableTableRead(d *schema.ResourceData, meta interface{}) error {
 	defer c.Close()
 
 	name := d.Id()
-	_, err = c.TableInfo(ctx, name)
+	table, err := c.TableInfo(ctx, name)
 	if err != nil {
 		log.Printf("[WARN] Removing %s because it's gone", name)
 		d.SetId("")
 		return fmt.Errorf("Error retrieving table. Could not find %s in %s. %s", name, instanceName, err)
 	}
 
+	// Only the families declared in the configuration are managed, so that
+	// families created outside of Terraform are never deleted by Update.
+	declared := d.Get("column_family").(*schema.Set)
+	families := make([]string, 0, len(table.Families))
+	for _, f := range table.Families {
+		if declared.Contains(map[string]interface{}{"family": f}) {
+			families = append(families, f)
+		}
+	}
+	d.Set("column_family", flattenColumnFamily(families))
+
 	return nil
 }
 
+func resourceBigtableTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	ctx := context.Background()
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	instanceName := d.Get("instance_name").(string)
+	c, err := config.bigtableClientFactory.NewAdminClient(project, instanceName)
+	if err != nil {
+		return fmt.Errorf("Error starting admin client. %s", err)
+	}
+
+	defer c.Close()
+
+	name := d.Get("name").(string)
+	o, n := d.GetChange("column_family")
+	oldFamilies, newFamilies := o.(*schema.Set), n.(*schema.Set)
+
+	for _, family := range oldFamilies.Difference(newFamilies).List() {
+		f := family.(map[string]interface{})["family"].(string)
+		log.Printf("[DEBUG] Deleting column family %s from table %s", f, name)
+		if err := c.DeleteColumnFamily(ctx, name, f); err != nil {
+			return fmt.Errorf("Error deleting column family %s. %s", f, err)
+		}
+	}
+
+	for _, family := range newFamilies.Difference(oldFamilies).List() {
+		f := family.(map[string]interface{})["family"].(string)
+		log.Printf("[DEBUG] Creating column family %s in table %s", f, name)
+		if err := c.CreateColumnFamily(ctx, name, f); err != nil {
+			return fmt.Errorf("Error creating column family %s. %s", f, err)
+		}
+	}
+
+	return resourceBigtableTableRead(d, meta)
+}
+
 func resourceBigtableTableDestroy(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	ctx := context.Background()
@@ -139,3 +211,15 @@ func resourceBigtableTableDestroy(d *schema.ResourceData, meta interface{}) erro
 
 	return nil
 }
+
+func flattenColumnFamily(families []string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(families))
+
+	for _, f := range families {
+		result = append(result, map[string]interface{}{
+			"family": f,
+		})
+	}
+
+	return result
+}
@@ -54,6 +54,68 @@ func TestAccBigtableTable_splitKeys(t *testing.T) {
 	})
 }
 
+func TestAccBigtableTable_family(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	tableName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigtableTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigtableTable_family(instanceName, tableName, "cf1", "cf2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigtableTableExists(
+						"google_bigtable_table.table"),
+					resource.TestCheckResourceAttr("google_bigtable_table.table", "column_family.#", "2"),
+				),
+			},
+			{
+				Config: testAccBigtableTable_family(instanceName, tableName, "cf2", "cf3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigtableTableExists(
+						"google_bigtable_table.table"),
+					resource.TestCheckResourceAttr("google_bigtable_table.table", "column_family.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigtableTable_familyCreatedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	tableName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigtableTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigtableTable_family(instanceName, tableName, "cf1", "cf2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigtableTableExists(
+						"google_bigtable_table.table"),
+					// The plan run after this step must not delete it.
+					testAccBigtableTableCreateColumnFamily("google_bigtable_table.table", "external"),
+				),
+			},
+			{
+				Config: testAccBigtableTable_family(instanceName, tableName, "cf1", "cf3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_bigtable_table.table", "column_family.#", "2"),
+					testAccBigtableTableHasColumnFamily("google_bigtable_table.table", "external"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckBigtableTableDestroy(s *terraform.State) error {
 	var ctx = context.Background()
 	for _, rs := range s.RootModule().Resources {
@@ -107,6 +169,53 @@ func testAccBigtableTableExists(n string) resource.TestCheckFunc {
 	}
 }
 
+// testAccBigtableTableCreateColumnFamily adds family to the table behind the
+// Terraform resource n, like a user or application working on the table.
+func testAccBigtableTableCreateColumnFamily(n, family string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		c, err := config.bigtableClientFactory.NewAdminClient(config.Project, rs.Primary.Attributes["instance_name"])
+		if err != nil {
+			return fmt.Errorf("Error starting admin client. %s", err)
+		}
+		defer c.Close()
+
+		return c.CreateColumnFamily(context.Background(), rs.Primary.Attributes["name"], family)
+	}
+}
+
+func testAccBigtableTableHasColumnFamily(n, family string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		c, err := config.bigtableClientFactory.NewAdminClient(config.Project, rs.Primary.Attributes["instance_name"])
+		if err != nil {
+			return fmt.Errorf("Error starting admin client. %s", err)
+		}
+		defer c.Close()
+
+		table, err := c.TableInfo(context.Background(), rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error retrieving table. %s", err)
+		}
+		for _, f := range table.Families {
+			if f == family {
+				return nil
+			}
+		}
+		return fmt.Errorf("Column family %s of %s was deleted", family, rs.Primary.Attributes["name"])
+	}
+}
+
 func testAccBigtableTable(instanceName, tableName string) string {
 	return fmt.Sprintf(`
 resource "google_bigtable_instance" "instance" {
@@ -139,3 +248,27 @@ resource "google_bigtable_table" "table" {
 }
 `, instanceName, instanceName, tableName)
 }
+
+func testAccBigtableTable_family(instanceName, tableName, family1, family2 string) string {
+	return fmt.Sprintf(`
+resource "google_bigtable_instance" "instance" {
+  name          = "%s"
+  cluster_id    = "%s"
+  zone          = "us-central1-b"
+  instance_type = "DEVELOPMENT"
+}
+
+resource "google_bigtable_table" "table" {
+  name          = "%s"
+  instance_name = "${google_bigtable_instance.instance.name}"
+
+  column_family {
+    family = "%s"
+  }
+
+  column_family {
+    family = "%s"
+  }
+}
+`, instanceName, instanceName, tableName, family1, family2)
+}
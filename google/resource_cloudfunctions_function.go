@@ -0,0 +1,384 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceCloudFunctionsFunction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudFunctionsFunctionCreate,
+		Read:   resourceCloudFunctionsFunctionRead,
+		Update: resourceCloudFunctionsFunctionUpdate,
+		Delete: resourceCloudFunctionsFunctionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCloudFunctionsFunctionImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRegexp(`^[a-zA-Z][a-zA-Z0-9_-]{0,62}$`),
+			},
+
+			"source_archive_bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// SourceArchiveObject: The zip archive of the function's source.
+			// Functions are only redeployed when the URL changes, so new
+			// versions of the source need a new object name.
+			"source_archive_object": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Runtime: The language runtime, such as nodejs8 or python37.
+			"runtime": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// EntryPoint: The name of the exported function to run. Defaults
+			// to the function's name.
+			"entry_point": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"available_memory_mb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      256,
+				ValidateFunc: validation.IntBetween(128, 2048),
+			},
+
+			// Timeout: The execution timeout in seconds.
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntBetween(1, 540),
+			},
+
+			// ServiceAccountEmail: The service account the function runs
+			// as. Defaults to the App Engine default service account.
+			"service_account_email": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"environment_variables": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"trigger_http": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"event_trigger"},
+			},
+
+			"event_trigger": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"trigger_http"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// EventType: The event to trigger on, such as
+						// google.storage.object.finalize or
+						// google.pubsub.topic.publish.
+						"event_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						// Resource: The bucket or topic the events come from.
+						"resource": {
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: compareSelfLinkOrResourceName,
+						},
+
+						"failure_policy": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"retry": {
+										Type:     schema.TypeBool,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"https_trigger_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudFunctionsFunctionCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	parent := fmt.Sprintf("projects/%s/locations/%s", project, region)
+	function := expandCloudFunctionsFunction(d)
+	function.Name = fmt.Sprintf("%s/functions/%s", parent, d.Get("name").(string))
+
+	if d.Get("trigger_http").(bool) {
+		function.HttpsTrigger = &cloudFunctionHttpsTrigger{}
+	} else if function.EventTrigger == nil {
+		return fmt.Errorf("One of trigger_http or event_trigger must be set")
+	}
+
+	log.Printf("[DEBUG] Creating Cloud Function %+v", function)
+	op, err := config.clientCloudFunctions.CreateFunction(parent, function)
+	if err != nil {
+		return fmt.Errorf("Error creating Cloud Function %q: %s", function.Name, err)
+	}
+
+	// The function exists as soon as the create call returns, so a failed
+	// deployment is left in state to be replaced on the next apply.
+	d.SetId(function.Name)
+
+	err = cloudFunctionsOperationWait(config, op, "creating Cloud Function", int(d.Timeout(schema.TimeoutCreate).Minutes()))
+	if err != nil {
+		return err
+	}
+
+	return resourceCloudFunctionsFunctionRead(d, meta)
+}
+
+func resourceCloudFunctionsFunctionRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	function, err := config.clientCloudFunctions.GetFunction(d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Cloud Function %q", d.Id()))
+	}
+
+	parts := cloudFunctionsFunctionNameRegex.FindStringSubmatch(function.Name)
+	if parts == nil {
+		return fmt.Errorf("Unexpected Cloud Function name %q", function.Name)
+	}
+	d.Set("project", parts[1])
+	d.Set("region", parts[2])
+	d.Set("name", parts[3])
+
+	// sourceArchiveUrl has the form gs://{bucket}/{object}.
+	source := strings.SplitN(strings.TrimPrefix(function.SourceArchiveUrl, "gs://"), "/", 2)
+	if len(source) == 2 {
+		d.Set("source_archive_bucket", source[0])
+		d.Set("source_archive_object", source[1])
+	}
+
+	timeout, err := time.ParseDuration(function.Timeout)
+	if err != nil {
+		return fmt.Errorf("Error parsing timeout %q of Cloud Function %q: %s", function.Timeout, d.Id(), err)
+	}
+
+	d.Set("runtime", function.Runtime)
+	d.Set("description", function.Description)
+	d.Set("entry_point", function.EntryPoint)
+	d.Set("available_memory_mb", function.AvailableMemoryMb)
+	d.Set("timeout", int(timeout.Seconds()))
+	d.Set("service_account_email", function.ServiceAccountEmail)
+	d.Set("labels", function.Labels)
+	d.Set("environment_variables", function.EnvironmentVariables)
+
+	d.Set("trigger_http", function.HttpsTrigger != nil)
+	if function.HttpsTrigger != nil {
+		d.Set("https_trigger_url", function.HttpsTrigger.Url)
+	}
+	d.Set("event_trigger", flattenCloudFunctionsEventTrigger(function.EventTrigger))
+
+	return nil
+}
+
+func resourceCloudFunctionsFunctionUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var fields []string
+	for field, mask := range map[string]string{
+		"source_archive_bucket": "sourceArchiveUrl",
+		"source_archive_object": "sourceArchiveUrl",
+		"runtime":               "runtime",
+		"description":           "description",
+		"entry_point":           "entryPoint",
+		"available_memory_mb":   "availableMemoryMb",
+		"timeout":               "timeout",
+		"service_account_email": "serviceAccountEmail",
+		"labels":                "labels",
+		"environment_variables": "environmentVariables",
+		"event_trigger":         "eventTrigger",
+	} {
+		if d.HasChange(field) && !stringInSlice(fields, mask) {
+			fields = append(fields, mask)
+		}
+	}
+
+	if len(fields) > 0 {
+		op, err := config.clientCloudFunctions.UpdateFunction(d.Id(), expandCloudFunctionsFunction(d), strings.Join(fields, ","))
+		if err != nil {
+			return fmt.Errorf("Error updating Cloud Function %q: %s", d.Id(), err)
+		}
+
+		err = cloudFunctionsOperationWait(config, op, "updating Cloud Function", int(d.Timeout(schema.TimeoutUpdate).Minutes()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudFunctionsFunctionRead(d, meta)
+}
+
+func resourceCloudFunctionsFunctionDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	op, err := config.clientCloudFunctions.DeleteFunction(d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Cloud Function %q", d.Id()))
+	}
+
+	err = cloudFunctionsOperationWait(config, op, "deleting Cloud Function", int(d.Timeout(schema.TimeoutDelete).Minutes()))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var cloudFunctionsFunctionNameRegex = regexp.MustCompile("^projects/([^/]+)/locations/([^/]+)/functions/([^/]+)$")
+
+func resourceCloudFunctionsFunctionImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if cloudFunctionsFunctionNameRegex.MatchString(d.Id()) {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid Cloud Function specifier %q, expected {project}/{region}/{name} or projects/{project}/locations/{region}/functions/{name}", d.Id())
+	}
+	d.SetId(fmt.Sprintf("projects/%s/locations/%s/functions/%s", parts[0], parts[1], parts[2]))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandCloudFunctionsFunction(d *schema.ResourceData) *cloudFunction {
+	return &cloudFunction{
+		Description:          d.Get("description").(string),
+		SourceArchiveUrl:     fmt.Sprintf("gs://%s/%s", d.Get("source_archive_bucket").(string), d.Get("source_archive_object").(string)),
+		EntryPoint:           d.Get("entry_point").(string),
+		Runtime:              d.Get("runtime").(string),
+		Timeout:              fmt.Sprintf("%ds", d.Get("timeout").(int)),
+		AvailableMemoryMb:    d.Get("available_memory_mb").(int),
+		ServiceAccountEmail:  d.Get("service_account_email").(string),
+		Labels:               convertStringMap(d.Get("labels").(map[string]interface{})),
+		EnvironmentVariables: convertStringMap(d.Get("environment_variables").(map[string]interface{})),
+		EventTrigger:         expandCloudFunctionsEventTrigger(d.Get("event_trigger").([]interface{})),
+	}
+}
+
+func expandCloudFunctionsEventTrigger(configured []interface{}) *cloudFunctionEventTrigger {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+
+	data := configured[0].(map[string]interface{})
+	eventTrigger := &cloudFunctionEventTrigger{
+		EventType: data["event_type"].(string),
+		Resource:  data["resource"].(string),
+	}
+
+	if policies := data["failure_policy"].([]interface{}); len(policies) > 0 && policies[0] != nil {
+		eventTrigger.FailurePolicy = &cloudFunctionFailurePolicy{}
+		if policies[0].(map[string]interface{})["retry"].(bool) {
+			eventTrigger.FailurePolicy.Retry = &struct{}{}
+		}
+	}
+
+	return eventTrigger
+}
+
+func flattenCloudFunctionsEventTrigger(eventTrigger *cloudFunctionEventTrigger) []map[string]interface{} {
+	if eventTrigger == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"event_type": eventTrigger.EventType,
+			"resource":   eventTrigger.Resource,
+			"failure_policy": []map[string]interface{}{
+				{
+					"retry": eventTrigger.FailurePolicy != nil && eventTrigger.FailurePolicy.Retry != nil,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package google
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testCloudFunctionsHttpSource = `exports.helloHttp = (req, res) => {
+  res.send("Hello " + process.env.GREETING_TARGET);
+};
+`
+
+const testCloudFunctionsStorageSource = `exports.helloGCS = (data, context) => {
+  console.log("Got " + data.name);
+};
+`
+
+func TestAccCloudFunctionsFunction_http(t *testing.T) {
+	t.Parallel()
+
+	bucketName := fmt.Sprintf("tf-test-bucket-%s", acctest.RandString(10))
+	functionName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	zipPath := testAccCloudFunctionsCreateZip(t, testCloudFunctionsHttpSource)
+	defer os.Remove(zipPath)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFunctionsFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudFunctionsFunction_http(bucketName, functionName, zipPath, 128, "world"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("google_cloudfunctions_function.function", "https_trigger_url"),
+					resource.TestCheckResourceAttr("google_cloudfunctions_function.function", "entry_point", "helloHttp"),
+				),
+			},
+			{
+				Config: testAccCloudFunctionsFunction_http(bucketName, functionName, zipPath, 256, "everyone"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_cloudfunctions_function.function", "available_memory_mb", "256"),
+					resource.TestCheckResourceAttr("google_cloudfunctions_function.function", "environment_variables.GREETING_TARGET", "everyone"),
+				),
+			},
+			{
+				ResourceName:      "google_cloudfunctions_function.function",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionsFunction_storageTrigger(t *testing.T) {
+	t.Parallel()
+
+	bucketName := fmt.Sprintf("tf-test-bucket-%s", acctest.RandString(10))
+	functionName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	zipPath := testAccCloudFunctionsCreateZip(t, testCloudFunctionsStorageSource)
+	defer os.Remove(zipPath)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudFunctionsFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudFunctionsFunction_storageTrigger(bucketName, functionName, zipPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_cloudfunctions_function.function", "event_trigger.0.event_type", "google.storage.object.finalize"),
+					resource.TestCheckResourceAttr("google_cloudfunctions_function.function", "event_trigger.0.failure_policy.0.retry", "true"),
+				),
+			},
+			{
+				ResourceName:      "google_cloudfunctions_function.function",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudFunctionsFunctionDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_cloudfunctions_function" {
+			continue
+		}
+
+		if _, err := config.clientCloudFunctions.GetFunction(rs.Primary.ID); err == nil {
+			return fmt.Errorf("Cloud Function %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+// testAccCloudFunctionsCreateZip writes source to index.js in a temporary
+// zip archive and returns the archive's path.
+func testAccCloudFunctionsCreateZip(t *testing.T, source string) string {
+	f, err := ioutil.TempFile("", "tf-test-cloudfunctions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	index, err := w.Create("index.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := index.Write([]byte(source)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return f.Name()
+}
+
+func testAccCloudFunctionsFunction_http(bucketName, functionName, zipPath string, memory int, greetingTarget string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+  name = "%s"
+}
+
+resource "google_storage_bucket_object" "archive" {
+  name   = "index.zip"
+  bucket = "${google_storage_bucket.bucket.name}"
+  source = "%s"
+}
+
+resource "google_cloudfunctions_function" "function" {
+  name                  = "%s"
+  runtime               = "nodejs8"
+  description           = "test function"
+  source_archive_bucket = "${google_storage_bucket.bucket.name}"
+  source_archive_object = "${google_storage_bucket_object.archive.name}"
+  entry_point           = "helloHttp"
+  trigger_http          = true
+  available_memory_mb   = %d
+  timeout               = 61
+
+  labels {
+    my-label = "my-label-value"
+  }
+
+  environment_variables {
+    GREETING_TARGET = "%s"
+  }
+}
+`, bucketName, zipPath, functionName, memory, greetingTarget)
+}
+
+func testAccCloudFunctionsFunction_storageTrigger(bucketName, functionName, zipPath string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+  name = "%s"
+}
+
+resource "google_storage_bucket_object" "archive" {
+  name   = "index.zip"
+  bucket = "${google_storage_bucket.bucket.name}"
+  source = "%s"
+}
+
+resource "google_cloudfunctions_function" "function" {
+  name                  = "%s"
+  runtime               = "nodejs8"
+  source_archive_bucket = "${google_storage_bucket.bucket.name}"
+  source_archive_object = "${google_storage_bucket_object.archive.name}"
+  entry_point           = "helloGCS"
+
+  event_trigger {
+    event_type = "google.storage.object.finalize"
+    resource   = "${google_storage_bucket.bucket.name}"
+
+    failure_policy {
+      retry = true
+    }
+  }
+}
+`, bucketName, zipPath, functionName)
+}
@@ -17,10 +17,6 @@ const COMPUTE_FIREWALL_PRIORITY_DEFAULT = 1000
 
 var FirewallBaseApiVersion = v1
 var FirewallVersionedFeatures = []Feature{
-	Feature{Version: v0beta, Item: "deny"},
-	Feature{Version: v0beta, Item: "direction"},
-	Feature{Version: v0beta, Item: "destination_ranges"},
-	Feature{Version: v0beta, Item: "priority", DefaultValue: COMPUTE_FIREWALL_PRIORITY_DEFAULT},
 	Feature{Version: v0beta, Item: "source_service_accounts"},
 	Feature{Version: v0beta, Item: "target_service_accounts"},
 }
@@ -54,7 +50,6 @@ func resourceComputeFirewall() *schema.Resource {
 			"priority": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				Default:      COMPUTE_FIREWALL_PRIORITY_DEFAULT,
 				ValidateFunc: validation.IntBetween(0, 65535),
 			},
@@ -296,10 +291,6 @@ func resourceComputeFirewallRead(d *schema.ResourceData, meta interface{}) error
 		if err != nil {
 			return err
 		}
-		// During firewall conversion from v1 to v0beta, the value for Priority is read as 0 (as it doesn't exist in
-		// v1). Unfortunately this is a valid value, but not the same as the default. To avoid this, we explicitly set
-		// the default value here.
-		firewall.Priority = COMPUTE_FIREWALL_PRIORITY_DEFAULT
 	case v0beta:
 		firewallV0Beta, err := config.clientComputeBeta.Firewalls.Get(project, d.Id()).Do()
 		if err != nil {
@@ -76,7 +76,7 @@ func TestAccComputeFirewall_update(t *testing.T) {
 func TestAccComputeFirewall_priority(t *testing.T) {
 	t.Parallel()
 
-	var firewall computeBeta.Firewall
+	var firewall compute.Firewall
 	networkName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
 	firewallName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
 
@@ -84,15 +84,28 @@ func TestAccComputeFirewall_priority(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckComputeFirewallDestroy,
-		Steps: []resource.TestStep{{
-			Config: testAccComputeFirewall_priority(networkName, firewallName, 1001),
-			Check: resource.ComposeTestCheckFunc(
-				testAccCheckComputeBetaFirewallExists(
-					"google_compute_firewall.foobar", &firewall),
-				testAccCheckComputeFirewallHasPriority(&firewall, 1001),
-				testAccCheckComputeFirewallBetaApiVersion(&firewall),
-			),
-		}},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeFirewall_priority(networkName, firewallName, 1001),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeFirewallExists(
+						"google_compute_firewall.foobar", &firewall),
+					testAccCheckComputeFirewallHasPriority(&firewall, 1001),
+					testAccCheckComputeFirewallApiVersion(&firewall),
+					resource.TestCheckResourceAttr("google_compute_firewall.foobar", "priority", "1001"),
+				),
+			},
+			{
+				Config: testAccComputeFirewall_priority(networkName, firewallName, 900),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeFirewallExists(
+						"google_compute_firewall.foobar", &firewall),
+					testAccCheckComputeFirewallHasPriority(&firewall, 900),
+					testAccCheckComputeFirewallApiVersion(&firewall),
+					resource.TestCheckResourceAttr("google_compute_firewall.foobar", "priority", "900"),
+				),
+			},
+		},
 	})
 }
 
@@ -123,7 +136,7 @@ func TestAccComputeFirewall_noSource(t *testing.T) {
 func TestAccComputeFirewall_denied(t *testing.T) {
 	t.Parallel()
 
-	var firewall computeBeta.Firewall
+	var firewall compute.Firewall
 	networkName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
 	firewallName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
 
@@ -135,9 +148,9 @@ func TestAccComputeFirewall_denied(t *testing.T) {
 			resource.TestStep{
 				Config: testAccComputeFirewall_denied(networkName, firewallName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckComputeBetaFirewallExists("google_compute_firewall.foobar", &firewall),
-					testAccCheckComputeBetaFirewallDenyPorts(&firewall, "22"),
-					testAccCheckComputeFirewallBetaApiVersion(&firewall),
+					testAccCheckComputeFirewallExists("google_compute_firewall.foobar", &firewall),
+					testAccCheckComputeFirewallDenyPorts(&firewall, "22"),
+					testAccCheckComputeFirewallApiVersion(&firewall),
 				),
 			},
 		},
@@ -147,7 +160,7 @@ func TestAccComputeFirewall_denied(t *testing.T) {
 func TestAccComputeFirewall_egress(t *testing.T) {
 	t.Parallel()
 
-	var firewall computeBeta.Firewall
+	var firewall compute.Firewall
 	networkName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
 	firewallName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
 
@@ -159,9 +172,36 @@ func TestAccComputeFirewall_egress(t *testing.T) {
 			resource.TestStep{
 				Config: testAccComputeFirewall_egress(networkName, firewallName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckComputeBetaFirewallExists("google_compute_firewall.foobar", &firewall),
-					testAccCheckComputeBetaFirewallEgress(&firewall),
-					testAccCheckComputeFirewallBetaApiVersion(&firewall),
+					testAccCheckComputeFirewallExists("google_compute_firewall.foobar", &firewall),
+					testAccCheckComputeFirewallEgress(&firewall),
+					testAccCheckComputeFirewallApiVersion(&firewall),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeFirewall_egressDeny(t *testing.T) {
+	t.Parallel()
+
+	var firewall compute.Firewall
+	networkName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
+	firewallName := fmt.Sprintf("firewall-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeFirewallDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeFirewall_egressDeny(networkName, firewallName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeFirewallExists("google_compute_firewall.foobar", &firewall),
+					testAccCheckComputeFirewallEgress(&firewall),
+					testAccCheckComputeFirewallDenyPorts(&firewall, "25"),
+					testAccCheckComputeFirewallHasPriority(&firewall, 65000),
+					testAccCheckComputeFirewallApiVersion(&firewall),
+					resource.TestCheckResourceAttr("google_compute_firewall.foobar", "priority", "65000"),
 				),
 			},
 		},
@@ -245,7 +285,7 @@ func testAccCheckComputeFirewallExists(n string, firewall *compute.Firewall) res
 	}
 }
 
-func testAccCheckComputeFirewallHasPriority(firewall *computeBeta.Firewall, priority int) resource.TestCheckFunc {
+func testAccCheckComputeFirewallHasPriority(firewall *compute.Firewall, priority int) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if firewall.Priority != int64(priority) {
 			return fmt.Errorf("Priority for firewall does not match: expected %d, found %d", priority, firewall.Priority)
@@ -298,7 +338,7 @@ func testAccCheckComputeFirewallPorts(
 	}
 }
 
-func testAccCheckComputeBetaFirewallDenyPorts(firewall *computeBeta.Firewall, ports string) resource.TestCheckFunc {
+func testAccCheckComputeFirewallDenyPorts(firewall *compute.Firewall, ports string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(firewall.Denied) == 0 {
 			return fmt.Errorf("no denied rules")
@@ -312,7 +352,7 @@ func testAccCheckComputeBetaFirewallDenyPorts(firewall *computeBeta.Firewall, po
 	}
 }
 
-func testAccCheckComputeBetaFirewallEgress(firewall *computeBeta.Firewall) resource.TestCheckFunc {
+func testAccCheckComputeFirewallEgress(firewall *compute.Firewall) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if firewall.Direction != "EGRESS" {
 			return fmt.Errorf("firewall not EGRESS")
@@ -352,7 +392,7 @@ func testAccCheckComputeFirewallApiVersion(firewall *compute.Firewall) resource.
 		// The self-link of the network field is used to determine which API was used when fetching
 		// the state from the API.
 		if !strings.Contains(firewall.Network, "compute/v1") {
-			return fmt.Errorf("firewall beta API was not used")
+			return fmt.Errorf("firewall v1 API was not used")
 		}
 
 		return nil
@@ -471,6 +511,27 @@ func testAccComputeFirewall_egress(network, firewall string) string {
 	}`, network, firewall)
 }
 
+func testAccComputeFirewall_egressDeny(network, firewall string) string {
+	return fmt.Sprintf(`
+	resource "google_compute_network" "foobar" {
+		name = "%s"
+	}
+
+	resource "google_compute_firewall" "foobar" {
+		name = "firewall-test-%s"
+		direction = "EGRESS"
+		description = "Resource created for Terraform acceptance testing"
+		network = "${google_compute_network.foobar.name}"
+		destination_ranges = ["0.0.0.0/0"]
+		priority = 65000
+
+		deny {
+			protocol = "tcp"
+			ports    = [25]
+		}
+	}`, network, firewall)
+}
+
 func testAccComputeFirewall_serviceAccounts(sourceSa, targetSa, network, firewall string) string {
 	return fmt.Sprintf(`
 	resource "google_service_account" "source" {
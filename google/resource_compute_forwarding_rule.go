@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -32,9 +33,10 @@ func resourceComputeForwardingRule() *schema.Resource {
 			},
 
 			"backend_service": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: compareSelfLinkOrResourceName,
 			},
 
 			"description": &schema.Schema{
@@ -58,10 +60,11 @@ func resourceComputeForwardingRule() *schema.Resource {
 			},
 
 			"load_balancing_scheme": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "EXTERNAL",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "EXTERNAL",
+				ValidateFunc: validation.StringInSlice([]string{"INTERNAL", "EXTERNAL"}, false),
 			},
 
 			"network": &schema.Schema{
@@ -108,10 +111,11 @@ func resourceComputeForwardingRule() *schema.Resource {
 			},
 
 			"subnetwork": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Computed:         true,
+				DiffSuppressFunc: compareSelfLinkOrResourceName,
 			},
 		},
 	}
@@ -135,6 +139,15 @@ func resourceComputeForwardingRuleCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	if d.Get("load_balancing_scheme").(string) == "INTERNAL" {
+		if d.Get("backend_service").(string) == "" {
+			return fmt.Errorf("backend_service must be set for INTERNAL forwarding rules")
+		}
+		if d.Get("target").(string) != "" || d.Get("port_range").(string) != "" {
+			return fmt.Errorf("target and port_range cannot be set for INTERNAL forwarding rules, use backend_service and ports instead")
+		}
+	}
+
 	ps := d.Get("ports").(*schema.Set).List()
 	ports := make([]string, 0, len(ps))
 	for _, v := range ps {
@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -31,6 +32,11 @@ func resourceComputeHealthCheck() *schema.Resource {
 				Default:  5,
 			},
 
+			"creation_timestamp": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"description": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -55,9 +61,10 @@ func resourceComputeHealthCheck() *schema.Resource {
 							Default:  80,
 						},
 						"proxy_header": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "NONE",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "PROXY_V1"}, false),
 						},
 						"request": &schema.Schema{
 							Type:     schema.TypeString,
@@ -84,9 +91,10 @@ func resourceComputeHealthCheck() *schema.Resource {
 							Default:  443,
 						},
 						"proxy_header": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "NONE",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "PROXY_V1"}, false),
 						},
 						"request": &schema.Schema{
 							Type:     schema.TypeString,
@@ -117,9 +125,10 @@ func resourceComputeHealthCheck() *schema.Resource {
 							Default:  80,
 						},
 						"proxy_header": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "NONE",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "PROXY_V1"}, false),
 						},
 						"request_path": &schema.Schema{
 							Type:     schema.TypeString,
@@ -147,9 +156,10 @@ func resourceComputeHealthCheck() *schema.Resource {
 							Default:  443,
 						},
 						"proxy_header": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "NONE",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "PROXY_V1"}, false),
 						},
 						"request_path": &schema.Schema{
 							Type:     schema.TypeString,
@@ -178,6 +188,11 @@ func resourceComputeHealthCheck() *schema.Resource {
 				Default:  5,
 			},
 
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"unhealthy_threshold": &schema.Schema{
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -195,101 +210,9 @@ func resourceComputeHealthCheckCreate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	// Build the parameter
-	hchk := &compute.HealthCheck{
-		Name: d.Get("name").(string),
-	}
-	// Optional things
-	if v, ok := d.GetOk("description"); ok {
-		hchk.Description = v.(string)
-	}
-	if v, ok := d.GetOk("check_interval_sec"); ok {
-		hchk.CheckIntervalSec = int64(v.(int))
-	}
-	if v, ok := d.GetOk("healthy_threshold"); ok {
-		hchk.HealthyThreshold = int64(v.(int))
-	}
-	if v, ok := d.GetOk("timeout_sec"); ok {
-		hchk.TimeoutSec = int64(v.(int))
-	}
-	if v, ok := d.GetOk("unhealthy_threshold"); ok {
-		hchk.UnhealthyThreshold = int64(v.(int))
-	}
-
-	if v, ok := d.GetOk("tcp_health_check"); ok {
-		hchk.Type = "TCP"
-		tcpcheck := v.([]interface{})[0].(map[string]interface{})
-		tcpHealthCheck := &compute.TCPHealthCheck{}
-		if val, ok := tcpcheck["port"]; ok {
-			tcpHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := tcpcheck["proxy_header"]; ok {
-			tcpHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := tcpcheck["request"]; ok {
-			tcpHealthCheck.Request = val.(string)
-		}
-		if val, ok := tcpcheck["response"]; ok {
-			tcpHealthCheck.Response = val.(string)
-		}
-		hchk.TcpHealthCheck = tcpHealthCheck
-	}
-
-	if v, ok := d.GetOk("ssl_health_check"); ok {
-		hchk.Type = "SSL"
-		sslcheck := v.([]interface{})[0].(map[string]interface{})
-		sslHealthCheck := &compute.SSLHealthCheck{}
-		if val, ok := sslcheck["port"]; ok {
-			sslHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := sslcheck["proxy_header"]; ok {
-			sslHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := sslcheck["request"]; ok {
-			sslHealthCheck.Request = val.(string)
-		}
-		if val, ok := sslcheck["response"]; ok {
-			sslHealthCheck.Response = val.(string)
-		}
-		hchk.SslHealthCheck = sslHealthCheck
-	}
-
-	if v, ok := d.GetOk("http_health_check"); ok {
-		hchk.Type = "HTTP"
-		httpcheck := v.([]interface{})[0].(map[string]interface{})
-		httpHealthCheck := &compute.HTTPHealthCheck{}
-		if val, ok := httpcheck["host"]; ok {
-			httpHealthCheck.Host = val.(string)
-		}
-		if val, ok := httpcheck["port"]; ok {
-			httpHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := httpcheck["proxy_header"]; ok {
-			httpHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := httpcheck["request_path"]; ok {
-			httpHealthCheck.RequestPath = val.(string)
-		}
-		hchk.HttpHealthCheck = httpHealthCheck
-	}
-
-	if v, ok := d.GetOk("https_health_check"); ok {
-		hchk.Type = "HTTPS"
-		httpscheck := v.([]interface{})[0].(map[string]interface{})
-		httpsHealthCheck := &compute.HTTPSHealthCheck{}
-		if val, ok := httpscheck["host"]; ok {
-			httpsHealthCheck.Host = val.(string)
-		}
-		if val, ok := httpscheck["port"]; ok {
-			httpsHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := httpscheck["proxy_header"]; ok {
-			httpsHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := httpscheck["request_path"]; ok {
-			httpsHealthCheck.RequestPath = val.(string)
-		}
-		hchk.HttpsHealthCheck = httpsHealthCheck
+	hchk, err := expandComputeHealthCheck(d)
+	if err != nil {
+		return err
 	}
 
 	log.Printf("[DEBUG] HealthCheck insert request: %#v", hchk)
@@ -318,110 +241,20 @@ func resourceComputeHealthCheckUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	// Build the parameter
-	hchk := &compute.HealthCheck{
-		Name: d.Get("name").(string),
-	}
-	// Optional things
-	if v, ok := d.GetOk("description"); ok {
-		hchk.Description = v.(string)
-	}
-	if v, ok := d.GetOk("check_interval_sec"); ok {
-		hchk.CheckIntervalSec = int64(v.(int))
-	}
-	if v, ok := d.GetOk("healthy_threshold"); ok {
-		hchk.HealthyThreshold = int64(v.(int))
-	}
-	if v, ok := d.GetOk("timeout_sec"); ok {
-		hchk.TimeoutSec = int64(v.(int))
-	}
-	if v, ok := d.GetOk("unhealthy_threshold"); ok {
-		hchk.UnhealthyThreshold = int64(v.(int))
-	}
-	if v, ok := d.GetOk("tcp_health_check"); ok {
-		hchk.Type = "TCP"
-		tcpcheck := v.([]interface{})[0].(map[string]interface{})
-		tcpHealthCheck := &compute.TCPHealthCheck{}
-		if val, ok := tcpcheck["port"]; ok {
-			tcpHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := tcpcheck["proxy_header"]; ok {
-			tcpHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := tcpcheck["request"]; ok {
-			tcpHealthCheck.Request = val.(string)
-		}
-		if val, ok := tcpcheck["response"]; ok {
-			tcpHealthCheck.Response = val.(string)
-		}
-		hchk.TcpHealthCheck = tcpHealthCheck
-	}
-	if v, ok := d.GetOk("ssl_health_check"); ok {
-		hchk.Type = "SSL"
-		sslcheck := v.([]interface{})[0].(map[string]interface{})
-		sslHealthCheck := &compute.SSLHealthCheck{}
-		if val, ok := sslcheck["port"]; ok {
-			sslHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := sslcheck["proxy_header"]; ok {
-			sslHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := sslcheck["request"]; ok {
-			sslHealthCheck.Request = val.(string)
-		}
-		if val, ok := sslcheck["response"]; ok {
-			sslHealthCheck.Response = val.(string)
-		}
-		hchk.SslHealthCheck = sslHealthCheck
-	}
-	if v, ok := d.GetOk("http_health_check"); ok {
-		hchk.Type = "HTTP"
-		httpcheck := v.([]interface{})[0].(map[string]interface{})
-		httpHealthCheck := &compute.HTTPHealthCheck{}
-		if val, ok := httpcheck["host"]; ok {
-			httpHealthCheck.Host = val.(string)
-		}
-		if val, ok := httpcheck["port"]; ok {
-			httpHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := httpcheck["proxy_header"]; ok {
-			httpHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := httpcheck["request_path"]; ok {
-			httpHealthCheck.RequestPath = val.(string)
-		}
-		hchk.HttpHealthCheck = httpHealthCheck
-	}
-
-	if v, ok := d.GetOk("https_health_check"); ok {
-		hchk.Type = "HTTPS"
-		httpscheck := v.([]interface{})[0].(map[string]interface{})
-		httpsHealthCheck := &compute.HTTPSHealthCheck{}
-		if val, ok := httpscheck["host"]; ok {
-			httpsHealthCheck.Host = val.(string)
-		}
-		if val, ok := httpscheck["port"]; ok {
-			httpsHealthCheck.Port = int64(val.(int))
-		}
-		if val, ok := httpscheck["proxy_header"]; ok {
-			httpsHealthCheck.ProxyHeader = val.(string)
-		}
-		if val, ok := httpscheck["request_path"]; ok {
-			httpsHealthCheck.RequestPath = val.(string)
-		}
-		hchk.HttpsHealthCheck = httpsHealthCheck
+	hchk, err := expandComputeHealthCheck(d)
+	if err != nil {
+		return err
 	}
 
-	log.Printf("[DEBUG] HealthCheck patch request: %#v", hchk)
-	op, err := config.clientCompute.HealthChecks.Patch(
+	// Update rather than patch, so that switching protocols drops the block
+	// of the previous protocol.
+	log.Printf("[DEBUG] HealthCheck update request: %#v", hchk)
+	op, err := config.clientCompute.HealthChecks.Update(
 		project, hchk.Name, hchk).Do()
 	if err != nil {
-		return fmt.Errorf("Error patching HealthCheck: %s", err)
+		return fmt.Errorf("Error updating HealthCheck: %s", err)
 	}
 
-	// It probably maybe worked, so store the ID now
-	d.SetId(hchk.Name)
-
 	err = computeOperationWait(config.clientCompute, op, project, "Updating Health Check")
 	if err != nil {
 		return err
@@ -452,6 +285,8 @@ func resourceComputeHealthCheckRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("ssl_health_check", flattenSslHealthCheck(hchk.SslHealthCheck))
 	d.Set("http_health_check", flattenHttpHealthCheck(hchk.HttpHealthCheck))
 	d.Set("https_health_check", flattenHttpsHealthCheck(hchk.HttpsHealthCheck))
+	d.Set("type", hchk.Type)
+	d.Set("creation_timestamp", hchk.CreationTimestamp)
 	d.Set("self_link", hchk.SelfLink)
 	d.Set("name", hchk.Name)
 	d.Set("description", hchk.Description)
@@ -484,6 +319,67 @@ func resourceComputeHealthCheckDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+func expandComputeHealthCheck(d *schema.ResourceData) (*compute.HealthCheck, error) {
+	hchk := &compute.HealthCheck{
+		Name:               d.Get("name").(string),
+		Description:        d.Get("description").(string),
+		CheckIntervalSec:   int64(d.Get("check_interval_sec").(int)),
+		HealthyThreshold:   int64(d.Get("healthy_threshold").(int)),
+		TimeoutSec:         int64(d.Get("timeout_sec").(int)),
+		UnhealthyThreshold: int64(d.Get("unhealthy_threshold").(int)),
+	}
+
+	if v, ok := d.GetOk("tcp_health_check"); ok {
+		hchk.Type = "TCP"
+		tcpcheck := v.([]interface{})[0].(map[string]interface{})
+		hchk.TcpHealthCheck = &compute.TCPHealthCheck{
+			Port:        int64(tcpcheck["port"].(int)),
+			ProxyHeader: tcpcheck["proxy_header"].(string),
+			Request:     tcpcheck["request"].(string),
+			Response:    tcpcheck["response"].(string),
+		}
+	}
+
+	if v, ok := d.GetOk("ssl_health_check"); ok {
+		hchk.Type = "SSL"
+		sslcheck := v.([]interface{})[0].(map[string]interface{})
+		hchk.SslHealthCheck = &compute.SSLHealthCheck{
+			Port:        int64(sslcheck["port"].(int)),
+			ProxyHeader: sslcheck["proxy_header"].(string),
+			Request:     sslcheck["request"].(string),
+			Response:    sslcheck["response"].(string),
+		}
+	}
+
+	if v, ok := d.GetOk("http_health_check"); ok {
+		hchk.Type = "HTTP"
+		httpcheck := v.([]interface{})[0].(map[string]interface{})
+		hchk.HttpHealthCheck = &compute.HTTPHealthCheck{
+			Host:        httpcheck["host"].(string),
+			Port:        int64(httpcheck["port"].(int)),
+			ProxyHeader: httpcheck["proxy_header"].(string),
+			RequestPath: httpcheck["request_path"].(string),
+		}
+	}
+
+	if v, ok := d.GetOk("https_health_check"); ok {
+		hchk.Type = "HTTPS"
+		httpscheck := v.([]interface{})[0].(map[string]interface{})
+		hchk.HttpsHealthCheck = &compute.HTTPSHealthCheck{
+			Host:        httpscheck["host"].(string),
+			Port:        int64(httpscheck["port"].(int)),
+			ProxyHeader: httpscheck["proxy_header"].(string),
+			RequestPath: httpscheck["request_path"].(string),
+		}
+	}
+
+	if hchk.Type == "" {
+		return nil, fmt.Errorf("One of tcp_health_check, ssl_health_check, http_health_check or https_health_check must be set")
+	}
+
+	return hchk, nil
+}
+
 func flattenTcpHealthCheck(hchk *compute.TCPHealthCheck) []map[string]interface{} {
 	if hchk == nil {
 		return nil
@@ -148,6 +148,53 @@ func TestAccComputeHealthCheck_https(t *testing.T) {
 	})
 }
 
+func TestAccComputeHealthCheck_typeTransition(t *testing.T) {
+	t.Parallel()
+
+	var healthCheck compute.HealthCheck
+
+	hckName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeHealthCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeHealthCheck_tcp(hckName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeHealthCheckExists(
+						"google_compute_health_check.foobar", &healthCheck),
+					resource.TestCheckResourceAttr(
+						"google_compute_health_check.foobar", "type", "TCP"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccComputeHealthCheck_http(hckName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeHealthCheckExists(
+						"google_compute_health_check.foobar", &healthCheck),
+					resource.TestCheckResourceAttr(
+						"google_compute_health_check.foobar", "type", "HTTP"),
+					resource.TestCheckResourceAttr(
+						"google_compute_health_check.foobar", "tcp_health_check.#", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccComputeHealthCheck_https(hckName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeHealthCheckExists(
+						"google_compute_health_check.foobar", &healthCheck),
+					resource.TestCheckResourceAttr(
+						"google_compute_health_check.foobar", "type", "HTTPS"),
+					resource.TestCheckResourceAttr(
+						"google_compute_health_check.foobar", "http_health_check.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccComputeHealthCheck_tcpAndSsl_shouldFail(t *testing.T) {
 	t.Parallel()
 
@@ -509,6 +509,8 @@ func resourceComputeInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"shielded_instance_config": computeShieldedInstanceConfigSchema(),
+
 			"tags": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -543,36 +545,30 @@ func resourceComputeInstance() *schema.Resource {
 }
 
 func getInstance(config *Config, d *schema.ResourceData) (*computeBeta.Instance, error) {
+	instance, _, err := getInstanceWithShieldedConfig(config, d)
+	return instance, err
+}
+
+// getInstanceWithShieldedConfig is getInstance, also returning the
+// instance's shielded config, which the vendored clients don't know about.
+func getInstanceWithShieldedConfig(config *Config, d *schema.ResourceData) (*computeBeta.Instance, *computeShieldedInstanceConfig, error) {
 	computeApiVersion := getComputeApiVersion(d, InstanceBaseApiVersion, InstanceVersionedFeatures)
 	project, err := getProject(d, config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	zone := d.Get("zone").(string)
-	instance := &computeBeta.Instance{}
-	switch computeApiVersion {
-	case v1:
-		instanceV1, err := config.clientCompute.Instances.Get(
-			project, zone, d.Id()).Do()
-		if err != nil {
-			return nil, handleNotFoundError(err, d, fmt.Sprintf("Instance %s", d.Get("name").(string)))
-		}
+	basePath := config.clientCompute.BasePath
+	if computeApiVersion == v0beta {
+		basePath = config.clientComputeBeta.BasePath
+	}
 
-		err = Convert(instanceV1, instance)
-		if err != nil {
-			return nil, err
-		}
-	case v0beta:
-		var err error
-		instance, err = config.clientComputeBeta.Instances.Get(
-			project, zone, d.Id()).Do()
-		if err != nil {
-			return nil, handleNotFoundError(err, d, fmt.Sprintf("Instance %s", d.Get("name").(string)))
-		}
+	instance, shielded, err := getComputeInstanceWithShieldedConfig(config, basePath, project, d.Get("zone").(string), d.Id())
+	if err != nil {
+		return nil, nil, handleNotFoundError(err, d, fmt.Sprintf("Instance %s", d.Get("name").(string)))
 	}
 
-	return instance, nil
+	return instance, shielded, nil
 }
 
 func resourceComputeInstanceCreate(d *schema.ResourceData, meta interface{}) error {
@@ -748,6 +744,8 @@ func resourceComputeInstanceCreate(d *schema.ResourceData, meta interface{}) err
 		Scheduling:        scheduling,
 	}
 
+	shieldedInstanceConfig := expandComputeShieldedInstanceConfig(d.Get("shielded_instance_config").([]interface{}))
+
 	log.Printf("[INFO] Requesting instance creation")
 	var op interface{}
 	switch computeApiVersion {
@@ -758,11 +756,21 @@ func resourceComputeInstanceCreate(d *schema.ResourceData, meta interface{}) err
 			return err
 		}
 
-		op, err = config.clientCompute.Instances.Insert(
-			project, zone.Name, instanceV1).Do()
+		if shieldedInstanceConfig != nil {
+			op, err = insertComputeInstanceWithShieldedConfig(
+				config, config.clientCompute.BasePath, project, zone.Name, instanceV1, shieldedInstanceConfig)
+		} else {
+			op, err = config.clientCompute.Instances.Insert(
+				project, zone.Name, instanceV1).Do()
+		}
 	case v0beta:
-		op, err = config.clientComputeBeta.Instances.Insert(
-			project, zone.Name, &instance).Do()
+		if shieldedInstanceConfig != nil {
+			op, err = insertComputeInstanceWithShieldedConfig(
+				config, config.clientComputeBeta.BasePath, project, zone.Name, &instance, shieldedInstanceConfig)
+		} else {
+			op, err = config.clientComputeBeta.Instances.Insert(
+				project, zone.Name, &instance).Do()
+		}
 	}
 
 	if err != nil {
@@ -786,7 +794,7 @@ func resourceComputeInstanceCreate(d *schema.ResourceData, meta interface{}) err
 func resourceComputeInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
-	instance, err := getInstance(config, d)
+	instance, shieldedInstanceConfig, err := getInstanceWithShieldedConfig(config, d)
 	if err != nil || instance == nil {
 		return err
 	}
@@ -954,6 +962,11 @@ func resourceComputeInstanceRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("guest_accelerator", flattenGuestAccelerators(instance.Zone, instance.GuestAccelerators))
 	d.Set("cpu_platform", instance.CpuPlatform)
 	d.Set("min_cpu_platform", instance.MinCpuPlatform)
+
+	if err := d.Set("shielded_instance_config", flattenComputeShieldedInstanceConfig(shieldedInstanceConfig)); err != nil {
+		return fmt.Errorf("Error setting shielded_instance_config: %s", err)
+	}
+
 	d.Set("self_link", ConvertSelfLinkToV1(instance.SelfLink))
 	d.Set("instance_id", fmt.Sprintf("%d", instance.Id))
 	d.SetId(instance.Name)
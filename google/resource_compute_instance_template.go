@@ -284,6 +284,8 @@ func resourceComputeInstanceTemplate() *schema.Resource {
 				Computed: true,
 			},
 
+			"shielded_instance_config": computeShieldedInstanceConfigSchema(),
+
 			"service_account": &schema.Schema{
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -603,8 +605,13 @@ func resourceComputeInstanceTemplateCreate(d *schema.ResourceData, meta interfac
 		Name:        itName,
 	}
 
-	op, err := config.clientCompute.InstanceTemplates.Insert(
-		project, &instanceTemplate).Do()
+	var op *compute.Operation
+	if shieldedInstanceConfig := expandComputeShieldedInstanceConfig(d.Get("shielded_instance_config").([]interface{})); shieldedInstanceConfig != nil {
+		op, err = insertComputeInstanceTemplateWithShieldedConfig(config, project, &instanceTemplate, shieldedInstanceConfig)
+	} else {
+		op, err = config.clientCompute.InstanceTemplates.Insert(
+			project, &instanceTemplate).Do()
+	}
 	if err != nil {
 		return fmt.Errorf("Error creating instance: %s", err)
 	}
@@ -722,8 +729,7 @@ func resourceComputeInstanceTemplateRead(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	instanceTemplate, err := config.clientCompute.InstanceTemplates.Get(
-		project, d.Id()).Do()
+	instanceTemplate, shieldedInstanceConfig, err := getComputeInstanceTemplateWithShieldedConfig(config, project, d.Id())
 	if err != nil {
 		return handleNotFoundError(err, d, fmt.Sprintf("Instance Template %q", d.Get("name").(string)))
 	}
@@ -814,6 +820,10 @@ func resourceComputeInstanceTemplateRead(d *schema.ResourceData, meta interface{
 			return fmt.Errorf("Error setting service_account: %s", err)
 		}
 	}
+
+	if err = d.Set("shielded_instance_config", flattenComputeShieldedInstanceConfig(shieldedInstanceConfig)); err != nil {
+		return fmt.Errorf("Error setting shielded_instance_config: %s", err)
+	}
 	return nil
 }
 
@@ -37,6 +37,30 @@ func TestAccComputeInstanceTemplate_basic(t *testing.T) {
 	})
 }
 
+func TestAccComputeInstanceTemplate_shieldedInstanceConfig(t *testing.T) {
+	t.Parallel()
+
+	var instanceTemplate compute.InstanceTemplate
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeInstanceTemplateDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeInstanceTemplate_shieldedInstanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInstanceTemplateExists(
+						"google_compute_instance_template.foobar", &instanceTemplate),
+					resource.TestCheckResourceAttr("google_compute_instance_template.foobar", "shielded_instance_config.0.enable_secure_boot", "true"),
+					resource.TestCheckResourceAttr("google_compute_instance_template.foobar", "shielded_instance_config.0.enable_vtpm", "false"),
+					resource.TestCheckResourceAttr("google_compute_instance_template.foobar", "shielded_instance_config.0.enable_integrity_monitoring", "false"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccComputeInstanceTemplate_preemptible(t *testing.T) {
 	t.Parallel()
 
@@ -718,3 +742,25 @@ resource "google_compute_instance_template" "foobar" {
 
 	metadata_startup_script = "echo 'Hello'"
 }`, acctest.RandString(10))
+
+var testAccComputeInstanceTemplate_shieldedInstanceConfig = fmt.Sprintf(`
+resource "google_compute_instance_template" "foobar" {
+	name = "instance-test-%s"
+	machine_type = "n1-standard-1"
+
+	disk {
+		source_image = "ubuntu-os-cloud/ubuntu-1804-lts"
+		auto_delete = true
+		boot = true
+	}
+
+	network_interface {
+		network = "default"
+	}
+
+	shielded_instance_config {
+		enable_secure_boot          = true
+		enable_vtpm                 = false
+		enable_integrity_monitoring = false
+	}
+}`, acctest.RandString(10))
@@ -773,6 +773,30 @@ func TestAccComputeInstance_minCpuPlatform(t *testing.T) {
 	})
 }
 
+func TestAccComputeInstance_shieldedInstanceConfig(t *testing.T) {
+	t.Parallel()
+
+	var instance compute.Instance
+	instanceName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeInstance_shieldedInstanceConfig(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInstanceExists("google_compute_instance.foobar", &instance),
+					resource.TestCheckResourceAttr("google_compute_instance.foobar", "shielded_instance_config.0.enable_secure_boot", "true"),
+					resource.TestCheckResourceAttr("google_compute_instance.foobar", "shielded_instance_config.0.enable_vtpm", "true"),
+					resource.TestCheckResourceAttr("google_compute_instance.foobar", "shielded_instance_config.0.enable_integrity_monitoring", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccComputeInstance_primaryAliasIpRange(t *testing.T) {
 	t.Parallel()
 
@@ -2242,6 +2266,29 @@ resource "google_compute_instance" "foobar" {
 }`, instance)
 }
 
+func testAccComputeInstance_shieldedInstanceConfig(instance string) string {
+	return fmt.Sprintf(`
+resource "google_compute_instance" "foobar" {
+  name = "%s"
+  machine_type = "n1-standard-1"
+  zone = "us-east1-d"
+
+  boot_disk {
+    initialize_params {
+      image = "ubuntu-os-cloud/ubuntu-1804-lts"
+    }
+  }
+
+  network_interface {
+    network = "default"
+  }
+
+  shielded_instance_config {
+    enable_secure_boot = true
+  }
+}`, instance)
+}
+
 func testAccComputeInstance_primaryAliasIpRange(instance string) string {
 	return fmt.Sprintf(`
 resource "google_compute_instance" "foobar" {
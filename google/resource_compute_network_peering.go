@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"google.golang.org/api/compute/v1"
@@ -17,6 +18,9 @@ func resourceComputeNetworkPeering() *schema.Resource {
 		Create: resourceComputeNetworkPeeringCreate,
 		Read:   resourceComputeNetworkPeeringRead,
 		Delete: resourceComputeNetworkPeeringDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeNetworkPeeringImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -64,12 +68,22 @@ func resourceComputeNetworkPeeringCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	peerNetworkFieldValue, err := ParseNetworkFieldValue(d.Get("peer_network").(string), d, config)
+	if err != nil {
+		return err
+	}
+
 	request := &compute.NetworksAddPeeringRequest{
 		Name:             d.Get("name").(string),
 		PeerNetwork:      d.Get("peer_network").(string),
 		AutoCreateRoutes: d.Get("auto_create_routes").(bool),
 	}
 
+	// Peering operations on either network fail while another one is running.
+	peeringLockName := getNetworkPeeringLockName(networkFieldValue.Name, peerNetworkFieldValue.Name)
+	mutexKV.Lock(peeringLockName)
+	defer mutexKV.Unlock(peeringLockName)
+
 	addOp, err := config.clientCompute.Networks.AddPeering(networkFieldValue.Project, networkFieldValue.Name, request).Do()
 	if err != nil {
 		return fmt.Errorf("Error adding network peering: %s", err)
@@ -96,7 +110,7 @@ func resourceComputeNetworkPeeringRead(d *schema.ResourceData, meta interface{})
 
 	network, err := config.clientCompute.Networks.Get(networkFieldValue.Project, networkFieldValue.Name).Do()
 	if err != nil {
-		return handleNotFoundError(err, d, fmt.Sprintf("Network %q", network.Name))
+		return handleNotFoundError(err, d, fmt.Sprintf("Network %q", networkFieldValue.Name))
 	}
 
 	peering := findPeeringFromNetwork(network, peeringName)
@@ -106,6 +120,7 @@ func resourceComputeNetworkPeeringRead(d *schema.ResourceData, meta interface{})
 		return nil
 	}
 
+	d.Set("network", network.SelfLink)
 	d.Set("peer_network", peering.Network)
 	d.Set("auto_create_routes", peering.AutoCreateRoutes)
 	d.Set("state", peering.State)
@@ -154,6 +169,19 @@ func resourceComputeNetworkPeeringDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
+func resourceComputeNetworkPeeringImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Invalid network peering specifier %q, expected {project}/{network}/{name}", d.Id())
+	}
+
+	d.Set("network", fmt.Sprintf(globalLinkTemplate, parts[0], "networks", parts[1]))
+	d.Set("name", parts[2])
+	d.SetId(fmt.Sprintf("%s/%s", parts[1], parts[2]))
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func findPeeringFromNetwork(network *compute.Network, peeringName string) *compute.NetworkPeering {
 	for _, p := range network.Peerings {
 		if p.Name == peeringName {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"google.golang.org/api/compute/v1"
 	"strings"
@@ -14,6 +15,8 @@ func TestAccComputeNetworkPeering_basic(t *testing.T) {
 	t.Parallel()
 
 	var peering compute.NetworkPeering
+	networkName := fmt.Sprintf("network-test-1-%s", acctest.RandString(10))
+	peeringName := fmt.Sprintf("peering-test-1-%s", acctest.RandString(10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -21,7 +24,7 @@ func TestAccComputeNetworkPeering_basic(t *testing.T) {
 		CheckDestroy: testAccComputeNetworkPeeringDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: testAccComputeNetworkPeering_basic,
+				Config: testAccComputeNetworkPeering_basic(networkName, peeringName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckComputeNetworkPeeringExist("google_compute_network_peering.foo", &peering),
 					testAccCheckComputeNetworkPeeringAutoCreateRoutes(true, &peering),
@@ -29,11 +32,42 @@ func TestAccComputeNetworkPeering_basic(t *testing.T) {
 					testAccCheckComputeNetworkPeeringAutoCreateRoutes(true, &peering),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "google_compute_network_peering.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%s/%s/%s", getTestProjectFromEnv(), networkName, peeringName),
+			},
 		},
 	})
 
 }
 
+func TestComputeNetworkPeeringImport(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceComputeNetworkPeering().Schema, map[string]interface{}{})
+	d.SetId("my-project/my-network/my-peering")
+
+	if _, err := resourceComputeNetworkPeeringImport(d, nil); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if network := d.Get("network").(string); network != "projects/my-project/global/networks/my-network" {
+		t.Errorf("Expected network projects/my-project/global/networks/my-network, got %s", network)
+	}
+	if name := d.Get("name").(string); name != "my-peering" {
+		t.Errorf("Expected name my-peering, got %s", name)
+	}
+	if d.Id() != "my-network/my-peering" {
+		t.Errorf("Expected id my-network/my-peering, got %s", d.Id())
+	}
+
+	d.SetId("my-network/my-peering")
+	if _, err := resourceComputeNetworkPeeringImport(d, nil); err == nil {
+		t.Error("Expected an error importing an ID without a project")
+	}
+}
+
 func testAccComputeNetworkPeeringDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -97,9 +131,10 @@ func testAccCheckComputeNetworkPeeringAutoCreateRoutes(v bool, peering *compute.
 	}
 }
 
-var testAccComputeNetworkPeering_basic = fmt.Sprintf(`
+func testAccComputeNetworkPeering_basic(networkName, peeringName string) string {
+	return fmt.Sprintf(`
 resource "google_compute_network" "network1" {
-	name = "network-test-1-%s"
+	name = "%s"
 	auto_create_subnetworks = false
 }
 
@@ -109,7 +144,7 @@ resource "google_compute_network" "network2" {
 }
 
 resource "google_compute_network_peering" "foo" {
-	name = "peering-test-1-%s"
+	name = "%s"
 	network = "${google_compute_network.network1.self_link}"
 	peer_network = "${google_compute_network.network2.self_link}"
 }
@@ -120,4 +155,5 @@ resource "google_compute_network_peering" "bar" {
 	network = "${google_compute_network.network2.self_link}"
 	peer_network = "${google_compute_network.network1.self_link}"
 }
-`, acctest.RandString(10), acctest.RandString(10), acctest.RandString(10), acctest.RandString(10))
+`, networkName, acctest.RandString(10), peeringName, acctest.RandString(10))
+}
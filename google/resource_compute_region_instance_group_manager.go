@@ -11,7 +11,11 @@ import (
 )
 
 var RegionInstanceGroupManagerBaseApiVersion = v1
-var RegionInstanceGroupManagerVersionedFeatures = []Feature{Feature{Version: v0beta, Item: "auto_healing_policies"}}
+var RegionInstanceGroupManagerVersionedFeatures = []Feature{
+	Feature{Version: v0beta, Item: "auto_healing_policies"},
+	Feature{Version: v0beta, Item: "distribution_policy_zones"},
+	Feature{Version: v0beta, Item: "update_policy"},
+}
 
 func resourceComputeRegionInstanceGroupManager() *schema.Resource {
 	return &schema.Resource{
@@ -130,6 +134,71 @@ func resourceComputeRegionInstanceGroupManager() *schema.Resource {
 					},
 				},
 			},
+
+			"distribution_policy_zones": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"update_policy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"OPPORTUNISTIC", "PROACTIVE"}, false),
+						},
+
+						"minimal_action": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"RESTART", "REPLACE"}, false),
+						},
+
+						"max_surge_fixed": &schema.Schema{
+							Type:          schema.TypeInt,
+							Optional:      true,
+							Computed:      true,
+							ConflictsWith: []string{"update_policy.0.max_surge_percent"},
+						},
+
+						"max_surge_percent": &schema.Schema{
+							Type:          schema.TypeInt,
+							Optional:      true,
+							ConflictsWith: []string{"update_policy.0.max_surge_fixed"},
+							ValidateFunc:  validation.IntBetween(0, 100),
+						},
+
+						"max_unavailable_fixed": &schema.Schema{
+							Type:          schema.TypeInt,
+							Optional:      true,
+							Computed:      true,
+							ConflictsWith: []string{"update_policy.0.max_unavailable_percent"},
+						},
+
+						"max_unavailable_percent": &schema.Schema{
+							Type:          schema.TypeInt,
+							Optional:      true,
+							ConflictsWith: []string{"update_policy.0.max_unavailable_fixed"},
+							ValidateFunc:  validation.IntBetween(0, 100),
+						},
+
+						"min_ready_sec": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 3600),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -152,22 +221,28 @@ func resourceComputeRegionInstanceGroupManagerCreate(d *schema.ResourceData, met
 		NamedPorts:          getNamedPortsBeta(d.Get("named_port").([]interface{})),
 		TargetPools:         convertStringSet(d.Get("target_pools").(*schema.Set)),
 		AutoHealingPolicies: expandAutoHealingPolicies(d.Get("auto_healing_policies").([]interface{})),
+		UpdatePolicy:        expandRegionInstanceGroupManagerUpdatePolicy(d.Get("update_policy").([]interface{})),
 		// Force send TargetSize to allow size of 0.
 		ForceSendFields: []string{"TargetSize"},
 	}
 
+	region := d.Get("region").(string)
+	zones := convertStringSet(d.Get("distribution_policy_zones").(*schema.Set))
+
 	var op interface{}
-	switch computeApiVersion {
-	case v1:
+	switch {
+	case len(zones) > 0:
+		op, err = insertRegionInstanceGroupManagerWithDistributionPolicy(config, project, region, manager, zones)
+	case computeApiVersion == v1:
 		managerV1 := &compute.InstanceGroupManager{}
 		err = Convert(manager, managerV1)
 		if err != nil {
 			return err
 		}
 		managerV1.ForceSendFields = manager.ForceSendFields
-		op, err = config.clientCompute.RegionInstanceGroupManagers.Insert(project, d.Get("region").(string), managerV1).Do()
-	case v0beta:
-		op, err = config.clientComputeBeta.RegionInstanceGroupManagers.Insert(project, d.Get("region").(string), manager).Do()
+		op, err = config.clientCompute.RegionInstanceGroupManagers.Insert(project, region, managerV1).Do()
+	case computeApiVersion == v0beta:
+		op, err = config.clientComputeBeta.RegionInstanceGroupManagers.Insert(project, region, manager).Do()
 	}
 
 	if err != nil {
@@ -198,8 +273,11 @@ func resourceComputeRegionInstanceGroupManagerRead(d *schema.ResourceData, meta
 	manager := &computeBeta.InstanceGroupManager{}
 	switch computeApiVersion {
 	case v1:
-		v1Manager := &compute.InstanceGroupManager{}
+		var v1Manager *compute.InstanceGroupManager
 		v1Manager, err = config.clientCompute.RegionInstanceGroupManagers.Get(project, region, d.Id()).Do()
+		if err != nil {
+			return handleNotFoundError(err, d, fmt.Sprintf("Region Instance Manager %q", d.Get("name").(string)))
+		}
 
 		err = Convert(v1Manager, manager)
 		if err != nil {
@@ -207,10 +285,15 @@ func resourceComputeRegionInstanceGroupManagerRead(d *schema.ResourceData, meta
 		}
 	case v0beta:
 		manager, err = config.clientComputeBeta.RegionInstanceGroupManagers.Get(project, region, d.Id()).Do()
-	}
+		if err != nil {
+			return handleNotFoundError(err, d, fmt.Sprintf("Region Instance Manager %q", d.Get("name").(string)))
+		}
 
-	if err != nil {
-		handleNotFoundError(err, d, fmt.Sprintf("Region Instance Manager %q", d.Get("name").(string)))
+		zones, err := getRegionInstanceGroupManagerDistributionPolicyZones(config, project, region, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error reading distribution policy of RegionInstanceGroupManager: %s", err)
+		}
+		d.Set("distribution_policy_zones", zones)
 	}
 
 	d.Set("base_instance_name", manager.BaseInstanceName)
@@ -225,6 +308,9 @@ func resourceComputeRegionInstanceGroupManagerRead(d *schema.ResourceData, meta
 	d.Set("fingerprint", manager.Fingerprint)
 	d.Set("instance_group", manager.InstanceGroup)
 	d.Set("auto_healing_policies", flattenAutoHealingPolicies(manager.AutoHealingPolicies))
+	if err := d.Set("update_policy", flattenRegionInstanceGroupManagerUpdatePolicy(manager.UpdatePolicy)); err != nil {
+		return fmt.Errorf("Error setting update_policy in state: %s", err)
+	}
 	d.Set("self_link", ConvertSelfLinkToV1(manager.SelfLink))
 
 	return nil
@@ -418,6 +504,27 @@ func resourceComputeRegionInstanceGroupManagerUpdate(d *schema.ResourceData, met
 		d.SetPartial("auto_healing_policies")
 	}
 
+	if d.HasChange("update_policy") {
+		manager := &computeBeta.InstanceGroupManager{
+			UpdatePolicy: expandRegionInstanceGroupManagerUpdatePolicy(d.Get("update_policy").([]interface{})),
+		}
+
+		op, err := config.clientComputeBeta.RegionInstanceGroupManagers.Patch(
+			project, region, d.Id(), manager).Do()
+
+		if err != nil {
+			return fmt.Errorf("Error updating UpdatePolicy: %s", err)
+		}
+
+		// Wait for the operation to complete
+		err = computeSharedOperationWait(config.clientCompute, op, project, "Updating UpdatePolicy")
+		if err != nil {
+			return err
+		}
+
+		d.SetPartial("update_policy")
+	}
+
 	d.Partial(false)
 
 	return resourceComputeRegionInstanceGroupManagerRead(d, meta)
@@ -481,3 +588,111 @@ func resourceComputeRegionInstanceGroupManagerExists(d *schema.ResourceData, met
 	return true, nil
 
 }
+
+func expandRegionInstanceGroupManagerUpdatePolicy(configured []interface{}) *computeBeta.InstanceGroupManagerUpdatePolicy {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+	raw := configured[0].(map[string]interface{})
+
+	policy := &computeBeta.InstanceGroupManagerUpdatePolicy{
+		Type:          raw["type"].(string),
+		MinimalAction: raw["minimal_action"].(string),
+		MinReadySec:   int64(raw["min_ready_sec"].(int)),
+	}
+
+	policy.MaxSurge = expandRegionInstanceGroupManagerFixedOrPercent(raw["max_surge_fixed"].(int), raw["max_surge_percent"].(int))
+	policy.MaxUnavailable = expandRegionInstanceGroupManagerFixedOrPercent(raw["max_unavailable_fixed"].(int), raw["max_unavailable_percent"].(int))
+
+	// Leave both unset when neither is configured so the API picks its
+	// defaults, rather than sending a surge and unavailability of 0.
+	if policy.MaxSurge.Fixed == 0 && policy.MaxSurge.Percent == 0 &&
+		policy.MaxUnavailable.Fixed == 0 && policy.MaxUnavailable.Percent == 0 {
+		policy.MaxSurge = nil
+		policy.MaxUnavailable = nil
+	}
+
+	return policy
+}
+
+// A percentage takes precedence, as the fixed numbers are computed when
+// they're not set.
+func expandRegionInstanceGroupManagerFixedOrPercent(fixed, percent int) *computeBeta.FixedOrPercent {
+	if percent > 0 {
+		return &computeBeta.FixedOrPercent{Percent: int64(percent)}
+	}
+	return &computeBeta.FixedOrPercent{Fixed: int64(fixed), ForceSendFields: []string{"Fixed"}}
+}
+
+func flattenRegionInstanceGroupManagerUpdatePolicy(policy *computeBeta.InstanceGroupManagerUpdatePolicy) []map[string]interface{} {
+	if policy == nil {
+		return nil
+	}
+
+	result := map[string]interface{}{
+		"type":           policy.Type,
+		"minimal_action": policy.MinimalAction,
+		"min_ready_sec":  policy.MinReadySec,
+	}
+	if v := policy.MaxSurge; v != nil {
+		if v.Percent > 0 {
+			result["max_surge_percent"] = v.Percent
+		} else {
+			result["max_surge_fixed"] = v.Fixed
+		}
+	}
+	if v := policy.MaxUnavailable; v != nil {
+		if v.Percent > 0 {
+			result["max_unavailable_percent"] = v.Percent
+		} else {
+			result["max_unavailable_fixed"] = v.Fixed
+		}
+	}
+
+	return []map[string]interface{}{result}
+}
+
+// The vendored compute clients predate distribution policies, so managers
+// with distribution_policy_zones are inserted as raw JSON, and the policy is
+// read back separately.
+
+type computeDistributionPolicy struct {
+	Zones []*computeDistributionPolicyZone `json:"zones,omitempty"`
+}
+
+type computeDistributionPolicyZone struct {
+	Zone string `json:"zone,omitempty"`
+}
+
+func insertRegionInstanceGroupManagerWithDistributionPolicy(config *Config, project, region string, manager *computeBeta.InstanceGroupManager, zones []string) (*computeBeta.Operation, error) {
+	body, err := toJsonMap(manager)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &computeDistributionPolicy{}
+	for _, zone := range zones {
+		policy.Zones = append(policy.Zones, &computeDistributionPolicyZone{Zone: "zones/" + zone})
+	}
+	body["distributionPolicy"] = policy
+
+	op := &computeBeta.Operation{}
+	err = sendJsonRequest(config.client, config.clientComputeBeta.UserAgent, "POST", config.clientComputeBeta.BasePath+project+"/regions/"+region+"/instanceGroupManagers", body, op)
+	return op, err
+}
+
+func getRegionInstanceGroupManagerDistributionPolicyZones(config *Config, project, region, name string) ([]string, error) {
+	res := &struct {
+		DistributionPolicy *computeDistributionPolicy `json:"distributionPolicy"`
+	}{}
+	err := sendJsonRequest(config.client, config.clientComputeBeta.UserAgent, "GET", config.clientComputeBeta.BasePath+project+"/regions/"+region+"/instanceGroupManagers/"+name, nil, res)
+	if err != nil || res.DistributionPolicy == nil {
+		return nil, err
+	}
+
+	zones := make([]string, 0, len(res.DistributionPolicy.Zones))
+	for _, zone := range res.DistributionPolicy.Zones {
+		zones = append(zones, GetResourceNameFromSelfLink(zone.Zone))
+	}
+	return zones, nil
+}
@@ -204,6 +204,51 @@ func TestAccRegionInstanceGroupManager_autoHealingPolicies(t *testing.T) {
 	})
 }
 
+func TestAccRegionInstanceGroupManager_distributionPolicyAndUpdatePolicy(t *testing.T) {
+	t.Parallel()
+
+	var manager computeBeta.InstanceGroupManager
+
+	template := fmt.Sprintf("igm-test-%s", acctest.RandString(10))
+	igm := fmt.Sprintf("igm-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRegionInstanceGroupManagerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRegionInstanceGroupManager_distributionPolicy(template, igm, "OPPORTUNISTIC"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRegionInstanceGroupManagerBetaExists(
+						"google_compute_region_instance_group_manager.igm-basic", &manager),
+					resource.TestCheckResourceAttr(
+						"google_compute_region_instance_group_manager.igm-basic", "distribution_policy_zones.#", "2"),
+					resource.TestCheckResourceAttr(
+						"google_compute_region_instance_group_manager.igm-basic", "update_policy.0.type", "OPPORTUNISTIC"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccRegionInstanceGroupManager_distributionPolicy(template, igm, "PROACTIVE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRegionInstanceGroupManagerBetaExists(
+						"google_compute_region_instance_group_manager.igm-basic", &manager),
+					resource.TestCheckResourceAttr(
+						"google_compute_region_instance_group_manager.igm-basic", "update_policy.0.type", "PROACTIVE"),
+					resource.TestCheckResourceAttr(
+						"google_compute_region_instance_group_manager.igm-basic", "update_policy.0.max_surge_fixed", "2"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "google_compute_region_instance_group_manager.igm-basic",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"region"},
+			},
+		},
+	})
+}
+
 func testAccCheckRegionInstanceGroupManagerDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -799,3 +844,45 @@ resource "google_compute_http_health_check" "zero" {
 }
 	`, template, target, igm, hck)
 }
+
+func testAccRegionInstanceGroupManager_distributionPolicy(template, igm, updateType string) string {
+	return fmt.Sprintf(`
+resource "google_compute_instance_template" "igm-basic" {
+	name = "%s"
+	machine_type = "n1-standard-1"
+	can_ip_forward = false
+	tags = ["foo", "bar"]
+	disk {
+		source_image = "debian-cloud/debian-8-jessie-v20160803"
+		auto_delete = true
+		boot = true
+	}
+	network_interface {
+		network = "default"
+	}
+}
+
+resource "google_compute_region_instance_group_manager" "igm-basic" {
+	description = "Terraform test instance group manager"
+	name = "%s"
+	instance_template = "${google_compute_instance_template.igm-basic.self_link}"
+	base_instance_name = "igm-basic"
+	region = "us-central1"
+	target_size = 2
+	distribution_policy_zones = ["us-central1-a", "us-central1-f"]
+
+	named_port {
+		name = "http"
+		port = 8080
+	}
+
+	update_policy {
+		type = "%s"
+		minimal_action = "REPLACE"
+		max_surge_fixed = 2
+		max_unavailable_fixed = 0
+		min_ready_sec = 20
+	}
+}
+	`, template, igm, updateType)
+}
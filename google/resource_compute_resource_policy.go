@@ -0,0 +1,549 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Snapshot schedules start on the hour, in UTC.
+const resourcePolicyStartTimeRegex = "^([01][0-9]|2[0-3]):00$"
+
+func resourceComputeResourcePolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeResourcePolicyCreate,
+		Read:   resourceComputeResourcePolicyRead,
+		Delete: resourceComputeResourcePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeResourcePolicyImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateGCPName,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"snapshot_schedule_policy": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"instance_schedule_policy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"hourly_schedule": &schema.Schema{
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"hours_in_cycle": &schema.Schema{
+													Type:         schema.TypeInt,
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validation.IntBetween(1, 23),
+												},
+												"start_time": &schema.Schema{
+													Type:         schema.TypeString,
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validateRegexp(resourcePolicyStartTimeRegex),
+												},
+											},
+										},
+									},
+									"daily_schedule": &schema.Schema{
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"days_in_cycle": &schema.Schema{
+													Type:         schema.TypeInt,
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validation.IntBetween(1, 1),
+												},
+												"start_time": &schema.Schema{
+													Type:         schema.TypeString,
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validateRegexp(resourcePolicyStartTimeRegex),
+												},
+											},
+										},
+									},
+									"weekly_schedule": &schema.Schema{
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"day_of_weeks": &schema.Schema{
+													Type:     schema.TypeSet,
+													Required: true,
+													ForceNew: true,
+													MaxItems: 7,
+													Set:      computeResourcePolicyDayOfWeekHash,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"day": &schema.Schema{
+																Type:     schema.TypeString,
+																Required: true,
+																ForceNew: true,
+																ValidateFunc: validation.StringInSlice([]string{
+																	"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY",
+																}, false),
+															},
+															"start_time": &schema.Schema{
+																Type:         schema.TypeString,
+																Required:     true,
+																ForceNew:     true,
+																ValidateFunc: validateRegexp(resourcePolicyStartTimeRegex),
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"retention_policy": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"max_retention_days": &schema.Schema{
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"on_source_disk_delete": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										ForceNew:     true,
+										Default:      "KEEP_AUTO_SNAPSHOTS",
+										ValidateFunc: validation.StringInSlice([]string{"KEEP_AUTO_SNAPSHOTS", "APPLY_RETENTION_POLICY"}, false),
+									},
+								},
+							},
+						},
+						"snapshot_properties": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"labels": &schema.Schema{
+										Type:     schema.TypeMap,
+										Optional: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"storage_locations": &schema.Schema{
+										Type:     schema.TypeSet,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"guest_flush": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"instance_schedule_policy": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"snapshot_schedule_policy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"vm_start_schedule": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"vm_stop_schedule": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"start_time": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"expiration_time": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeResourcePolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	policy := &computeResourcePolicy{
+		Name:                   name,
+		Description:            d.Get("description").(string),
+		SnapshotSchedulePolicy: expandComputeResourcePolicySnapshotSchedule(d.Get("snapshot_schedule_policy").([]interface{})),
+		InstanceSchedulePolicy: expandComputeResourcePolicyInstanceSchedule(d.Get("instance_schedule_policy").([]interface{})),
+	}
+	if err := validateComputeResourcePolicy(policy); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating resource policy %s/%s: %+v", region, name, policy)
+	op, err := config.clientResourcePolicies.Insert(project, region, policy)
+	if err != nil {
+		return fmt.Errorf("Error creating resource policy %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", region, name))
+
+	err = computeOperationWait(config.clientCompute, op, project, "Creating Resource Policy")
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceComputeResourcePolicyRead(d, meta)
+}
+
+func resourceComputeResourcePolicyRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	policy, err := config.clientResourcePolicies.Get(project, region, name)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Resource Policy %q", name))
+	}
+
+	d.Set("description", policy.Description)
+	if err := d.Set("snapshot_schedule_policy", flattenComputeResourcePolicySnapshotSchedule(policy.SnapshotSchedulePolicy)); err != nil {
+		return fmt.Errorf("Error setting snapshot_schedule_policy: %s", err)
+	}
+	if err := d.Set("instance_schedule_policy", flattenComputeResourcePolicyInstanceSchedule(policy.InstanceSchedulePolicy)); err != nil {
+		return fmt.Errorf("Error setting instance_schedule_policy: %s", err)
+	}
+	d.Set("self_link", policy.SelfLink)
+	d.Set("region", region)
+	d.Set("project", project)
+
+	return nil
+}
+
+func resourceComputeResourcePolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Deleting resource policy %s/%s", region, name)
+	op, err := config.clientResourcePolicies.Delete(project, region, name)
+	if err != nil {
+		return fmt.Errorf("Error deleting resource policy %s: %s", name, err)
+	}
+
+	err = computeOperationWait(config.clientCompute, op, project, "Deleting Resource Policy")
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeResourcePolicyImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid resource policy specifier. Expecting {region}/{name}")
+	}
+
+	d.Set("region", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateComputeResourcePolicy checks the constraints between fields that
+// the schema can't express.
+func validateComputeResourcePolicy(policy *computeResourcePolicy) error {
+	if policy.SnapshotSchedulePolicy == nil && policy.InstanceSchedulePolicy == nil {
+		return fmt.Errorf("One of snapshot_schedule_policy or instance_schedule_policy must be set")
+	}
+
+	if s := policy.SnapshotSchedulePolicy; s != nil {
+		set := 0
+		for _, isSet := range []bool{s.Schedule.HourlySchedule != nil, s.Schedule.DailySchedule != nil, s.Schedule.WeeklySchedule != nil} {
+			if isSet {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("Exactly one of hourly_schedule, daily_schedule or weekly_schedule must be set")
+		}
+	}
+
+	if s := policy.InstanceSchedulePolicy; s != nil && s.VmStartSchedule == nil && s.VmStopSchedule == nil {
+		return fmt.Errorf("At least one of vm_start_schedule or vm_stop_schedule must be set")
+	}
+
+	return nil
+}
+
+func expandComputeResourcePolicySnapshotSchedule(configured []interface{}) *computeResourcePolicySnapshotSchedule {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+	raw := configured[0].(map[string]interface{})
+
+	policy := &computeResourcePolicySnapshotSchedule{
+		Schedule: &computeResourcePolicySchedule{},
+	}
+
+	if schedules := raw["schedule"].([]interface{}); len(schedules) > 0 && schedules[0] != nil {
+		schedule := schedules[0].(map[string]interface{})
+		if v := schedule["hourly_schedule"].([]interface{}); len(v) > 0 && v[0] != nil {
+			hourly := v[0].(map[string]interface{})
+			policy.Schedule.HourlySchedule = &computeResourcePolicyHourlyCycle{
+				HoursInCycle: int64(hourly["hours_in_cycle"].(int)),
+				StartTime:    hourly["start_time"].(string),
+			}
+		}
+		if v := schedule["daily_schedule"].([]interface{}); len(v) > 0 && v[0] != nil {
+			daily := v[0].(map[string]interface{})
+			policy.Schedule.DailySchedule = &computeResourcePolicyDailyCycle{
+				DaysInCycle: int64(daily["days_in_cycle"].(int)),
+				StartTime:   daily["start_time"].(string),
+			}
+		}
+		if v := schedule["weekly_schedule"].([]interface{}); len(v) > 0 && v[0] != nil {
+			weekly := v[0].(map[string]interface{})
+			policy.Schedule.WeeklySchedule = &computeResourcePolicyWeeklyCycle{}
+			for _, d := range weekly["day_of_weeks"].(*schema.Set).List() {
+				day := d.(map[string]interface{})
+				policy.Schedule.WeeklySchedule.DayOfWeeks = append(policy.Schedule.WeeklySchedule.DayOfWeeks, &computeResourcePolicyWeeklyCycleDayOfWeek{
+					Day:       day["day"].(string),
+					StartTime: day["start_time"].(string),
+				})
+			}
+		}
+	}
+
+	if v := raw["retention_policy"].([]interface{}); len(v) > 0 && v[0] != nil {
+		retention := v[0].(map[string]interface{})
+		policy.RetentionPolicy = &computeResourcePolicySnapshotRetention{
+			MaxRetentionDays:   int64(retention["max_retention_days"].(int)),
+			OnSourceDiskDelete: retention["on_source_disk_delete"].(string),
+		}
+	}
+
+	if v := raw["snapshot_properties"].([]interface{}); len(v) > 0 && v[0] != nil {
+		properties := v[0].(map[string]interface{})
+		policy.SnapshotProperties = &computeResourcePolicySnapshotProperties{
+			Labels:           convertStringMap(properties["labels"].(map[string]interface{})),
+			StorageLocations: convertStringSet(properties["storage_locations"].(*schema.Set)),
+			GuestFlush:       properties["guest_flush"].(bool),
+		}
+	}
+
+	return policy
+}
+
+func flattenComputeResourcePolicySnapshotSchedule(policy *computeResourcePolicySnapshotSchedule) []map[string]interface{} {
+	if policy == nil {
+		return nil
+	}
+
+	schedule := map[string]interface{}{}
+	if s := policy.Schedule; s != nil {
+		if s.HourlySchedule != nil {
+			schedule["hourly_schedule"] = []map[string]interface{}{{
+				"hours_in_cycle": int(s.HourlySchedule.HoursInCycle),
+				"start_time":     s.HourlySchedule.StartTime,
+			}}
+		}
+		if s.DailySchedule != nil {
+			schedule["daily_schedule"] = []map[string]interface{}{{
+				"days_in_cycle": int(s.DailySchedule.DaysInCycle),
+				"start_time":    s.DailySchedule.StartTime,
+			}}
+		}
+		if s.WeeklySchedule != nil {
+			days := schema.NewSet(computeResourcePolicyDayOfWeekHash, nil)
+			for _, day := range s.WeeklySchedule.DayOfWeeks {
+				days.Add(map[string]interface{}{
+					"day":        day.Day,
+					"start_time": day.StartTime,
+				})
+			}
+			schedule["weekly_schedule"] = []map[string]interface{}{{
+				"day_of_weeks": days,
+			}}
+		}
+	}
+
+	result := map[string]interface{}{
+		"schedule": []map[string]interface{}{schedule},
+	}
+	if r := policy.RetentionPolicy; r != nil {
+		result["retention_policy"] = []map[string]interface{}{{
+			"max_retention_days":    int(r.MaxRetentionDays),
+			"on_source_disk_delete": r.OnSourceDiskDelete,
+		}}
+	}
+	if p := policy.SnapshotProperties; p != nil {
+		result["snapshot_properties"] = []map[string]interface{}{{
+			"labels":            p.Labels,
+			"storage_locations": schema.NewSet(schema.HashString, convertStringArrToInterface(p.StorageLocations)),
+			"guest_flush":       p.GuestFlush,
+		}}
+	}
+
+	return []map[string]interface{}{result}
+}
+
+func expandComputeResourcePolicyInstanceSchedule(configured []interface{}) *computeResourcePolicyInstanceSchedule {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+	raw := configured[0].(map[string]interface{})
+
+	policy := &computeResourcePolicyInstanceSchedule{
+		TimeZone:       raw["time_zone"].(string),
+		StartTime:      raw["start_time"].(string),
+		ExpirationTime: raw["expiration_time"].(string),
+	}
+	if v := raw["vm_start_schedule"].(string); v != "" {
+		policy.VmStartSchedule = &computeResourcePolicyInstanceScheduleCron{Schedule: v}
+	}
+	if v := raw["vm_stop_schedule"].(string); v != "" {
+		policy.VmStopSchedule = &computeResourcePolicyInstanceScheduleCron{Schedule: v}
+	}
+
+	return policy
+}
+
+func flattenComputeResourcePolicyInstanceSchedule(policy *computeResourcePolicyInstanceSchedule) []map[string]interface{} {
+	if policy == nil {
+		return nil
+	}
+
+	result := map[string]interface{}{
+		"time_zone":       policy.TimeZone,
+		"start_time":      policy.StartTime,
+		"expiration_time": policy.ExpirationTime,
+	}
+	if policy.VmStartSchedule != nil {
+		result["vm_start_schedule"] = policy.VmStartSchedule.Schedule
+	}
+	if policy.VmStopSchedule != nil {
+		result["vm_stop_schedule"] = policy.VmStopSchedule.Schedule
+	}
+
+	return []map[string]interface{}{result}
+}
+
+func computeResourcePolicyDayOfWeekHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s-%s", m["day"].(string), m["start_time"].(string)))
+}
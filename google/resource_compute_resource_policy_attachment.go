@@ -0,0 +1,189 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceComputeResourcePolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeResourcePolicyAttachmentCreate,
+		Read:   resourceComputeResourcePolicyAttachmentRead,
+		Delete: resourceComputeResourcePolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeResourcePolicyAttachmentImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: compareSelfLinkOrResourceName,
+			},
+
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"disk": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: compareSelfLinkOrResourceName,
+				ConflictsWith:    []string{"instance"},
+			},
+
+			"instance": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: compareSelfLinkOrResourceName,
+				ConflictsWith:    []string{"disk"},
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceComputeResourcePolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	target, err := computeResourcePolicyAttachmentTarget(d)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	name := GetResourceNameFromSelfLink(d.Get("name").(string))
+	policyUrl := config.clientResourcePolicies.policyUrl(project, getRegionFromZone(zone), name)
+
+	log.Printf("[DEBUG] Attaching resource policy %s to %s/%s", name, zone, target)
+	op, err := config.clientResourcePolicies.Attach(project, zone, target, policyUrl)
+	if err != nil {
+		return fmt.Errorf("Error attaching resource policy %s to %s: %s", name, target, err)
+	}
+
+	err = computeOperationWait(config.clientCompute, op, project, "Attaching Resource Policy")
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", zone, target, name))
+
+	return resourceComputeResourcePolicyAttachmentRead(d, meta)
+}
+
+func resourceComputeResourcePolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	target, err := computeResourcePolicyAttachmentTarget(d)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	name := GetResourceNameFromSelfLink(d.Get("name").(string))
+
+	attached, err := config.clientResourcePolicies.GetAttached(project, zone, target)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Resource Policy Attachment %q", d.Id()))
+	}
+
+	if !computeResourcePolicyIsAttached(attached, name) {
+		log.Printf("[WARN] Removing resource policy attachment %s because it's gone", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("project", project)
+
+	return nil
+}
+
+func resourceComputeResourcePolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	target, err := computeResourcePolicyAttachmentTarget(d)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	name := GetResourceNameFromSelfLink(d.Get("name").(string))
+	policyUrl := config.clientResourcePolicies.policyUrl(project, getRegionFromZone(zone), name)
+
+	log.Printf("[DEBUG] Detaching resource policy %s from %s/%s", name, zone, target)
+	op, err := config.clientResourcePolicies.Detach(project, zone, target, policyUrl)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Resource Policy Attachment %q", d.Id()))
+	}
+
+	err = computeOperationWait(config.clientCompute, op, project, "Detaching Resource Policy")
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeResourcePolicyAttachmentImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 4 || (parts[1] != "disks" && parts[1] != "instances") {
+		return nil, fmt.Errorf("Invalid resource policy attachment specifier. Expecting {zone}/disks/{disk}/{name} or {zone}/instances/{instance}/{name}")
+	}
+
+	d.Set("zone", parts[0])
+	d.Set(strings.TrimSuffix(parts[1], "s"), parts[2])
+	d.Set("name", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// computeResourcePolicyAttachmentTarget returns the path of the disk or
+// instance the policy is attached to, relative to its zone.
+func computeResourcePolicyAttachmentTarget(d *schema.ResourceData) (string, error) {
+	if v, ok := d.GetOk("disk"); ok {
+		return "disks/" + GetResourceNameFromSelfLink(v.(string)), nil
+	}
+	if v, ok := d.GetOk("instance"); ok {
+		return "instances/" + GetResourceNameFromSelfLink(v.(string)), nil
+	}
+	return "", fmt.Errorf("One of disk or instance must be set")
+}
+
+func computeResourcePolicyIsAttached(attached []string, name string) bool {
+	for _, policy := range attached {
+		if GetResourceNameFromSelfLink(policy) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestComputeResourcePolicyAttachmentImport(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Id          string
+		Expected    map[string]string
+		ExpectError bool
+	}{
+		"disk": {
+			Id:       "us-central1-a/disks/my-disk/my-policy",
+			Expected: map[string]string{"zone": "us-central1-a", "disk": "my-disk", "name": "my-policy"},
+		},
+		"instance": {
+			Id:       "us-central1-a/instances/my-instance/my-policy",
+			Expected: map[string]string{"zone": "us-central1-a", "instance": "my-instance", "name": "my-policy"},
+		},
+		"unknown kind": {
+			Id:          "us-central1-a/images/my-image/my-policy",
+			ExpectError: true,
+		},
+		"missing policy": {
+			Id:          "us-central1-a/disks/my-disk",
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceComputeResourcePolicyAttachment().Schema, map[string]interface{}{})
+		d.SetId(tc.Id)
+
+		_, err := resourceComputeResourcePolicyAttachmentImportState(d, nil)
+		if (err != nil) != tc.ExpectError {
+			t.Errorf("%s: expected error %t, got %v", tn, tc.ExpectError, err)
+			continue
+		}
+
+		for k, v := range tc.Expected {
+			if actual := d.Get(k).(string); actual != v {
+				t.Errorf("%s: expected %s to be %q, got %q", tn, k, v, actual)
+			}
+		}
+	}
+}
+
+func TestAccComputeResourcePolicyAttachment_disk(t *testing.T) {
+	t.Parallel()
+
+	diskName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	policyName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeResourcePolicyAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeResourcePolicyAttachment_disk(diskName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeResourcePolicyAttachmentExists("google_compute_resource_policy_attachment.attachment"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_resource_policy_attachment.attachment",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccComputeResourcePolicyAttachment_instance(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	policyName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeResourcePolicyAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeResourcePolicyAttachment_instance(instanceName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeResourcePolicyAttachmentExists("google_compute_resource_policy_attachment.attachment"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_resource_policy_attachment.attachment",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckComputeResourcePolicyAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		attached, err := testAccGetComputeResourcePolicyAttachments(rs)
+		if err != nil {
+			return err
+		}
+
+		if !computeResourcePolicyIsAttached(attached, rs.Primary.Attributes["name"]) {
+			return fmt.Errorf("Resource policy %s is not attached", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckComputeResourcePolicyAttachmentDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_compute_resource_policy_attachment" {
+			continue
+		}
+
+		// The disk or instance is normally destroyed too, in which case there's
+		// nothing left to check.
+		attached, err := testAccGetComputeResourcePolicyAttachments(rs)
+		if err == nil && computeResourcePolicyIsAttached(attached, rs.Primary.Attributes["name"]) {
+			return fmt.Errorf("Resource policy %s is still attached", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccGetComputeResourcePolicyAttachments(rs *terraform.ResourceState) ([]string, error) {
+	config := testAccProvider.Meta().(*Config)
+
+	target := "disks/" + rs.Primary.Attributes["disk"]
+	if instance := rs.Primary.Attributes["instance"]; instance != "" {
+		target = "instances/" + instance
+	}
+
+	return config.clientResourcePolicies.GetAttached(config.Project, rs.Primary.Attributes["zone"], target)
+}
+
+func testAccComputeResourcePolicyAttachment_disk(diskName, policyName string) string {
+	return fmt.Sprintf(`
+resource "google_compute_disk" "disk" {
+	name  = "%s"
+	image = "debian-8-jessie-v20170523"
+	size  = 10
+	zone  = "us-central1-a"
+}
+
+resource "google_compute_resource_policy" "policy" {
+	name   = "%s"
+	region = "us-central1"
+
+	snapshot_schedule_policy {
+		schedule {
+			hourly_schedule {
+				hours_in_cycle = 6
+				start_time     = "00:00"
+			}
+		}
+	}
+}
+
+resource "google_compute_resource_policy_attachment" "attachment" {
+	name = "${google_compute_resource_policy.policy.name}"
+	disk = "${google_compute_disk.disk.name}"
+	zone = "us-central1-a"
+}`, diskName, policyName)
+}
+
+func testAccComputeResourcePolicyAttachment_instance(instanceName, policyName string) string {
+	return fmt.Sprintf(`
+resource "google_compute_instance" "instance" {
+	name         = "%s"
+	machine_type = "n1-standard-1"
+	zone         = "us-central1-a"
+
+	boot_disk {
+		initialize_params {
+			image = "debian-8-jessie-v20160803"
+		}
+	}
+
+	network_interface {
+		network = "default"
+	}
+}
+
+resource "google_compute_resource_policy" "policy" {
+	name   = "%s"
+	region = "us-central1"
+
+	instance_schedule_policy {
+		time_zone        = "US/Central"
+		vm_stop_schedule = "0 19 * * *"
+	}
+}
+
+resource "google_compute_resource_policy_attachment" "attachment" {
+	name     = "${google_compute_resource_policy.policy.name}"
+	instance = "${google_compute_instance.instance.name}"
+	zone     = "us-central1-a"
+}`, instanceName, policyName)
+}
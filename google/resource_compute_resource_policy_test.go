@@ -0,0 +1,327 @@
+package google
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestComputeResourcePolicySnapshotSchedule_roundTrip(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceComputeResourcePolicy().Schema, map[string]interface{}{
+		"name": "policy",
+		"snapshot_schedule_policy": []interface{}{
+			map[string]interface{}{
+				"schedule": []interface{}{
+					map[string]interface{}{
+						"daily_schedule": []interface{}{
+							map[string]interface{}{
+								"days_in_cycle": 1,
+								"start_time":    "04:00",
+							},
+						},
+					},
+				},
+				"retention_policy": []interface{}{
+					map[string]interface{}{
+						"max_retention_days": 7,
+					},
+				},
+				"snapshot_properties": []interface{}{
+					map[string]interface{}{
+						"labels":            map[string]interface{}{"cluster": "analytics"},
+						"storage_locations": []interface{}{"us"},
+					},
+				},
+			},
+		},
+	})
+
+	expected := &computeResourcePolicySnapshotSchedule{
+		Schedule: &computeResourcePolicySchedule{
+			DailySchedule: &computeResourcePolicyDailyCycle{DaysInCycle: 1, StartTime: "04:00"},
+		},
+		RetentionPolicy: &computeResourcePolicySnapshotRetention{
+			MaxRetentionDays:   7,
+			OnSourceDiskDelete: "KEEP_AUTO_SNAPSHOTS",
+		},
+		SnapshotProperties: &computeResourcePolicySnapshotProperties{
+			Labels:           map[string]string{"cluster": "analytics"},
+			StorageLocations: []string{"us"},
+		},
+	}
+
+	policy := expandComputeResourcePolicySnapshotSchedule(d.Get("snapshot_schedule_policy").([]interface{}))
+	if !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, policy)
+	}
+
+	if err := d.Set("snapshot_schedule_policy", flattenComputeResourcePolicySnapshotSchedule(policy)); err != nil {
+		t.Fatalf("Error setting snapshot_schedule_policy: %s", err)
+	}
+	if roundTripped := expandComputeResourcePolicySnapshotSchedule(d.Get("snapshot_schedule_policy").([]interface{})); !reflect.DeepEqual(roundTripped, expected) {
+		t.Fatalf("Expected %+v after a round trip, got %+v", expected, roundTripped)
+	}
+}
+
+func TestComputeResourcePolicyWeeklySchedule_roundTrip(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceComputeResourcePolicy().Schema, map[string]interface{}{
+		"name": "policy",
+	})
+
+	expected := &computeResourcePolicySnapshotSchedule{
+		Schedule: &computeResourcePolicySchedule{
+			WeeklySchedule: &computeResourcePolicyWeeklyCycle{
+				DayOfWeeks: []*computeResourcePolicyWeeklyCycleDayOfWeek{
+					{Day: "MONDAY", StartTime: "02:00"},
+				},
+			},
+		},
+	}
+
+	if err := d.Set("snapshot_schedule_policy", flattenComputeResourcePolicySnapshotSchedule(expected)); err != nil {
+		t.Fatalf("Error setting snapshot_schedule_policy: %s", err)
+	}
+	if policy := expandComputeResourcePolicySnapshotSchedule(d.Get("snapshot_schedule_policy").([]interface{})); !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, policy)
+	}
+}
+
+func TestComputeResourcePolicyInstanceSchedule_roundTrip(t *testing.T) {
+	t.Parallel()
+
+	expected := &computeResourcePolicyInstanceSchedule{
+		TimeZone:        "Europe/London",
+		VmStartSchedule: &computeResourcePolicyInstanceScheduleCron{Schedule: "0 7 * * MON-FRI"},
+		VmStopSchedule:  &computeResourcePolicyInstanceScheduleCron{Schedule: "0 19 * * MON-FRI"},
+	}
+
+	flattened := flattenComputeResourcePolicyInstanceSchedule(expected)
+	raw := make([]interface{}, len(flattened))
+	for i, v := range flattened {
+		raw[i] = v
+	}
+
+	if policy := expandComputeResourcePolicyInstanceSchedule(raw); !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, policy)
+	}
+}
+
+func TestValidateComputeResourcePolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Policy      *computeResourcePolicy
+		ExpectError bool
+	}{
+		"empty": {
+			Policy:      &computeResourcePolicy{},
+			ExpectError: true,
+		},
+		"no schedule": {
+			Policy: &computeResourcePolicy{
+				SnapshotSchedulePolicy: &computeResourcePolicySnapshotSchedule{Schedule: &computeResourcePolicySchedule{}},
+			},
+			ExpectError: true,
+		},
+		"two schedules": {
+			Policy: &computeResourcePolicy{
+				SnapshotSchedulePolicy: &computeResourcePolicySnapshotSchedule{Schedule: &computeResourcePolicySchedule{
+					HourlySchedule: &computeResourcePolicyHourlyCycle{HoursInCycle: 4, StartTime: "00:00"},
+					DailySchedule:  &computeResourcePolicyDailyCycle{DaysInCycle: 1, StartTime: "00:00"},
+				}},
+			},
+			ExpectError: true,
+		},
+		"hourly schedule": {
+			Policy: &computeResourcePolicy{
+				SnapshotSchedulePolicy: &computeResourcePolicySnapshotSchedule{Schedule: &computeResourcePolicySchedule{
+					HourlySchedule: &computeResourcePolicyHourlyCycle{HoursInCycle: 4, StartTime: "00:00"},
+				}},
+			},
+		},
+		"instance schedule without start or stop": {
+			Policy: &computeResourcePolicy{
+				InstanceSchedulePolicy: &computeResourcePolicyInstanceSchedule{TimeZone: "UTC"},
+			},
+			ExpectError: true,
+		},
+		"instance schedule": {
+			Policy: &computeResourcePolicy{
+				InstanceSchedulePolicy: &computeResourcePolicyInstanceSchedule{
+					TimeZone:       "UTC",
+					VmStopSchedule: &computeResourcePolicyInstanceScheduleCron{Schedule: "0 19 * * *"},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		if err := validateComputeResourcePolicy(tc.Policy); (err != nil) != tc.ExpectError {
+			t.Errorf("%s: expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestAccComputeResourcePolicy_snapshotSchedule(t *testing.T) {
+	t.Parallel()
+
+	policyName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeResourcePolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeResourcePolicy_snapshotSchedule(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_compute_resource_policy.policy", "snapshot_schedule_policy.0.schedule.0.daily_schedule.0.start_time", "04:00"),
+					resource.TestCheckResourceAttr("google_compute_resource_policy.policy", "snapshot_schedule_policy.0.retention_policy.0.max_retention_days", "14"),
+					resource.TestCheckResourceAttrSet("google_compute_resource_policy.policy", "self_link"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_resource_policy.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccComputeResourcePolicy_weeklySnapshotSchedule(t *testing.T) {
+	t.Parallel()
+
+	policyName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeResourcePolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeResourcePolicy_weeklySnapshotSchedule(policyName),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_resource_policy.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccComputeResourcePolicy_instanceSchedule(t *testing.T) {
+	t.Parallel()
+
+	policyName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeResourcePolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeResourcePolicy_instanceSchedule(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_compute_resource_policy.policy", "instance_schedule_policy.0.vm_start_schedule", "0 7 * * MON-FRI"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_resource_policy.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckComputeResourcePolicyDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_compute_resource_policy" {
+			continue
+		}
+
+		_, err := config.clientResourcePolicies.Get(
+			config.Project, rs.Primary.Attributes["region"], rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Resource policy %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccComputeResourcePolicy_snapshotSchedule(policyName string) string {
+	return fmt.Sprintf(`
+resource "google_compute_resource_policy" "policy" {
+	name   = "%s"
+	region = "us-central1"
+
+	snapshot_schedule_policy {
+		schedule {
+			daily_schedule {
+				days_in_cycle = 1
+				start_time    = "04:00"
+			}
+		}
+
+		retention_policy {
+			max_retention_days    = 14
+			on_source_disk_delete = "APPLY_RETENTION_POLICY"
+		}
+
+		snapshot_properties {
+			labels {
+				purpose = "backup"
+			}
+			storage_locations = ["us"]
+		}
+	}
+}`, policyName)
+}
+
+func testAccComputeResourcePolicy_weeklySnapshotSchedule(policyName string) string {
+	return fmt.Sprintf(`
+resource "google_compute_resource_policy" "policy" {
+	name   = "%s"
+	region = "us-central1"
+
+	snapshot_schedule_policy {
+		schedule {
+			weekly_schedule {
+				day_of_weeks {
+					day        = "MONDAY"
+					start_time = "02:00"
+				}
+				day_of_weeks {
+					day        = "THURSDAY"
+					start_time = "02:00"
+				}
+			}
+		}
+	}
+}`, policyName)
+}
+
+func testAccComputeResourcePolicy_instanceSchedule(policyName string) string {
+	return fmt.Sprintf(`
+resource "google_compute_resource_policy" "policy" {
+	name   = "%s"
+	region = "us-central1"
+
+	instance_schedule_policy {
+		time_zone         = "US/Central"
+		vm_start_schedule = "0 7 * * MON-FRI"
+		vm_stop_schedule  = "0 19 * * MON-FRI"
+	}
+}`, policyName)
+}
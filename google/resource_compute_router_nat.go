@@ -0,0 +1,381 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceComputeRouterNat() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeRouterNatCreate,
+		Read:   resourceComputeRouterNatRead,
+		Delete: resourceComputeRouterNatDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeRouterNatImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateGCPName,
+			},
+
+			"router": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"nat_ip_allocate_option": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AUTO_ONLY", "MANUAL_ONLY"}, false),
+			},
+
+			"source_subnetwork_ip_ranges_to_nat": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ALL_SUBNETWORKS_ALL_IP_RANGES",
+					"ALL_SUBNETWORKS_ALL_PRIMARY_IP_RANGES",
+					"LIST_OF_SUBNETWORKS",
+				}, false),
+			},
+
+			"nat_ips": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"subnetwork": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"source_ip_ranges_to_nat": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"ALL_IP_RANGES",
+									"LIST_OF_SECONDARY_IP_RANGES",
+									"PRIMARY_IP_RANGE",
+								}, false),
+							},
+							Set: schema.HashString,
+						},
+						"secondary_ip_range_names": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+
+			"min_ports_per_vm": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"udp_idle_timeout_sec": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"icmp_idle_timeout_sec": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"tcp_established_idle_timeout_sec": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"tcp_transitory_idle_timeout_sec": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceComputeRouterNatCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	routerName := d.Get("router").(string)
+	natName := d.Get("name").(string)
+
+	nat := expandComputeRouterNat(d)
+	if nat.NatIpAllocateOption == "MANUAL_ONLY" && len(nat.NatIps) == 0 {
+		return fmt.Errorf("nat_ips must be set when nat_ip_allocate_option is MANUAL_ONLY")
+	}
+	if nat.NatIpAllocateOption == "AUTO_ONLY" && len(nat.NatIps) > 0 {
+		return fmt.Errorf("nat_ips can't be set when nat_ip_allocate_option is AUTO_ONLY")
+	}
+	if nat.SourceSubnetworkIpRangesToNat == "LIST_OF_SUBNETWORKS" && len(nat.Subnetworks) == 0 {
+		return fmt.Errorf("At least one subnetwork must be set when source_subnetwork_ip_ranges_to_nat is LIST_OF_SUBNETWORKS")
+	}
+	if nat.SourceSubnetworkIpRangesToNat != "LIST_OF_SUBNETWORKS" && len(nat.Subnetworks) > 0 {
+		return fmt.Errorf("subnetwork can only be set when source_subnetwork_ip_ranges_to_nat is LIST_OF_SUBNETWORKS")
+	}
+
+	routerLock := getRouterLockName(region, routerName)
+	mutexKV.Lock(routerLock)
+	defer mutexKV.Unlock(routerLock)
+
+	nats, err := config.clientComputeRouterNats.Get(project, region, routerName)
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing router NAT %s because its router %s/%s is gone", natName, region, routerName)
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error Reading router %s/%s: %s", region, routerName, err)
+	}
+
+	for _, n := range nats {
+		if n.Name == natName {
+			d.SetId("")
+			return fmt.Errorf("Router %s has NAT %s already", routerName, natName)
+		}
+	}
+
+	log.Printf("[INFO] Adding NAT %s", natName)
+	nats = append(nats, nat)
+
+	log.Printf("[DEBUG] Updating router %s/%s with NATs: %+v", region, routerName, nats)
+	op, err := config.clientComputeRouterNats.Patch(project, region, routerName, nats)
+	if err != nil {
+		return fmt.Errorf("Error patching router %s/%s: %s", region, routerName, err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s/%s", region, routerName, natName))
+	err = computeOperationWait(config.clientCompute, op, project, "Patching router")
+	if err != nil {
+		d.SetId("")
+		return fmt.Errorf("Error waiting to patch router %s/%s: %s", region, routerName, err)
+	}
+
+	return resourceComputeRouterNatRead(d, meta)
+}
+
+func resourceComputeRouterNatRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	routerName := d.Get("router").(string)
+	natName := d.Get("name").(string)
+
+	nats, err := config.clientComputeRouterNats.Get(project, region, routerName)
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing router NAT %s because its router %s/%s is gone", natName, region, routerName)
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error Reading router %s/%s: %s", region, routerName, err)
+	}
+
+	for _, nat := range nats {
+		if nat.Name == natName {
+			d.SetId(fmt.Sprintf("%s/%s/%s", region, routerName, natName))
+			d.Set("nat_ip_allocate_option", nat.NatIpAllocateOption)
+			d.Set("nat_ips", nat.NatIps)
+			d.Set("source_subnetwork_ip_ranges_to_nat", nat.SourceSubnetworkIpRangesToNat)
+			if err := d.Set("subnetwork", flattenComputeRouterNatSubnetworks(nat.Subnetworks)); err != nil {
+				return fmt.Errorf("Error setting subnetwork: %s", err)
+			}
+			d.Set("min_ports_per_vm", nat.MinPortsPerVm)
+			d.Set("udp_idle_timeout_sec", nat.UdpIdleTimeoutSec)
+			d.Set("icmp_idle_timeout_sec", nat.IcmpIdleTimeoutSec)
+			d.Set("tcp_established_idle_timeout_sec", nat.TcpEstablishedIdleTimeoutSec)
+			d.Set("tcp_transitory_idle_timeout_sec", nat.TcpTransitoryIdleTimeoutSec)
+			d.Set("region", region)
+			d.Set("project", project)
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] Removing router NAT %s/%s/%s because it is gone", region, routerName, natName)
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeRouterNatDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	routerName := d.Get("router").(string)
+	natName := d.Get("name").(string)
+
+	routerLock := getRouterLockName(region, routerName)
+	mutexKV.Lock(routerLock)
+	defer mutexKV.Unlock(routerLock)
+
+	nats, err := config.clientComputeRouterNats.Get(project, region, routerName)
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing router NAT %s because its router %s/%s is gone", natName, region, routerName)
+
+			return nil
+		}
+
+		return fmt.Errorf("Error Reading Router %s: %s", routerName, err)
+	}
+
+	newNats := make([]*computeRouterNat, 0, len(nats))
+	for _, nat := range nats {
+		if nat.Name != natName {
+			newNats = append(newNats, nat)
+		}
+	}
+
+	if len(newNats) == len(nats) {
+		log.Printf("[DEBUG] Router %s/%s had no NAT %s already", region, routerName, natName)
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[INFO] Removing NAT %s from router %s/%s", natName, region, routerName)
+	op, err := config.clientComputeRouterNats.Patch(project, region, routerName, newNats)
+	if err != nil {
+		return fmt.Errorf("Error patching router %s/%s: %s", region, routerName, err)
+	}
+
+	err = computeOperationWait(config.clientCompute, op, project, "Patching router")
+	if err != nil {
+		return fmt.Errorf("Error waiting to patch router %s/%s: %s", region, routerName, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeRouterNatImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid router NAT specifier. Expecting {region}/{router}/{nat}")
+	}
+
+	d.Set("region", parts[0])
+	d.Set("router", parts[1])
+	d.Set("name", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandComputeRouterNat(d *schema.ResourceData) *computeRouterNat {
+	nat := &computeRouterNat{
+		Name:                          d.Get("name").(string),
+		NatIpAllocateOption:           d.Get("nat_ip_allocate_option").(string),
+		NatIps:                        convertStringSet(d.Get("nat_ips").(*schema.Set)),
+		SourceSubnetworkIpRangesToNat: d.Get("source_subnetwork_ip_ranges_to_nat").(string),
+		MinPortsPerVm:                 int64(d.Get("min_ports_per_vm").(int)),
+		UdpIdleTimeoutSec:             int64(d.Get("udp_idle_timeout_sec").(int)),
+		IcmpIdleTimeoutSec:            int64(d.Get("icmp_idle_timeout_sec").(int)),
+		TcpEstablishedIdleTimeoutSec:  int64(d.Get("tcp_established_idle_timeout_sec").(int)),
+		TcpTransitoryIdleTimeoutSec:   int64(d.Get("tcp_transitory_idle_timeout_sec").(int)),
+	}
+
+	for _, raw := range d.Get("subnetwork").(*schema.Set).List() {
+		s := raw.(map[string]interface{})
+		nat.Subnetworks = append(nat.Subnetworks, &computeRouterNatSubnetwork{
+			Name:                  s["name"].(string),
+			SourceIpRangesToNat:   convertStringSet(s["source_ip_ranges_to_nat"].(*schema.Set)),
+			SecondaryIpRangeNames: convertStringSet(s["secondary_ip_range_names"].(*schema.Set)),
+		})
+	}
+
+	return nat
+}
+
+func flattenComputeRouterNatSubnetworks(subnetworks []*computeRouterNatSubnetwork) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(subnetworks))
+	for _, s := range subnetworks {
+		result = append(result, map[string]interface{}{
+			"name":                     s.Name,
+			"source_ip_ranges_to_nat":  schema.NewSet(schema.HashString, convertStringArrToInterface(s.SourceIpRangesToNat)),
+			"secondary_ip_range_names": schema.NewSet(schema.HashString, convertStringArrToInterface(s.SecondaryIpRangeNames)),
+		})
+	}
+	return result
+}
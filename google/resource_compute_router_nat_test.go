@@ -0,0 +1,313 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccComputeRouterNat_basic(t *testing.T) {
+	t.Parallel()
+
+	testId := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeRouterNatDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeRouterNatBasic(testId),
+				Check: testAccCheckComputeRouterNatExists(
+					"google_compute_router_nat.foobar"),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_router_nat.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: testAccComputeRouterNatKeepRouter(testId),
+				Check: testAccCheckComputeRouterNatDelete(
+					"google_compute_router_nat.foobar"),
+			},
+		},
+	})
+}
+
+func TestAccComputeRouterNat_withManualIpAndSubnetConfiguration(t *testing.T) {
+	t.Parallel()
+
+	testId := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeRouterNatDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeRouterNatWithManualIpAndSubnetConfiguration(testId),
+				Check: testAccCheckComputeRouterNatExists(
+					"google_compute_router_nat.foobar"),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_router_nat.foobar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestComputeRouterNatExpandFlatten(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceComputeRouterNat().Schema, map[string]interface{}{
+		"name":                               "nat-1",
+		"router":                             "router-1",
+		"nat_ip_allocate_option":             "MANUAL_ONLY",
+		"nat_ips":                            []interface{}{"https://www.googleapis.com/compute/v1/projects/p/regions/us-central1/addresses/a"},
+		"source_subnetwork_ip_ranges_to_nat": "LIST_OF_SUBNETWORKS",
+		"subnetwork": []interface{}{
+			map[string]interface{}{
+				"name":                     "https://www.googleapis.com/compute/v1/projects/p/regions/us-central1/subnetworks/s",
+				"source_ip_ranges_to_nat":  []interface{}{"LIST_OF_SECONDARY_IP_RANGES"},
+				"secondary_ip_range_names": []interface{}{"pods"},
+			},
+		},
+		"udp_idle_timeout_sec": 60,
+	})
+
+	nat := expandComputeRouterNat(d)
+	if nat.Name != "nat-1" || nat.NatIpAllocateOption != "MANUAL_ONLY" || nat.SourceSubnetworkIpRangesToNat != "LIST_OF_SUBNETWORKS" {
+		t.Fatalf("Unexpected NAT: %+v", nat)
+	}
+	if len(nat.NatIps) != 1 {
+		t.Fatalf("Expected 1 NAT IP, got %v", nat.NatIps)
+	}
+	if nat.UdpIdleTimeoutSec != 60 || nat.TcpTransitoryIdleTimeoutSec != 0 {
+		t.Errorf("Unexpected timeouts: udp %d, tcp transitory %d", nat.UdpIdleTimeoutSec, nat.TcpTransitoryIdleTimeoutSec)
+	}
+	if len(nat.Subnetworks) != 1 {
+		t.Fatalf("Expected 1 subnetwork, got %d", len(nat.Subnetworks))
+	}
+	s := nat.Subnetworks[0]
+	if len(s.SourceIpRangesToNat) != 1 || s.SourceIpRangesToNat[0] != "LIST_OF_SECONDARY_IP_RANGES" {
+		t.Errorf("Unexpected source_ip_ranges_to_nat: %v", s.SourceIpRangesToNat)
+	}
+	if len(s.SecondaryIpRangeNames) != 1 || s.SecondaryIpRangeNames[0] != "pods" {
+		t.Errorf("Unexpected secondary_ip_range_names: %v", s.SecondaryIpRangeNames)
+	}
+
+	if err := d.Set("subnetwork", flattenComputeRouterNatSubnetworks(nat.Subnetworks)); err != nil {
+		t.Fatalf("Error setting subnetwork: %s", err)
+	}
+	if roundTrip := expandComputeRouterNat(d).Subnetworks; len(roundTrip) != 1 || roundTrip[0].Name != s.Name {
+		t.Errorf("Expected subnetworks to round trip, got %+v", roundTrip)
+	}
+}
+
+func testAccCheckComputeRouterNatDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	routersService := config.clientCompute.Routers
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_compute_router" {
+			continue
+		}
+
+		project, err := getTestProject(rs.Primary, config)
+		if err != nil {
+			return err
+		}
+
+		region, err := getTestRegion(rs.Primary, config)
+		if err != nil {
+			return err
+		}
+
+		routerName := rs.Primary.Attributes["name"]
+
+		_, err = routersService.Get(project, region, routerName).Do()
+
+		if err == nil {
+			return fmt.Errorf("Error, Router %s in region %s still exists",
+				routerName, region)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckComputeRouterNatDelete(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Config)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "google_compute_router_nat" {
+				continue
+			}
+
+			project, err := getTestProject(rs.Primary, config)
+			if err != nil {
+				return err
+			}
+
+			region, err := getTestRegion(rs.Primary, config)
+			if err != nil {
+				return err
+			}
+
+			name := rs.Primary.Attributes["name"]
+			routerName := rs.Primary.Attributes["router"]
+
+			nats, err := config.clientComputeRouterNats.Get(project, region, routerName)
+			if err != nil {
+				return fmt.Errorf("Error Reading Router %s: %s", routerName, err)
+			}
+
+			for _, nat := range nats {
+				if nat.Name == name {
+					return fmt.Errorf("NAT %s still exists on router %s/%s", name, region, routerName)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckComputeRouterNatExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+
+		project, err := getTestProject(rs.Primary, config)
+		if err != nil {
+			return err
+		}
+
+		region, err := getTestRegion(rs.Primary, config)
+		if err != nil {
+			return err
+		}
+
+		name := rs.Primary.Attributes["name"]
+		routerName := rs.Primary.Attributes["router"]
+
+		nats, err := config.clientComputeRouterNats.Get(project, region, routerName)
+		if err != nil {
+			return fmt.Errorf("Error Reading Router %s: %s", routerName, err)
+		}
+
+		for _, nat := range nats {
+			if nat.Name == name {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("NAT %s not found for router %s", name, routerName)
+	}
+}
+
+func testAccComputeRouterNatBasic(testId string) string {
+	return fmt.Sprintf(`
+		resource "google_compute_network" "foobar" {
+			name = "router-nat-test-%s"
+		}
+		resource "google_compute_subnetwork" "foobar" {
+			name = "router-nat-test-subnetwork-%s"
+			network = "${google_compute_network.foobar.self_link}"
+			ip_cidr_range = "10.0.0.0/16"
+			region = "us-central1"
+		}
+		resource "google_compute_router" "foobar"{
+			name = "router-nat-test-%s"
+			region = "${google_compute_subnetwork.foobar.region}"
+			network = "${google_compute_network.foobar.self_link}"
+			bgp {
+				asn = 64514
+			}
+		}
+		resource "google_compute_router_nat" "foobar" {
+			name = "router-nat-test-%s"
+			router = "${google_compute_router.foobar.name}"
+			region = "${google_compute_router.foobar.region}"
+			nat_ip_allocate_option = "AUTO_ONLY"
+			source_subnetwork_ip_ranges_to_nat = "ALL_SUBNETWORKS_ALL_IP_RANGES"
+		}
+	`, testId, testId, testId, testId)
+}
+
+func testAccComputeRouterNatWithManualIpAndSubnetConfiguration(testId string) string {
+	return fmt.Sprintf(`
+		resource "google_compute_network" "foobar" {
+			name = "router-nat-test-%s"
+			auto_create_subnetworks = "false"
+		}
+		resource "google_compute_subnetwork" "foobar" {
+			name = "router-nat-test-subnetwork-%s"
+			network = "${google_compute_network.foobar.self_link}"
+			ip_cidr_range = "10.0.0.0/16"
+			region = "us-central1"
+		}
+		resource "google_compute_address" "foobar" {
+			name = "router-nat-test-%s"
+			region = "${google_compute_subnetwork.foobar.region}"
+		}
+		resource "google_compute_router" "foobar"{
+			name = "router-nat-test-%s"
+			region = "${google_compute_subnetwork.foobar.region}"
+			network = "${google_compute_network.foobar.self_link}"
+			bgp {
+				asn = 64514
+			}
+		}
+		resource "google_compute_router_nat" "foobar" {
+			name = "router-nat-test-%s"
+			router = "${google_compute_router.foobar.name}"
+			region = "${google_compute_router.foobar.region}"
+			nat_ip_allocate_option = "MANUAL_ONLY"
+			nat_ips = ["${google_compute_address.foobar.self_link}"]
+			source_subnetwork_ip_ranges_to_nat = "LIST_OF_SUBNETWORKS"
+			subnetwork {
+				name = "${google_compute_subnetwork.foobar.self_link}"
+				source_ip_ranges_to_nat = ["ALL_IP_RANGES"]
+			}
+			udp_idle_timeout_sec = 60
+		}
+	`, testId, testId, testId, testId, testId)
+}
+
+func testAccComputeRouterNatKeepRouter(testId string) string {
+	return fmt.Sprintf(`
+		resource "google_compute_network" "foobar" {
+			name = "router-nat-test-%s"
+		}
+		resource "google_compute_subnetwork" "foobar" {
+			name = "router-nat-test-subnetwork-%s"
+			network = "${google_compute_network.foobar.self_link}"
+			ip_cidr_range = "10.0.0.0/16"
+			region = "us-central1"
+		}
+		resource "google_compute_router" "foobar"{
+			name = "router-nat-test-%s"
+			region = "${google_compute_subnetwork.foobar.region}"
+			network = "${google_compute_network.foobar.self_link}"
+			bgp {
+				asn = 64514
+			}
+		}
+	`, testId, testId, testId)
+}
@@ -12,6 +12,9 @@ func resourceComputeSharedVpcHostProject() *schema.Resource {
 		Create: resourceComputeSharedVpcHostProjectCreate,
 		Read:   resourceComputeSharedVpcHostProjectRead,
 		Delete: resourceComputeSharedVpcHostProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeSharedVpcHostProjectImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"project": &schema.Schema{
@@ -78,3 +81,8 @@ func resourceComputeSharedVpcHostProjectDelete(d *schema.ResourceData, meta inte
 	d.SetId("")
 	return nil
 }
+
+func resourceComputeSharedVpcHostProjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("project", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
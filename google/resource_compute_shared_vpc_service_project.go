@@ -2,12 +2,13 @@ package google
 
 import (
 	"fmt"
+	"log"
+	"strings"
 
 	"google.golang.org/api/compute/v1"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"google.golang.org/api/googleapi"
-	"log"
 )
 
 func resourceComputeSharedVpcServiceProject() *schema.Resource {
@@ -15,6 +16,9 @@ func resourceComputeSharedVpcServiceProject() *schema.Resource {
 		Create: resourceComputeSharedVpcServiceProjectCreate,
 		Read:   resourceComputeSharedVpcServiceProjectRead,
 		Delete: resourceComputeSharedVpcServiceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeSharedVpcServiceProjectImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"host_project": &schema.Schema{
@@ -94,6 +98,18 @@ func resourceComputeSharedVpcServiceProjectDelete(d *schema.ResourceData, meta i
 	return nil
 }
 
+func resourceComputeSharedVpcServiceProjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid Shared VPC service project specifier %q, expected {host_project}/{service_project}", d.Id())
+	}
+
+	d.Set("host_project", parts[0])
+	d.Set("service_project", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func disableXpnResource(config *Config, hostProject, project string) error {
 	req := &compute.ProjectsDisableXpnResourceRequest{
 		XpnResource: &compute.XpnResourceId{
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"os"
 )
@@ -28,6 +29,16 @@ func TestAccComputeSharedVpc_basic(t *testing.T) {
 					testAccCheckComputeSharedVpcServiceProject(hostProject, serviceProject, true),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "google_compute_shared_vpc_host_project.host",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_shared_vpc_service_project.service",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Use a separate TestStep rather than a CheckDestroy because we need the project to still exist.
 			resource.TestStep{
 				Config: testAccComputeSharedVpc_disabled(hostProject, serviceProject, org, billingId),
@@ -40,6 +51,28 @@ func TestAccComputeSharedVpc_basic(t *testing.T) {
 	})
 }
 
+func TestComputeSharedVpcServiceProjectImport(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceComputeSharedVpcServiceProject().Schema, map[string]interface{}{})
+	d.SetId("host-project/service-project")
+
+	if _, err := resourceComputeSharedVpcServiceProjectImport(d, nil); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if host := d.Get("host_project").(string); host != "host-project" {
+		t.Errorf("Expected host_project host-project, got %s", host)
+	}
+	if service := d.Get("service_project").(string); service != "service-project" {
+		t.Errorf("Expected service_project service-project, got %s", service)
+	}
+
+	d.SetId("service-project")
+	if _, err := resourceComputeSharedVpcServiceProjectImport(d, nil); err == nil {
+		t.Error("Expected an error importing an ID without a host project")
+	}
+}
+
 func testAccCheckComputeSharedVpcHostProject(hostProject string, enabled bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		config := testAccProvider.Meta().(*Config)
@@ -0,0 +1,212 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceComputeSoleTenantNodeGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeSoleTenantNodeGroupCreate,
+		Read:   resourceComputeSoleTenantNodeGroupRead,
+		Update: resourceComputeSoleTenantNodeGroupUpdate,
+		Delete: resourceComputeSoleTenantNodeGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeSoleTenantNodeGroupImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateGCPName,
+			},
+
+			"zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"node_template": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: compareSelfLinkOrResourceName,
+			},
+
+			"size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"creation_timestamp": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeSoleTenantNodeGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+	group := &computeNodeGroup{
+		Name:         name,
+		Description:  d.Get("description").(string),
+		NodeTemplate: computeSoleTenantNodeGroupTemplateUrl(config, d.Get("node_template").(string), project, zone),
+	}
+
+	log.Printf("[DEBUG] Creating node group %s/%s: %+v", zone, name, group)
+	op, err := config.clientComputeSoleTenancy.InsertNodeGroup(project, zone, int64(d.Get("size").(int)), group)
+	if err != nil {
+		return fmt.Errorf("Error creating node group %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", zone, name))
+
+	err = computeOperationWaitTime(config.clientCompute, op, project, "Creating Node Group", int(d.Timeout(schema.TimeoutCreate).Minutes()))
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceComputeSoleTenantNodeGroupRead(d, meta)
+}
+
+func resourceComputeSoleTenantNodeGroupRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+	group, err := config.clientComputeSoleTenancy.GetNodeGroup(project, zone, name)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Node Group %q", name))
+	}
+
+	d.Set("node_template", group.NodeTemplate)
+	d.Set("size", group.Size)
+	d.Set("description", group.Description)
+	d.Set("creation_timestamp", group.CreationTimestamp)
+	d.Set("self_link", group.SelfLink)
+	d.Set("project", project)
+
+	return nil
+}
+
+func resourceComputeSoleTenantNodeGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+
+	if d.HasChange("node_template") {
+		nodeTemplate := computeSoleTenantNodeGroupTemplateUrl(config, d.Get("node_template").(string), project, zone)
+		op, err := config.clientComputeSoleTenancy.SetNodeGroupNodeTemplate(project, zone, name, nodeTemplate)
+		if err != nil {
+			return fmt.Errorf("Error setting node template of node group %s: %s", name, err)
+		}
+
+		err = computeOperationWaitTime(config.clientCompute, op, project, "Setting Node Group Template", int(d.Timeout(schema.TimeoutUpdate).Minutes()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceComputeSoleTenantNodeGroupRead(d, meta)
+}
+
+func resourceComputeSoleTenantNodeGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Deleting node group %s/%s", zone, name)
+	op, err := config.clientComputeSoleTenancy.DeleteNodeGroup(project, zone, name)
+	if err != nil {
+		return fmt.Errorf("Error deleting node group %s: %s", name, err)
+	}
+
+	err = computeOperationWaitTime(config.clientCompute, op, project, "Deleting Node Group", int(d.Timeout(schema.TimeoutDelete).Minutes()))
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeSoleTenantNodeGroupImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid node group specifier. Expecting {zone}/{name}")
+	}
+
+	d.Set("zone", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// computeSoleTenantNodeGroupTemplateUrl returns the URL of nodeTemplate, which is
+// either already a URL or the name of a template in the group's region.
+func computeSoleTenantNodeGroupTemplateUrl(config *Config, nodeTemplate, project, zone string) string {
+	if strings.Contains(nodeTemplate, "/") {
+		return nodeTemplate
+	}
+	return config.clientComputeSoleTenancy.nodeTemplateUrl(project, getRegionFromZone(zone), nodeTemplate)
+}
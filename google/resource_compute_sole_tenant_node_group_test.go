@@ -0,0 +1,115 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestComputeSoleTenantNodeGroupTemplateUrl(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		clientComputeSoleTenancy: &computeSoleTenancyClient{
+			BasePath: "https://www.googleapis.com/compute/v1/projects/",
+		},
+	}
+
+	cases := map[string]struct {
+		NodeTemplate string
+		Expected     string
+	}{
+		"name": {
+			NodeTemplate: "template-1",
+			Expected:     "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/nodeTemplates/template-1",
+		},
+		"self link": {
+			NodeTemplate: "https://www.googleapis.com/compute/v1/projects/other-project/regions/us-central1/nodeTemplates/template-1",
+			Expected:     "https://www.googleapis.com/compute/v1/projects/other-project/regions/us-central1/nodeTemplates/template-1",
+		},
+	}
+
+	for tn, tc := range cases {
+		if actual := computeSoleTenantNodeGroupTemplateUrl(config, tc.NodeTemplate, "my-project", "us-central1-a"); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestAccComputeSoleTenantNodeGroup_updateNodeTemplate(t *testing.T) {
+	t.Parallel()
+
+	groupName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	templateName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeSoleTenantNodeGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeSoleTenantNodeGroup_nodeTemplate(groupName, templateName, "template1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_compute_sole_tenant_node_group.nodes", "size", "1"),
+					resource.TestCheckResourceAttrPair(
+						"google_compute_sole_tenant_node_group.nodes", "node_template",
+						"google_compute_sole_tenant_node_template.template1", "self_link"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_sole_tenant_node_group.nodes",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: testAccComputeSoleTenantNodeGroup_nodeTemplate(groupName, templateName, "template2"),
+				Check: resource.TestCheckResourceAttrPair(
+					"google_compute_sole_tenant_node_group.nodes", "node_template",
+					"google_compute_sole_tenant_node_template.template2", "self_link"),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeSoleTenantNodeGroupDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_compute_sole_tenant_node_group" {
+			continue
+		}
+
+		_, err := config.clientComputeSoleTenancy.GetNodeGroup(
+			config.Project, rs.Primary.Attributes["zone"], rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Node group %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccComputeSoleTenantNodeGroup_nodeTemplate(groupName, templateName, template string) string {
+	return fmt.Sprintf(`
+resource "google_compute_sole_tenant_node_template" "template1" {
+	name      = "%s-1"
+	region    = "us-central1"
+	node_type = "n1-node-96-624"
+}
+
+resource "google_compute_sole_tenant_node_template" "template2" {
+	name      = "%s-2"
+	region    = "us-central1"
+	node_type = "n1-node-96-624"
+}
+
+resource "google_compute_sole_tenant_node_group" "nodes" {
+	name          = "%s"
+	zone          = "us-central1-a"
+	description   = "Resource created for Terraform acceptance testing"
+	size          = 1
+	node_template = "${google_compute_sole_tenant_node_template.%s.self_link}"
+}`, templateName, templateName, groupName, template)
+}
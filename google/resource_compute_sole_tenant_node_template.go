@@ -0,0 +1,230 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceComputeSoleTenantNodeTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeSoleTenantNodeTemplateCreate,
+		Read:   resourceComputeSoleTenantNodeTemplateRead,
+		Delete: resourceComputeSoleTenantNodeTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeSoleTenantNodeTemplateImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateGCPName,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"node_type": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"node_type_flexibility"},
+			},
+
+			"node_type_flexibility": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"node_type"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cpus": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"memory": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"local_ssd": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"node_affinity_labels": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"creation_timestamp": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeSoleTenantNodeTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	template := &computeNodeTemplate{
+		Name:               name,
+		Description:        d.Get("description").(string),
+		NodeType:           d.Get("node_type").(string),
+		NodeAffinityLabels: convertStringMap(d.Get("node_affinity_labels").(map[string]interface{})),
+	}
+	if v, ok := d.GetOk("node_type_flexibility"); ok {
+		flexibility := v.([]interface{})[0].(map[string]interface{})
+		template.NodeTypeFlexibility = &computeNodeTemplateFlexibility{
+			Cpus:   flexibility["cpus"].(string),
+			Memory: flexibility["memory"].(string),
+		}
+	}
+	if template.NodeType == "" && template.NodeTypeFlexibility == nil {
+		return fmt.Errorf("One of node_type or node_type_flexibility must be set")
+	}
+
+	log.Printf("[DEBUG] Creating node template %s/%s: %+v", region, name, template)
+	op, err := config.clientComputeSoleTenancy.InsertNodeTemplate(project, region, template)
+	if err != nil {
+		return fmt.Errorf("Error creating node template %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", region, name))
+
+	err = computeOperationWait(config.clientCompute, op, project, "Creating Node Template")
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceComputeSoleTenantNodeTemplateRead(d, meta)
+}
+
+func resourceComputeSoleTenantNodeTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	template, err := config.clientComputeSoleTenancy.GetNodeTemplate(project, region, name)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Node Template %q", name))
+	}
+
+	d.Set("description", template.Description)
+	d.Set("node_type", GetResourceNameFromSelfLink(template.NodeType))
+	if template.NodeTypeFlexibility != nil {
+		d.Set("node_type_flexibility", []map[string]interface{}{
+			{
+				"cpus":      template.NodeTypeFlexibility.Cpus,
+				"memory":    template.NodeTypeFlexibility.Memory,
+				"local_ssd": template.NodeTypeFlexibility.LocalSsd,
+			},
+		})
+	} else {
+		d.Set("node_type_flexibility", nil)
+	}
+	d.Set("node_affinity_labels", template.NodeAffinityLabels)
+	d.Set("creation_timestamp", template.CreationTimestamp)
+	d.Set("self_link", template.SelfLink)
+	d.Set("region", region)
+	d.Set("project", project)
+
+	return nil
+}
+
+func resourceComputeSoleTenantNodeTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, err := getRegion(d, config)
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Deleting node template %s/%s", region, name)
+	op, err := config.clientComputeSoleTenancy.DeleteNodeTemplate(project, region, name)
+	if err != nil {
+		return fmt.Errorf("Error deleting node template %s: %s", name, err)
+	}
+
+	err = computeOperationWait(config.clientCompute, op, project, "Deleting Node Template")
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeSoleTenantNodeTemplateImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid node template specifier. Expecting {region}/{name}")
+	}
+
+	d.Set("region", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,101 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccComputeSoleTenantNodeTemplate_basic(t *testing.T) {
+	t.Parallel()
+
+	templateName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeSoleTenantNodeTemplateDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeSoleTenantNodeTemplate_basic(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_compute_sole_tenant_node_template.template", "node_type", "n1-node-96-624"),
+					resource.TestCheckResourceAttr("google_compute_sole_tenant_node_template.template", "node_affinity_labels.workload", "dataproc"),
+					resource.TestCheckResourceAttrSet("google_compute_sole_tenant_node_template.template", "self_link"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_compute_sole_tenant_node_template.template",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccComputeSoleTenantNodeTemplate_flexibility(t *testing.T) {
+	t.Parallel()
+
+	templateName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeSoleTenantNodeTemplateDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeSoleTenantNodeTemplate_flexibility(templateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_compute_sole_tenant_node_template.template", "node_type_flexibility.0.cpus", "96"),
+					resource.TestCheckResourceAttrSet("google_compute_sole_tenant_node_template.template", "node_type_flexibility.0.local_ssd"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeSoleTenantNodeTemplateDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_compute_sole_tenant_node_template" {
+			continue
+		}
+
+		_, err := config.clientComputeSoleTenancy.GetNodeTemplate(
+			config.Project, rs.Primary.Attributes["region"], rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Node template %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccComputeSoleTenantNodeTemplate_basic(templateName string) string {
+	return fmt.Sprintf(`
+resource "google_compute_sole_tenant_node_template" "template" {
+	name        = "%s"
+	region      = "us-central1"
+	description = "Resource created for Terraform acceptance testing"
+	node_type   = "n1-node-96-624"
+
+	node_affinity_labels {
+		workload = "dataproc"
+	}
+}`, templateName)
+}
+
+func testAccComputeSoleTenantNodeTemplate_flexibility(templateName string) string {
+	return fmt.Sprintf(`
+resource "google_compute_sole_tenant_node_template" "template" {
+	name   = "%s"
+	region = "us-central1"
+
+	node_type_flexibility {
+		cpus   = "96"
+		memory = "any"
+	}
+}`, templateName)
+}
@@ -1,10 +1,13 @@
 package google
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,8 +17,10 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 
+	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/dataproc/v1"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/api/storage/v1"
 )
 
 func resourceDataprocCluster() *schema.Resource {
@@ -24,6 +29,9 @@ func resourceDataprocCluster() *schema.Resource {
 		Read:   resourceDataprocClusterRead,
 		Update: resourceDataprocClusterUpdate,
 		Delete: resourceDataprocClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDataprocClusterImportState,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -36,27 +44,7 @@ func resourceDataprocCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
-				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					value := v.(string)
-
-					if len(value) > 55 {
-						errors = append(errors, fmt.Errorf(
-							"%q cannot be longer than 55 characters", k))
-					}
-					if !regexp.MustCompile("^[a-z0-9-]+$").MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q can only contain lowercase letters, numbers and hyphens", k))
-					}
-					if !regexp.MustCompile("^[a-z]").MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q must start with a letter", k))
-					}
-					if !regexp.MustCompile("[a-z0-9]$").MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q must end with a number or a letter", k))
-					}
-					return
-				},
+				ValidateFunc: validateDataprocClusterName,
 			},
 
 			"project": {
@@ -72,14 +60,56 @@ func resourceDataprocCluster() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// Only the labels set by the user, the goog-dataproc-* labels
+			// Dataproc adds itself are left out so that drift on the others
+			// shows up.
 			"labels": {
 				Type:     schema.TypeMap,
 				Optional: true,
 				Elem:     schema.TypeString,
-				// GCP automatically adds two labels
-				//    'goog-dataproc-cluster-uuid'
-				//    'goog-dataproc-cluster-name'
+			},
+
+			// All labels of the cluster, including the goog-dataproc-* ones.
+			"effective_labels": {
+				Type:     schema.TypeMap,
 				Computed: true,
+				Elem:     schema.TypeString,
+			},
+
+			// Only used when the cluster is created, so changing it doesn't
+			// recreate the cluster.
+			"verification_job": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"main_class": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "org.apache.spark.examples.SparkPi",
+						},
+
+						"jar_file_uris": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"timeout_sec": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      600,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
 			},
 
 			"cluster_config": {
@@ -137,21 +167,33 @@ func resourceDataprocCluster() *schema.Resource {
 										},
 									},
 
+									// A self_link keeps the subnetwork's project, so
+									// clusters can use a Shared VPC host project's
+									// subnetworks.
 									"subnetwork": {
-										Type:          schema.TypeString,
-										Optional:      true,
-										ForceNew:      true,
-										ConflictsWith: []string{"cluster_config.0.gce_cluster_config.0.network"},
-										StateFunc: func(s interface{}) string {
-											return extractLastResourceFromUri(s.(string))
-										},
+										Type:             schema.TypeString,
+										Optional:         true,
+										ForceNew:         true,
+										ConflictsWith:    []string{"cluster_config.0.gce_cluster_config.0.network"},
+										ValidateFunc:     validateSubnetworkNameOrLink,
+										DiffSuppressFunc: compareSelfLinkOrResourceName,
 									},
 
+									// The Dataproc API can't change the tags of
+									// an existing cluster, see
+									// recreate_on_tag_change.
 									"tags": {
-										Type:     schema.TypeList,
+										Type:             schema.TypeList,
+										Optional:         true,
+										ForceNew:         true,
+										Elem:             &schema.Schema{Type: schema.TypeString},
+										DiffSuppressFunc: dataprocClusterTagsDiffSuppress,
+									},
+
+									"recreate_on_tag_change": {
+										Type:     schema.TypeBool,
 										Optional: true,
-										ForceNew: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
+										Default:  true,
 									},
 
 									"service_account": {
@@ -160,6 +202,15 @@ func resourceDataprocCluster() *schema.Resource {
 										ForceNew: true,
 									},
 
+									// Only used when creating the cluster, to check the
+									// network's firewall lets the nodes talk to each other,
+									// so changing it doesn't recreate the cluster.
+									"required_firewall_tags": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
 									"service_account_scopes": {
 										Type:     schema.TypeSet,
 										Optional: true,
@@ -257,6 +308,73 @@ func resourceDataprocCluster() *schema.Resource {
 										Computed: true,
 									},
 
+									// Typed settings which expand into the set of Hadoop
+									// and Spark properties needed to protect traffic
+									// between cluster nodes.
+									"wire_encryption": {
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"rpc_protection": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													ForceNew:     true,
+													ValidateFunc: validation.StringInSlice([]string{"authentication", "integrity", "privacy"}, false),
+												},
+
+												"encrypt_data_transfer": {
+													Type:     schema.TypeBool,
+													Optional: true,
+													ForceNew: true,
+												},
+
+												"spark_authenticate": {
+													Type:     schema.TypeBool,
+													Optional: true,
+													ForceNew: true,
+												},
+
+												"encrypted_shuffle": {
+													Type:     schema.TypeBool,
+													Optional: true,
+													ForceNew: true,
+												},
+											},
+										},
+									},
+
+									// Convenience lists which are installed on all nodes
+									// using the official conda initialization actions.
+									"python_packages": {
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									"conda_packages": {
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									// Keys listed here are refreshed from the API into
+									// override_properties on read, so drift on the
+									// properties users explicitly care about shows up
+									// in the plan. override_properties is ForceNew, so
+									// that plan recreates the cluster. All other
+									// override_properties keep their configured values.
+									"properties_filter": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+
 									// We have two versions of the properties field here because by default
 									// dataproc will set a number of default properties for you out of the
 									// box. If you want to override one or more, if we only had one field,
@@ -354,6 +472,64 @@ func instanceConfigSchema() *schema.Schema {
 	}
 }
 
+// lockDataprocClusterOperations waits for the provider's
+// dataproc_cluster_operation_concurrency to allow another cluster operation
+// in the region, and returns the function to call once it's done.
+func lockDataprocClusterOperations(config *Config, project, region string) func() {
+	if config.dataprocClusterOperations == nil {
+		return func() {}
+	}
+
+	key := fmt.Sprintf("dataproc/%s/%s", project, region)
+	config.dataprocClusterOperations.Acquire(key)
+	return func() {
+		config.dataprocClusterOperations.Release(key)
+	}
+}
+
+const dataprocClusterNameMaxLength = 55
+
+func validateDataprocClusterName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if len(value) > dataprocClusterNameMaxLength {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be longer than %d characters", k, dataprocClusterNameMaxLength))
+	}
+	if !regexp.MustCompile("^[a-z0-9-]+$").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q can only contain lowercase letters, numbers and hyphens", k))
+	}
+	if !regexp.MustCompile("^[a-z]").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must start with a letter", k))
+	}
+	if !regexp.MustCompile("[a-z0-9]$").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must end with a number or a letter", k))
+	}
+	return
+}
+
+// checkDataprocClusterZone checks that a zone is given for clusters in the
+// global region, and that it lies in region otherwise. The vendored
+// helper/schema has no CustomizeDiff, so this runs at the start of Create,
+// before any API call, rather than at plan time.
+func checkDataprocClusterZone(region, zoneUri string) error {
+	if zoneUri == "" {
+		if region == "global" {
+			return errors.New("zone is mandatory when region is set to 'global'")
+		}
+		return nil
+	}
+
+	zone := extractLastResourceFromUri(zoneUri)
+	if region != "global" && getRegionFromZone(zone) != region {
+		return fmt.Errorf("zone %q is not in region %q", zone, region)
+	}
+	return nil
+}
+
 func resourceDataprocClusterCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -373,15 +549,40 @@ func resourceDataprocClusterCreate(d *schema.ResourceData, meta interface{}) err
 		cluster.Labels = expandLabels(d)
 	}
 
+	if err := checkRequiredLabels(config, d.Get("labels").(map[string]interface{})); err != nil {
+		return fmt.Errorf("Error creating Dataproc cluster %s: %s", cluster.ClusterName, err)
+	}
+
 	// Checking here caters for the case where the user does not specify cluster_config
 	// at all, as well where it is simply missing from the gce_cluster_config
-	if region == "global" && cluster.Config.GceClusterConfig.ZoneUri == "" {
-		return errors.New("zone is mandatory when region is set to 'global'")
+	if err := checkDataprocClusterZone(region, cluster.Config.GceClusterConfig.ZoneUri); err != nil {
+		return err
 	}
 
-	// Create the cluster
-	op, err := config.clientDataproc.Projects.Regions.Clusters.Create(
-		project, region, cluster).Do()
+	if v, ok := d.GetOk("cluster_config.0.gce_cluster_config.0.required_firewall_tags"); ok {
+		if err := checkDataprocFirewallRules(config, project, region, cluster.Config.GceClusterConfig, convertStringArr(v.([]interface{}))); err != nil {
+			return err
+		}
+	}
+
+	defer lockDataprocClusterOperations(config, project, region)()
+
+	// Create the cluster. A service account created in the same apply may not
+	// have propagated through IAM yet, so retry for a little while if Dataproc
+	// claims it doesn't exist.
+	var op *dataproc.Operation
+	err = resource.Retry(dataprocServiceAccountPropagationTimeout, func() *resource.RetryError {
+		op, err = config.clientDataproc.Projects.Regions.Clusters.Create(
+			project, region, cluster).Do()
+		if err != nil {
+			if isDataprocServiceAccountNotFoundError(err) {
+				log.Printf("[DEBUG] Service account for Dataproc cluster %s not found yet, retrying: %s", cluster.ClusterName, err)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -392,16 +593,341 @@ func resourceDataprocClusterCreate(d *schema.ResourceData, meta interface{}) err
 	timeoutInMinutes := int(d.Timeout(schema.TimeoutCreate).Minutes())
 	waitErr := dataprocClusterOperationWait(config, op, "creating Dataproc cluster", timeoutInMinutes, 3)
 	if waitErr != nil {
+		// If we gave up waiting while the cluster is still being created, keep
+		// it in state so that it's tainted and cleaned up by the next apply
+		// rather than orphaned.
+		if c, err := config.clientDataproc.Projects.Regions.Clusters.Get(project, region, cluster.ClusterName).Do(); err == nil && c.Status != nil && c.Status.State == "CREATING" {
+			return fmt.Errorf("%s. The cluster is still being created by operation %s, it has been kept in state and marked tainted", waitErr, op.Name)
+		}
+
 		// The resource didn't actually create
 		d.SetId("")
 		return waitErr
 	}
 
 	log.Printf("[INFO] Dataproc cluster %s has been created", cluster.ClusterName)
-	return resourceDataprocClusterRead(d, meta)
+
+	if v, ok := d.GetOk("verification_job"); ok {
+		// The cluster stays in state, so a failed verification taints it
+		// and the next apply recreates it.
+		if err := runDataprocVerificationJob(config, project, region, cluster.ClusterName, v.([]interface{})[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	return readDataprocCluster(d, meta)
 
 }
 
+const dataprocVerificationJobJar = "file:///usr/lib/spark/examples/jars/spark-examples.jar"
+
+// runDataprocVerificationJob submits a small Spark job to a newly created
+// cluster and waits for it to succeed, so that broken initialization actions
+// or missing permissions show up at provisioning time.
+func runDataprocVerificationJob(config *Config, project, region, clusterName string, cfg map[string]interface{}) error {
+	sparkJob := &dataproc.SparkJob{
+		MainClass:   cfg["main_class"].(string),
+		JarFileUris: convertStringArr(cfg["jar_file_uris"].([]interface{})),
+		Args:        convertStringArr(cfg["args"].([]interface{})),
+	}
+	if len(sparkJob.JarFileUris) == 0 {
+		sparkJob.JarFileUris = []string{dataprocVerificationJobJar}
+	}
+
+	job := &dataproc.Job{
+		Placement: &dataproc.JobPlacement{
+			ClusterName: clusterName,
+		},
+		Reference: &dataproc.JobReference{
+			JobId: fmt.Sprintf("%s-verification-%d", clusterName, time.Now().Unix()),
+		},
+		SparkJob: sparkJob,
+		Labels: map[string]string{
+			"goog-terraform-verification": "true",
+		},
+	}
+
+	log.Printf("[INFO] Submitting verification job %s to Dataproc cluster %s", job.Reference.JobId, clusterName)
+	job, err := config.clientDataproc.Projects.Regions.Jobs.Submit(project, region, &dataproc.SubmitJobRequest{Job: job}).Do()
+	if err != nil {
+		return fmt.Errorf("Error submitting verification job to Dataproc cluster %s: %s", clusterName, err)
+	}
+	jobId := job.Reference.JobId
+
+	state := &resource.StateChangeConf{
+		Pending: []string{"STATE_UNSPECIFIED", "PENDING", "SETUP_DONE", "RUNNING", "CANCEL_PENDING", "CANCEL_STARTED", "ATTEMPT_FAILURE"},
+		Target:  []string{"DONE", "ERROR", "CANCELLED"},
+		Refresh: func() (interface{}, string, error) {
+			job, err := config.clientDataproc.Projects.Regions.Jobs.Get(project, region, jobId).Do()
+			if err != nil {
+				return nil, "", err
+			}
+			if job.Status == nil {
+				return job, "STATE_UNSPECIFIED", nil
+			}
+			return job, job.Status.State, nil
+		},
+		Timeout:    time.Duration(cfg["timeout_sec"].(int)) * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	jobRaw, err := state.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for verification job %s on Dataproc cluster %s: %s", jobId, clusterName, err)
+	}
+
+	job = jobRaw.(*dataproc.Job)
+	if job.Status.State != "DONE" {
+		return fmt.Errorf("Verification job %s on Dataproc cluster %s finished in state %s: %s. The driver output is in %s",
+			jobId, clusterName, job.Status.State, job.Status.Details, job.DriverOutputResourceUri)
+	}
+
+	log.Printf("[INFO] Verification job %s on Dataproc cluster %s succeeded", jobId, clusterName)
+	return nil
+}
+
+// checkDataprocFirewallRules makes sure that, for each of tags, the network
+// the cluster is going into has firewall rules allowing all TCP and UDP
+// traffic into instances with that tag from the rest of the cluster. Without
+// them the nodes can't talk to each other and cluster creation hangs until it
+// times out.
+func checkDataprocFirewallRules(config *Config, project, region string, gcc *dataproc.GceClusterConfig, tags []string) error {
+	for _, tag := range tags {
+		if !stringInSlice(gcc.Tags, tag) {
+			return fmt.Errorf("required_firewall_tags contains %q, which isn't in the cluster's tags, so no firewall "+
+				"rule for it would apply to the nodes. Add it to tags or remove it from required_firewall_tags.", tag)
+		}
+	}
+
+	subnetworkRegion := region
+	if gcc.ZoneUri != "" {
+		subnetworkRegion = getRegionFromZone(extractLastResourceFromUri(gcc.ZoneUri))
+	}
+
+	network := "default"
+	// Firewall rules belong to the network's project, which is the host
+	// project for a Shared VPC subnetwork.
+	networkProject := project
+	// The range the nodes get their addresses from. It stays empty if it
+	// can't be found, and then only rules allowing traffic from anywhere or
+	// from the tag itself count.
+	var cidr string
+	if gcc.SubnetworkUri != "" {
+		subnetworkProject, subnetworkRegion, subnetworkName := parseDataprocSubnetwork(gcc.SubnetworkUri, project, subnetworkRegion)
+		subnetwork, err := config.clientCompute.Subnetworks.Get(subnetworkProject, subnetworkRegion, subnetworkName).Do()
+		if err != nil {
+			return fmt.Errorf("Error reading subnetwork %q to check its firewall rules: %s", gcc.SubnetworkUri, err)
+		}
+		network = extractLastResourceFromUri(subnetwork.Network)
+		if p := getProjectFromNetworkLink(subnetwork.Network); p != "" {
+			networkProject = p
+		}
+		cidr = subnetwork.IpCidrRange
+	} else {
+		if gcc.NetworkUri != "" {
+			network = extractLastResourceFromUri(gcc.NetworkUri)
+		}
+		// Auto mode networks have a subnetwork named after the network in
+		// every region. Custom mode networks need subnetwork set, which
+		// Dataproc itself will complain about.
+		if subnetworkRegion != "" && subnetworkRegion != "global" {
+			subnetwork, err := config.clientCompute.Subnetworks.Get(networkProject, subnetworkRegion, network).Do()
+			if err != nil {
+				log.Printf("[WARN] Unable to read the %s subnetwork of network %q to check its firewall rules: %s", subnetworkRegion, network, err)
+			} else {
+				cidr = subnetwork.IpCidrRange
+			}
+		}
+	}
+
+	rules, err := listDataprocFirewallRules(config, networkProject, network)
+	if err != nil {
+		return fmt.Errorf("Error listing firewall rules of network %q: %s", network, err)
+	}
+
+	for _, tag := range tags {
+		for _, protocol := range []string{"tcp", "udp"} {
+			if !dataprocFirewallRulesAllow(rules, tag, protocol, cidr) {
+				return fmt.Errorf("No firewall rule in network %q allows all %s traffic to instances tagged %q. "+
+					"Dataproc nodes need to reach each other on all TCP and UDP ports, otherwise cluster creation "+
+					"hangs until it times out. Add a google_compute_firewall allowing this traffic with source_tags "+
+					"and target_tags set to [%q], or remove the tag from required_firewall_tags.", network, protocol, tag, tag)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dataprocFirewallRule is a firewall rule as returned by the Compute API. The
+// vendored compute clients predate disabled firewall rules, so rules are
+// listed with the JSON API directly.
+type dataprocFirewallRule struct {
+	compute.Firewall
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+type dataprocFirewallRuleList struct {
+	Items         []*dataprocFirewallRule `json:"items,omitempty"`
+	NextPageToken string                  `json:"nextPageToken,omitempty"`
+}
+
+// listDataprocFirewallRules returns the firewall rules of network in project.
+func listDataprocFirewallRules(config *Config, project, network string) ([]*dataprocFirewallRule, error) {
+	var rules []*dataprocFirewallRule
+	params := url.Values{}
+	for {
+		u := config.clientCompute.BasePath + project + "/global/firewalls?" + params.Encode()
+		res := &dataprocFirewallRuleList{}
+		if err := sendJsonRequest(config.client, config.clientCompute.UserAgent, "GET", u, nil, res); err != nil {
+			return nil, err
+		}
+		for _, rule := range res.Items {
+			if extractLastResourceFromUri(rule.Network) == network {
+				rules = append(rules, rule)
+			}
+		}
+		if res.NextPageToken == "" {
+			return rules, nil
+		}
+		params.Set("pageToken", res.NextPageToken)
+	}
+}
+
+// parseDataprocSubnetwork returns the project, region and name of the
+// subnetwork a cluster uses. A subnetwork given by name is in project and
+// region, a self_link or relative link can be in any project.
+func parseDataprocSubnetwork(subnetwork, project, region string) (string, string, string) {
+	r := regexp.MustCompile(SubnetworkLinkRegex)
+	if parts := r.FindStringSubmatch(subnetwork); parts != nil {
+		return parts[1], parts[2], parts[3]
+	}
+	return project, region, extractLastResourceFromUri(subnetwork)
+}
+
+// dataprocFirewallRulesAllow reports whether rules allow traffic on all ports
+// of protocol into instances tagged with tag from the other instances of the
+// cluster, which get their addresses from cidr. Only enabled ingress rules
+// count, and an allowing rule is overridden by a deny rule for the same
+// traffic with the same or a higher priority.
+func dataprocFirewallRulesAllow(rules []*dataprocFirewallRule, tag, protocol, cidr string) bool {
+	for _, rule := range rules {
+		if !dataprocFirewallRuleApplies(rule, tag) {
+			continue
+		}
+		if !stringInSlice(rule.SourceTags, tag) && !dataprocFirewallRangesCover(rule.SourceRanges, cidr) {
+			continue
+		}
+		if !dataprocFirewallAllowsAllPorts(rule.Allowed, protocol) {
+			continue
+		}
+		if !dataprocFirewallRuleDenied(rules, rule, tag, protocol, cidr) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataprocFirewallRuleApplies reports whether rule is an enabled ingress rule
+// applying to instances tagged with tag.
+func dataprocFirewallRuleApplies(rule *dataprocFirewallRule, tag string) bool {
+	if rule.Disabled {
+		return false
+	}
+	if rule.Direction != "" && rule.Direction != "INGRESS" {
+		return false
+	}
+	return len(rule.TargetTags) == 0 || stringInSlice(rule.TargetTags, tag)
+}
+
+func dataprocFirewallAllowsAllPorts(allowed []*compute.FirewallAllowed, protocol string) bool {
+	for _, a := range allowed {
+		if a.IPProtocol != protocol && a.IPProtocol != "all" {
+			continue
+		}
+		if len(a.Ports) == 0 || stringInSlice(a.Ports, "0-65535") || stringInSlice(a.Ports, "1-65535") {
+			return true
+		}
+	}
+	return false
+}
+
+// dataprocFirewallRuleDenied reports whether any deny rule in rules takes
+// precedence over allow for some of the cluster's traffic. A deny rule wins
+// over an allow rule of the same priority.
+func dataprocFirewallRuleDenied(rules []*dataprocFirewallRule, allow *dataprocFirewallRule, tag, protocol, cidr string) bool {
+	for _, rule := range rules {
+		if len(rule.Denied) == 0 || rule.Priority > allow.Priority {
+			continue
+		}
+		if !dataprocFirewallRuleApplies(rule, tag) {
+			continue
+		}
+		if !stringInSlice(rule.SourceTags, tag) && !dataprocFirewallRangesOverlap(rule.SourceRanges, cidr) {
+			continue
+		}
+		for _, d := range rule.Denied {
+			if d.IPProtocol == protocol || d.IPProtocol == "all" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dataprocFirewallRangesCover reports whether any of ranges contains all of
+// cidr. Only a range covering everything counts if cidr isn't known.
+func dataprocFirewallRangesCover(ranges []string, cidr string) bool {
+	_, inner, err := net.ParseCIDR(cidr)
+	for _, r := range ranges {
+		_, outer, parseErr := net.ParseCIDR(r)
+		if parseErr != nil {
+			continue
+		}
+		outerOnes, _ := outer.Mask.Size()
+		if outerOnes == 0 {
+			return true
+		}
+		if err != nil {
+			continue
+		}
+		innerOnes, _ := inner.Mask.Size()
+		if outer.Contains(inner.IP) && outerOnes <= innerOnes {
+			return true
+		}
+	}
+	return false
+}
+
+// dataprocFirewallRangesOverlap reports whether any of ranges shares
+// addresses with cidr. Any range is assumed to overlap if cidr isn't known.
+func dataprocFirewallRangesOverlap(ranges []string, cidr string) bool {
+	_, inner, err := net.ParseCIDR(cidr)
+	for _, r := range ranges {
+		_, outer, parseErr := net.ParseCIDR(r)
+		if parseErr != nil {
+			continue
+		}
+		if err != nil || outer.Contains(inner.IP) || inner.Contains(outer.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+const dataprocServiceAccountPropagationTimeout = 2 * time.Minute
+
+// isDataprocServiceAccountNotFoundError reports whether err is Dataproc
+// rejecting a cluster because its service account doesn't exist, which
+// happens for a while after the account is created.
+func isDataprocServiceAccountNotFoundError(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	if !ok || gerr.Code != http.StatusBadRequest {
+		return false
+	}
+	msg := strings.ToLower(gerr.Message)
+	return strings.Contains(msg, "service account") && strings.Contains(msg, "does not exist")
+}
+
 func expandClusterConfig(d *schema.ResourceData) *dataproc.ClusterConfig {
 	conf := &dataproc.ClusterConfig{
 		// SDK requires GceClusterConfig to be specified,
@@ -432,6 +958,10 @@ func expandClusterConfig(d *schema.ResourceData) *dataproc.ClusterConfig {
 		conf.InitializationActions = expandInitializationActions(v)
 	}
 
+	if cfg, ok := configOptions(d, "cluster_config.0.software_config"); ok {
+		expandPackageInstallConfig(cfg, conf)
+	}
+
 	if cfg, ok := configOptions(d, "cluster_config.0.master_config"); ok {
 		log.Println("[INFO] got master_config")
 		conf.MasterConfig = expandInstanceGroupConfig(cfg)
@@ -462,7 +992,7 @@ func expandGceClusterConfig(cfg map[string]interface{}) *dataproc.GceClusterConf
 		conf.NetworkUri = extractLastResourceFromUri(v.(string))
 	}
 	if v, ok := cfg["subnetwork"]; ok {
-		conf.SubnetworkUri = extractLastResourceFromUri(v.(string))
+		conf.SubnetworkUri = v.(string)
 	}
 	if v, ok := cfg["tags"]; ok {
 		conf.Tags = convertStringArr(v.([]interface{}))
@@ -493,9 +1023,96 @@ func expandSoftwareConfig(cfg map[string]interface{}) *dataproc.SoftwareConfig {
 	if v, ok := cfg["image_version"]; ok {
 		conf.ImageVersion = v.(string)
 	}
+	if v, ok := cfg["wire_encryption"]; ok {
+		// Explicit override_properties take precedence over the
+		// properties generated from wire_encryption.
+		for k, val := range expandWireEncryptionProperties(v.([]interface{})) {
+			if conf.Properties == nil {
+				conf.Properties = make(map[string]string)
+			}
+			if _, ok := conf.Properties[k]; !ok {
+				conf.Properties[k] = val
+			}
+		}
+	}
 	return conf
 }
 
+// expandWireEncryptionProperties returns the cluster properties needed to
+// enable the settings in a software_config.wire_encryption block.
+func expandWireEncryptionProperties(v []interface{}) map[string]string {
+	props := map[string]string{}
+	if len(v) == 0 || v[0] == nil {
+		return props
+	}
+	cfg := v[0].(map[string]interface{})
+
+	if rpc, ok := cfg["rpc_protection"]; ok && rpc.(string) != "" {
+		props["core:hadoop.rpc.protection"] = rpc.(string)
+	}
+	if enabled, ok := cfg["encrypt_data_transfer"]; ok && enabled.(bool) {
+		props["hdfs:dfs.encrypt.data.transfer"] = "true"
+	}
+	if enabled, ok := cfg["spark_authenticate"]; ok && enabled.(bool) {
+		props["spark:spark.authenticate"] = "true"
+	}
+	if enabled, ok := cfg["encrypted_shuffle"]; ok && enabled.(bool) {
+		// Spark only encrypts network traffic between authenticated peers
+		props["spark:spark.authenticate"] = "true"
+		props["spark:spark.network.crypto.enabled"] = "true"
+		props["spark:spark.io.encryption.enabled"] = "true"
+	}
+
+	return props
+}
+
+// The official initialization actions which install the packages listed in
+// the CONDA_PACKAGES and PIP_PACKAGES instance metadata, see
+// https://github.com/GoogleCloudPlatform/dataproc-initialization-actions/tree/master/conda
+var dataprocPackageInstallActions = []string{
+	"gs://dataproc-initialization-actions/conda/bootstrap-conda.sh",
+	"gs://dataproc-initialization-actions/conda/install-conda-env.sh",
+}
+
+// expandPackageInstallConfig wires up software_config.python_packages and
+// conda_packages as instance metadata, and runs the package install actions
+// ahead of any user supplied initialization actions.
+func expandPackageInstallConfig(cfg map[string]interface{}, conf *dataproc.ClusterConfig) {
+	metadata := map[string]string{}
+	if v, ok := cfg["python_packages"]; ok && len(v.([]interface{})) > 0 {
+		metadata["PIP_PACKAGES"] = strings.Join(convertStringArr(v.([]interface{})), " ")
+	}
+	if v, ok := cfg["conda_packages"]; ok && len(v.([]interface{})) > 0 {
+		metadata["CONDA_PACKAGES"] = strings.Join(convertStringArr(v.([]interface{})), " ")
+	}
+	if len(metadata) == 0 {
+		return
+	}
+
+	if conf.GceClusterConfig.Metadata == nil {
+		conf.GceClusterConfig.Metadata = map[string]string{}
+	}
+	for k, v := range metadata {
+		conf.GceClusterConfig.Metadata[k] = v
+	}
+
+	actions := []*dataproc.NodeInitializationAction{}
+	for _, script := range dataprocPackageInstallActions {
+		actions = append(actions, &dataproc.NodeInitializationAction{
+			ExecutableFile: script,
+		})
+	}
+	conf.InitializationActions = append(actions, conf.InitializationActions...)
+}
+
+// hasPackageInstallConfig returns whether the cluster was created with
+// python_packages or conda_packages.
+func hasPackageInstallConfig(metadata map[string]string) bool {
+	_, pip := metadata["PIP_PACKAGES"]
+	_, conda := metadata["CONDA_PACKAGES"]
+	return pip || conda
+}
+
 func expandInitializationActions(v interface{}) []*dataproc.NodeInitializationAction {
 	actionList := v.([]interface{})
 
@@ -573,44 +1190,23 @@ func resourceDataprocClusterUpdate(d *schema.ResourceData, meta interface{}) err
 	clusterName := d.Get("name").(string)
 	timeoutInMinutes := int(d.Timeout(schema.TimeoutUpdate).Minutes())
 
-	cluster := &dataproc.Cluster{
-		ClusterName: clusterName,
-		ProjectId:   project,
-		Config:      &dataproc.ClusterConfig{},
-	}
-
-	updMask := []string{}
-
 	if d.HasChange("labels") {
-		v := d.Get("labels")
-		m := make(map[string]string)
-		for k, val := range v.(map[string]interface{}) {
-			m[k] = val.(string)
+		if err := checkRequiredLabels(config, d.Get("labels").(map[string]interface{})); err != nil {
+			return fmt.Errorf("Error updating Dataproc cluster %s: %s", clusterName, err)
 		}
-		cluster.Labels = m
-
-		updMask = append(updMask, "labels")
 	}
 
-	if d.HasChange("cluster_config.0.worker_config.0.num_instances") {
-		desiredNumWorks := d.Get("cluster_config.0.worker_config.0.num_instances").(int)
-		cluster.Config.WorkerConfig = &dataproc.InstanceGroupConfig{
-			NumInstances: int64(desiredNumWorks),
-		}
-
-		updMask = append(updMask, "config.worker_config.num_instances")
+	cluster := &dataproc.Cluster{
+		ClusterName: clusterName,
+		ProjectId:   project,
+		Config:      &dataproc.ClusterConfig{},
 	}
 
-	if d.HasChange("cluster_config.0.preemptible_worker_config.0.num_instances") {
-		desiredNumWorks := d.Get("cluster_config.0.preemptible_worker_config.0.num_instances").(int)
-		cluster.Config.SecondaryWorkerConfig = &dataproc.InstanceGroupConfig{
-			NumInstances: int64(desiredNumWorks),
-		}
-
-		updMask = append(updMask, "config.secondary_worker_config.num_instances")
-	}
+	updMask := expandDataprocClusterUpdate(d, cluster)
 
 	if len(updMask) > 0 {
+		defer lockDataprocClusterOperations(config, project, region)()
+
 		patch := config.clientDataproc.Projects.Regions.Clusters.Patch(
 			project, region, clusterName, cluster)
 		op, err := patch.UpdateMask(strings.Join(updMask, ",")).Do()
@@ -627,10 +1223,96 @@ func resourceDataprocClusterUpdate(d *schema.ResourceData, meta interface{}) err
 		log.Printf("[INFO] Dataproc cluster %s has been updated ", d.Id())
 	}
 
-	return resourceDataprocClusterRead(d, meta)
+	return readDataprocCluster(d, meta)
+}
+
+// dataprocClusterUpdatableField describes an attribute of a Dataproc cluster
+// which can be changed in place, the update mask path the Dataproc API expects
+// for it, and how its new value is set on the cluster sent in the Patch request.
+type dataprocClusterUpdatableField struct {
+	Item       string
+	UpdateMask string
+	Expand     func(v interface{}, cluster *dataproc.Cluster)
+}
+
+var dataprocClusterUpdatableFields = []dataprocClusterUpdatableField{
+	{
+		Item:       "labels",
+		UpdateMask: "labels",
+		Expand: func(v interface{}, cluster *dataproc.Cluster) {
+			m := make(map[string]string)
+			for k, val := range v.(map[string]interface{}) {
+				m[k] = val.(string)
+			}
+			cluster.Labels = m
+		},
+	},
+	{
+		Item:       "cluster_config.0.worker_config.0.num_instances",
+		UpdateMask: "config.worker_config.num_instances",
+		Expand: func(v interface{}, cluster *dataproc.Cluster) {
+			if cluster.Config.WorkerConfig == nil {
+				cluster.Config.WorkerConfig = &dataproc.InstanceGroupConfig{}
+			}
+			cluster.Config.WorkerConfig.NumInstances = int64(v.(int))
+		},
+	},
+	{
+		Item:       "cluster_config.0.preemptible_worker_config.0.num_instances",
+		UpdateMask: "config.secondary_worker_config.num_instances",
+		Expand: func(v interface{}, cluster *dataproc.Cluster) {
+			if cluster.Config.SecondaryWorkerConfig == nil {
+				cluster.Config.SecondaryWorkerConfig = &dataproc.InstanceGroupConfig{}
+			}
+			cluster.Config.SecondaryWorkerConfig.NumInstances = int64(v.(int))
+		},
+	},
+}
+
+// expandDataprocClusterUpdate sets every changed updatable field on cluster and
+// returns the matching update mask paths, so that all changes can be sent
+// together in a single Patch request.
+func expandDataprocClusterUpdate(d TerraformResourceData, cluster *dataproc.Cluster) []string {
+	if cluster.Config == nil {
+		cluster.Config = &dataproc.ClusterConfig{}
+	}
+
+	updMask := []string{}
+	for _, field := range dataprocClusterUpdatableFields {
+		if !d.HasChange(field.Item) {
+			continue
+		}
+
+		field.Expand(d.Get(field.Item), cluster)
+		updMask = append(updMask, field.UpdateMask)
+	}
+
+	return updMask
+}
+
+// dataprocClusterUserLabels returns labels without the goog-dataproc-*
+// labels Dataproc adds to every cluster, e.g. goog-dataproc-cluster-name.
+func dataprocClusterUserLabels(labels map[string]string) map[string]string {
+	user := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if !strings.HasPrefix(k, "goog-dataproc-") {
+			user[k] = v
+		}
+	}
+	return user
 }
 
 func resourceDataprocClusterRead(d *schema.ResourceData, meta interface{}) error {
+	if skipRefresh(d, meta.(*Config)) {
+		return nil
+	}
+	return readDataprocCluster(d, meta)
+}
+
+// readDataprocCluster reads the cluster into d. Create and Update call it
+// rather than resourceDataprocClusterRead, so the state written after an
+// apply is always up to date, whatever the terraform-refresh label says.
+func readDataprocCluster(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
 	project, err := getProject(d, config)
@@ -649,7 +1331,8 @@ func resourceDataprocClusterRead(d *schema.ResourceData, meta interface{}) error
 
 	d.Set("name", cluster.ClusterName)
 	d.Set("region", region)
-	d.Set("labels", cluster.Labels)
+	d.Set("labels", dataprocClusterUserLabels(cluster.Labels))
+	d.Set("effective_labels", cluster.Labels)
 
 	cfg, err := flattenClusterConfig(d, cluster.Config)
 	if err != nil {
@@ -668,32 +1351,85 @@ func flattenClusterConfig(d *schema.ResourceData, cfg *dataproc.ClusterConfig) (
 
 		"bucket":                    cfg.ConfigBucket,
 		"gce_cluster_config":        flattenGceClusterConfig(d, cfg.GceClusterConfig),
-		"software_config":           flattenSoftwareConfig(d, cfg.SoftwareConfig),
+		"software_config":           flattenSoftwareConfig(d, cfg.SoftwareConfig, cfg.GceClusterConfig.Metadata),
 		"master_config":             flattenInstanceGroupConfig(d, cfg.MasterConfig),
 		"worker_config":             flattenInstanceGroupConfig(d, cfg.WorkerConfig),
 		"preemptible_worker_config": flattenPreemptibleInstanceGroupConfig(d, cfg.SecondaryWorkerConfig),
 	}
 
-	if len(cfg.InitializationActions) > 0 {
-		val, err := flattenInitializationActions(cfg.InitializationActions)
+	initActions := cfg.InitializationActions
+	if hasPackageInstallConfig(cfg.GceClusterConfig.Metadata) {
+		initActions = filterPackageInstallActions(initActions)
+	}
+	if len(initActions) > 0 {
+		val, err := flattenInitializationActions(initActions)
 		if err != nil {
 			return nil, err
 		}
-		data["intialization_action"] = val
+		data["initialization_action"] = val
 	}
 	return []map[string]interface{}{data}, nil
 }
 
-func flattenSoftwareConfig(d *schema.ResourceData, sc *dataproc.SoftwareConfig) []map[string]interface{} {
+func flattenSoftwareConfig(d *schema.ResourceData, sc *dataproc.SoftwareConfig, metadata map[string]string) []map[string]interface{} {
+	overrides := d.Get("cluster_config.0.software_config.0.override_properties").(map[string]interface{})
+	filter := d.Get("cluster_config.0.software_config.0.properties_filter").(*schema.Set)
+
 	data := map[string]interface{}{
 		"image_version":       sc.ImageVersion,
 		"properties":          sc.Properties,
-		"override_properties": d.Get("cluster_config.0.software_config.0.override_properties").(map[string]interface{}),
+		"override_properties": flattenOverrideProperties(overrides, sc.Properties, filter),
+		"properties_filter":   filter,
+		"wire_encryption":     d.Get("cluster_config.0.software_config.0.wire_encryption"),
+	}
+
+	if v, ok := metadata["PIP_PACKAGES"]; ok {
+		data["python_packages"] = strings.Fields(v)
+	}
+	if v, ok := metadata["CONDA_PACKAGES"]; ok {
+		data["conda_packages"] = strings.Fields(v)
 	}
 
 	return []map[string]interface{}{data}
 }
 
+// flattenOverrideProperties returns the configured override properties, with
+// the value of any key present in filter replaced by the value GCP reports for
+// it. Keys which are not overridden are left alone so that the many
+// server-populated properties never participate in diffs.
+func flattenOverrideProperties(overrides map[string]interface{}, props map[string]string, filter *schema.Set) map[string]interface{} {
+	result := make(map[string]interface{}, len(overrides))
+	for k, v := range overrides {
+		result[k] = v
+		if filter == nil || !filter.Contains(k) {
+			continue
+		}
+		if actual, ok := props[k]; ok {
+			result[k] = actual
+		}
+	}
+	return result
+}
+
+// filterPackageInstallActions removes the initialization actions added for
+// python_packages and conda_packages, so they don't show up as a diff against
+// the user's initialization_action blocks.
+func filterPackageInstallActions(nia []*dataproc.NodeInitializationAction) []*dataproc.NodeInitializationAction {
+	actions := []*dataproc.NodeInitializationAction{}
+	for _, v := range nia {
+		isPackageAction := false
+		for _, script := range dataprocPackageInstallActions {
+			if v.ExecutableFile == script {
+				isPackageAction = true
+			}
+		}
+		if !isPackageAction {
+			actions = append(actions, v)
+		}
+	}
+	return actions
+}
+
 func flattenInitializationActions(nia []*dataproc.NodeInitializationAction) ([]map[string]interface{}, error) {
 
 	actions := []map[string]interface{}{}
@@ -715,6 +1451,17 @@ func flattenInitializationActions(nia []*dataproc.NodeInitializationAction) ([]m
 
 }
 
+// dataprocClusterTagsDiffSuppress hides changes to gce_cluster_config.tags
+// when recreate_on_tag_change is false, so that they don't destroy the
+// cluster. The new tags are then only applied if the cluster is recreated
+// for some other reason.
+func dataprocClusterTagsDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	return !d.Get("cluster_config.0.gce_cluster_config.0.recreate_on_tag_change").(bool)
+}
+
 func flattenGceClusterConfig(d *schema.ResourceData, gcc *dataproc.GceClusterConfig) []map[string]interface{} {
 
 	gceConfig := map[string]interface{}{
@@ -723,11 +1470,18 @@ func flattenGceClusterConfig(d *schema.ResourceData, gcc *dataproc.GceClusterCon
 		"zone":            extractLastResourceFromUri(gcc.ZoneUri),
 	}
 
+	if v, ok := d.GetOk("cluster_config.0.gce_cluster_config.0.required_firewall_tags"); ok {
+		gceConfig["required_firewall_tags"] = v
+	}
+	gceConfig["recreate_on_tag_change"] = true
+	if v, ok := d.GetOkExists("cluster_config.0.gce_cluster_config.0.recreate_on_tag_change"); ok {
+		gceConfig["recreate_on_tag_change"] = v
+	}
 	if gcc.NetworkUri != "" {
 		gceConfig["network"] = extractLastResourceFromUri(gcc.NetworkUri)
 	}
 	if gcc.SubnetworkUri != "" {
-		gceConfig["subnetwork"] = extractLastResourceFromUri(gcc.SubnetworkUri)
+		gceConfig["subnetwork"] = gcc.SubnetworkUri
 	}
 	if len(gcc.ServiceAccountScopes) > 0 {
 		gceConfig["service_account_scopes"] = schema.NewSet(stringScopeHashcode, convertStringArrToInterface(gcc.ServiceAccountScopes))
@@ -799,6 +1553,8 @@ func resourceDataprocClusterDelete(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	defer lockDataprocClusterOperations(config, project, region)()
+
 	log.Printf("[DEBUG] Deleting Dataproc cluster %s", clusterName)
 	op, err := config.clientDataproc.Projects.Regions.Clusters.Delete(
 		project, region, clusterName).Do()
@@ -829,39 +1585,32 @@ func deleteAutogenBucketIfExists(d *schema.ResourceData, meta interface{}) error
 	bucket := d.Get("cluster_config.0.bucket").(string)
 
 	log.Printf("[DEBUG] Attempting to delete autogenerated bucket %s (for dataproc cluster)", bucket)
-	return emptyAndDeleteStorageBucket(config, bucket)
+	// Buckets created by Dataproc are never requester pays.
+	return emptyAndDeleteStorageBucket(config, bucket, "")
 }
 
-func emptyAndDeleteStorageBucket(config *Config, bucket string) error {
-	err := deleteStorageBucketContents(config, bucket)
+// emptyAndDeleteStorageBucket deletes bucket and everything in it. If set,
+// userProject is billed for the calls, as required for requester pays buckets.
+func emptyAndDeleteStorageBucket(config *Config, bucket, userProject string) error {
+	err := deleteStorageBucketContents(config, bucket, userProject)
 	if err != nil {
 		return err
 	}
 
-	err = deleteEmptyBucket(config, bucket)
+	err = deleteEmptyBucket(config, bucket, userProject)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func deleteEmptyBucket(config *Config, bucket string) error {
-	// remove empty bucket
-	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
-		err := config.clientStorage.Buckets.Delete(bucket).Do()
-		if err == nil {
-			return nil
-		}
-		gerr, ok := err.(*googleapi.Error)
-		if gerr.Code == http.StatusNotFound {
-			// Bucket may be gone already ignore
-			return nil
-		}
-		if ok && gerr.Code == http.StatusTooManyRequests {
-			return resource.RetryableError(gerr)
-		}
-		return resource.NonRetryableError(err)
-	})
+func deleteEmptyBucket(config *Config, bucket, userProject string) error {
+	// remove empty bucket, rate limiting is retried by the provider's retryTransport
+	err := config.clientStorage.Buckets.Delete(bucket).Do(storageUserProject(userProject)...)
+	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == http.StatusNotFound {
+		// Bucket may be gone already ignore
+		err = nil
+	}
 	if err != nil {
 		fmt.Printf("[ERROR] Attempting to delete autogenerated bucket (for dataproc cluster): Error deleting bucket %s: %v\n\n", bucket, err)
 		return err
@@ -872,37 +1621,60 @@ func deleteEmptyBucket(config *Config, bucket string) error {
 
 }
 
-func deleteStorageBucketContents(config *Config, bucket string) error {
+// deleteStorageBucketContents deletes every object in bucket, including the
+// noncurrent versions kept when object versioning is enabled.
+func deleteStorageBucketContents(config *Config, bucket, userProject string) error {
+	call := config.clientStorage.Objects.List(bucket).Versions(true).Fields("nextPageToken", "items(name,generation)")
+	if userProject != "" {
+		call = call.UserProject(userProject)
+	}
+
+	var deleted int
+	err := call.Pages(context.Background(), func(res *storage.Objects) error {
+		if len(res.Items) == 0 {
+			return nil
+		}
 
-	res, err := config.clientStorage.Objects.List(bucket).Do()
+		log.Printf("[DEBUG] Attempting to delete autogenerated bucket (for dataproc cluster): deleting %d objects from %s", len(res.Items), bucket)
+		if err := deleteStorageObjects(config, bucket, userProject, res.Items); err != nil {
+			return err
+		}
+		deleted += len(res.Items)
+		return nil
+	})
 	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == http.StatusNotFound {
 		// Bucket is already gone ...
 		return nil
 	}
 	if err != nil {
-		log.Fatalf("[DEBUG] Attempting to delete autogenerated bucket %s (for dataproc cluster). Error Objects.List failed: %v", bucket, err)
+		log.Printf("[DEBUG] Attempting to delete autogenerated bucket %s (for dataproc cluster). Error emptying bucket: %v", bucket, err)
 		return err
 	}
+	log.Printf("[DEBUG] Attempting to delete autogenerated bucket (for dataproc cluster): deleted %d objects from %s", deleted, bucket)
 
-	if len(res.Items) > 0 {
-		// purge the bucket...
-		log.Printf("[DEBUG] Attempting to delete autogenerated bucket (for dataproc cluster). \n\n")
+	return nil
+}
 
-		for _, object := range res.Items {
-			log.Printf("[DEBUG] Attempting to delete autogenerated bucket (for dataproc cluster). Found %s", object.Name)
+func resourceDataprocClusterImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
 
-			err := config.clientStorage.Objects.Delete(bucket, object.Name).Do()
-			if err != nil {
-				if gerr, ok := err.(*googleapi.Error); ok && gerr.Code != http.StatusNotFound {
-					log.Printf("[DEBUG] Attempting to delete autogenerated bucket (for dataproc cluster): Error trying to delete object: %s %s\n\n", object.Name, err)
-					return err
-				}
-			}
-			log.Printf("[DEBUG] Attempting to delete autogenerated bucket (for dataproc cluster): Object deleted: %s \n\n", object.Name)
-		}
+	var name string
+	switch len(parts) {
+	case 2:
+		d.Set("region", parts[0])
+		name = parts[1]
+	case 3:
+		d.Set("project", parts[0])
+		d.Set("region", parts[1])
+		name = parts[2]
+	default:
+		return nil, fmt.Errorf("Invalid dataproc cluster specifier. Expecting {region}/{name} or {project}/{region}/{name}")
 	}
 
-	return nil
+	d.Set("name", name)
+	d.SetId(name)
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func configOptions(d *schema.ResourceData, option string) (map[string]interface{}, bool) {
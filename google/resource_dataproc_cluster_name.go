@@ -0,0 +1,101 @@
+package google
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// resourceDataprocClusterName generates a random name valid for a
+// google_dataproc_cluster. It doesn't call any API.
+func resourceDataprocClusterName() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDataprocClusterNameCreate,
+		Read:   schema.Noop,
+		Delete: schema.RemoveFromState,
+
+		Schema: map[string]*schema.Schema{
+			"prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDataprocClusterNamePrefix,
+			},
+
+			"suffix_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(1, 32),
+			},
+
+			// Arbitrary values which, when changed, generate a new name.
+			"keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDataprocClusterNameCreate(d *schema.ResourceData, meta interface{}) error {
+	prefix := d.Get("prefix").(string)
+	suffixLength := d.Get("suffix_length").(int)
+
+	name, err := generateDataprocClusterName(prefix, suffixLength)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	d.Set("name", name)
+	return nil
+}
+
+const dataprocClusterNameSuffixChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// generateDataprocClusterName returns prefix followed by a hyphen and
+// suffixLength random lowercase letters and digits.
+func generateDataprocClusterName(prefix string, suffixLength int) (string, error) {
+	if len(prefix)+1+suffixLength > dataprocClusterNameMaxLength {
+		return "", fmt.Errorf("prefix %q with a %d character suffix would be longer than the %d characters allowed for a Dataproc cluster name",
+			prefix, suffixLength, dataprocClusterNameMaxLength)
+	}
+
+	suffix := make([]byte, suffixLength)
+	max := big.NewInt(int64(len(dataprocClusterNameSuffixChars)))
+	for i := range suffix {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("Error generating Dataproc cluster name: %s", err)
+		}
+		suffix[i] = dataprocClusterNameSuffixChars[n.Int64()]
+	}
+
+	return prefix + "-" + string(suffix), nil
+}
+
+func validateDataprocClusterNamePrefix(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !regexp.MustCompile("^[a-z]([a-z0-9-]*[a-z0-9])?$").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must start with a letter, end with a letter or number and only contain lowercase letters, numbers and hyphens", k))
+	}
+	if len(value)+2 > dataprocClusterNameMaxLength {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be longer than %d characters", k, dataprocClusterNameMaxLength-2))
+	}
+	return
+}
@@ -0,0 +1,89 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-google/google/fixtures"
+)
+
+func TestGenerateDataprocClusterName(t *testing.T) {
+	t.Parallel()
+
+	for _, prefix := range []string{"etl", "nightly-report-1", strings.Repeat("a", 46)} {
+		name, err := generateDataprocClusterName(prefix, 8)
+		if err != nil {
+			t.Fatalf("bad: %s, unexpected error: %s", prefix, err)
+		}
+		if !regexp.MustCompile("^" + prefix + "-[a-z0-9]{8}$").MatchString(name) {
+			t.Fatalf("bad: %s, unexpected name %q", prefix, name)
+		}
+		if _, errs := validateDataprocClusterName(name, "name"); len(errs) > 0 {
+			t.Fatalf("bad: %s, generated invalid name %q: %v", prefix, name, errs)
+		}
+	}
+
+	if _, err := generateDataprocClusterName(strings.Repeat("a", 47), 8); err == nil {
+		t.Fatalf("Expected an error for a name longer than 55 characters")
+	}
+}
+
+func TestValidateDataprocClusterNamePrefix(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]int{
+		"etl":                   0,
+		"etl-2017":              0,
+		"Etl":                   1,
+		"1etl":                  1,
+		"etl-":                  1,
+		"etl_job":               1,
+		strings.Repeat("a", 53): 0,
+		strings.Repeat("a", 54): 1,
+	}
+
+	for prefix, expected := range cases {
+		if _, errs := validateDataprocClusterNamePrefix(prefix, "prefix"); len(errs) != expected {
+			t.Errorf("bad: %s, expected %d errors, got %v", prefix, expected, errs)
+		}
+	}
+}
+
+func TestAccDataprocClusterName_basic(t *testing.T) {
+	t.Parallel()
+
+	rnd := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataprocClusterName_basic(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("google_dataproc_cluster_name.name", "name",
+						regexp.MustCompile(fmt.Sprintf("^dproc-cluster-test-%s-[a-z0-9]{6}$", rnd))),
+					resource.TestCheckResourceAttrPair(
+						"google_dataproc_cluster_name.name", "name",
+						"google_dataproc_cluster.named", "name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataprocClusterName_basic(rnd string) string {
+	return fmt.Sprintf(`
+resource "google_dataproc_cluster_name" "name" {
+	prefix        = "dproc-cluster-test-%s"
+	suffix_length = 6
+}
+`, rnd) + fixtures.DataprocCluster{
+		ResourceName: "named",
+		Name:         "${google_dataproc_cluster_name.name.name}",
+	}.Config()
+}
@@ -1,18 +1,27 @@
 package google
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-google/google/fixtures"
 
+	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/dataproc/v1"
 	"google.golang.org/api/googleapi"
 )
@@ -21,6 +30,286 @@ const emptyTFDefinition = `
 # empty def
 `
 
+func init() {
+	resource.AddTestSweepers("gcp_dataproc_cluster", &resource.Sweeper{
+		Name: "gcp_dataproc_cluster",
+		F:    testSweepDataprocClusters,
+	})
+}
+
+func testSweepDataprocClusters(region string) error {
+	config, err := sharedConfigForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting shared config for region: %s", err)
+	}
+
+	err = config.loadAndValidate()
+	if err != nil {
+		log.Fatalf("error loading: %s", err)
+	}
+
+	var clusters []*dataproc.Cluster
+	pageToken := ""
+	for {
+		resp, err := config.clientDataproc.Projects.Regions.Clusters.List(config.Project, region).PageToken(pageToken).Do()
+		if err != nil {
+			return fmt.Errorf("error listing Dataproc clusters: %s", err)
+		}
+		clusters = append(clusters, resp.Clusters...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	for _, cluster := range clusters {
+		// only destroy clusters we know to fit our test naming pattern
+		if !strings.HasPrefix(cluster.ClusterName, "dproc-cluster-test-") {
+			continue
+		}
+
+		log.Printf("Destroying Dataproc cluster (%s)", cluster.ClusterName)
+		op, err := config.clientDataproc.Projects.Regions.Clusters.Delete(config.Project, region, cluster.ClusterName).Do()
+		if err != nil {
+			log.Printf("Error destroying Dataproc cluster (%s): %s", cluster.ClusterName, err)
+			continue
+		}
+
+		if err := dataprocClusterOperationWait(config, op, "deleting Dataproc cluster", 20, 3); err != nil {
+			log.Printf("Error destroying Dataproc cluster (%s): %s", cluster.ClusterName, err)
+			continue
+		}
+
+		// Buckets generated by Dataproc are named dataproc-{uuid}-{region},
+		// anything else is a staging bucket owned by another resource.
+		if cluster.Config == nil || !strings.HasPrefix(cluster.Config.ConfigBucket, "dataproc-") {
+			continue
+		}
+
+		log.Printf("Destroying autogenerated bucket (%s) of Dataproc cluster (%s)", cluster.Config.ConfigBucket, cluster.ClusterName)
+		if err := emptyAndDeleteStorageBucket(config, cluster.Config.ConfigBucket, ""); err != nil {
+			log.Printf("Error destroying bucket (%s): %s", cluster.Config.ConfigBucket, err)
+		}
+	}
+
+	return nil
+}
+
+func TestDataprocFirewallRulesAllow(t *testing.T) {
+	t.Parallel()
+
+	internal := &dataprocFirewallRule{Firewall: compute.Firewall{
+		Network:    "projects/my-project/global/networks/dataproc",
+		Priority:   1000,
+		SourceTags: []string{"dataproc"},
+		TargetTags: []string{"dataproc"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "tcp", Ports: []string{"0-65535"}},
+			{IPProtocol: "udp"},
+		},
+	}}
+	ssh := &dataprocFirewallRule{Firewall: compute.Firewall{
+		Priority:     1000,
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "tcp", Ports: []string{"22"}},
+		},
+	}}
+	egress := &dataprocFirewallRule{Firewall: compute.Firewall{
+		Direction:  "EGRESS",
+		Priority:   1000,
+		SourceTags: []string{"dataproc"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "all"},
+		},
+	}}
+	allInternal := &dataprocFirewallRule{Firewall: compute.Firewall{
+		Priority:     1000,
+		SourceRanges: []string{"10.128.0.0/9"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "all"},
+		},
+	}}
+	anywhere := &dataprocFirewallRule{Firewall: compute.Firewall{
+		Priority:     1000,
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "all"},
+		},
+	}}
+	singleHost := &dataprocFirewallRule{Firewall: compute.Firewall{
+		Priority:     1000,
+		SourceRanges: []string{"1.2.3.4/32"},
+		TargetTags:   []string{"dataproc"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "all"},
+		},
+	}}
+	disabled := &dataprocFirewallRule{Firewall: internal.Firewall, Disabled: true}
+	deny := func(priority int64) *dataprocFirewallRule {
+		return &dataprocFirewallRule{Firewall: compute.Firewall{
+			Priority:     priority,
+			SourceRanges: []string{"10.128.0.0/16"},
+			Denied: []*compute.FirewallDenied{
+				{IPProtocol: "tcp", Ports: []string{"8088"}},
+			},
+		}}
+	}
+
+	cases := map[string]struct {
+		Rules    []*dataprocFirewallRule
+		Tag      string
+		Protocol string
+		Cidr     string
+		Expected bool
+	}{
+		"tagged rule tcp":             {[]*dataprocFirewallRule{ssh, internal}, "dataproc", "tcp", "10.128.0.0/20", true},
+		"tagged rule udp":             {[]*dataprocFirewallRule{ssh, internal}, "dataproc", "udp", "10.128.0.0/20", true},
+		"tagged rule other tag":       {[]*dataprocFirewallRule{ssh, internal}, "other", "tcp", "10.128.0.0/20", false},
+		"only some ports":             {[]*dataprocFirewallRule{ssh}, "dataproc", "tcp", "10.128.0.0/20", false},
+		"egress rules are skipped":    {[]*dataprocFirewallRule{egress}, "dataproc", "tcp", "10.128.0.0/20", false},
+		"range covering subnetwork":   {[]*dataprocFirewallRule{allInternal}, "dataproc", "udp", "10.128.0.0/20", true},
+		"range outside subnetwork":    {[]*dataprocFirewallRule{allInternal}, "dataproc", "udp", "192.168.0.0/24", false},
+		"range within subnetwork":     {[]*dataprocFirewallRule{allInternal}, "dataproc", "udp", "10.0.0.0/8", false},
+		"single host range":           {[]*dataprocFirewallRule{singleHost}, "dataproc", "tcp", "10.128.0.0/20", false},
+		"unknown subnetwork range":    {[]*dataprocFirewallRule{allInternal}, "dataproc", "tcp", "", false},
+		"anywhere":                    {[]*dataprocFirewallRule{anywhere}, "dataproc", "tcp", "", true},
+		"disabled rules are skipped":  {[]*dataprocFirewallRule{disabled}, "dataproc", "tcp", "10.128.0.0/20", false},
+		"higher priority deny":        {[]*dataprocFirewallRule{internal, deny(900)}, "dataproc", "tcp", "10.128.0.0/20", false},
+		"same priority deny":          {[]*dataprocFirewallRule{internal, deny(1000)}, "dataproc", "tcp", "10.128.0.0/20", false},
+		"lower priority deny":         {[]*dataprocFirewallRule{internal, deny(1100)}, "dataproc", "tcp", "10.128.0.0/20", true},
+		"deny for another protocol":   {[]*dataprocFirewallRule{internal, deny(900)}, "dataproc", "udp", "10.128.0.0/20", true},
+		"deny outside the subnetwork": {[]*dataprocFirewallRule{internal, deny(900)}, "dataproc", "tcp", "192.168.0.0/24", true},
+		"no rules":                    {nil, "dataproc", "tcp", "10.128.0.0/20", false},
+	}
+
+	for tn, tc := range cases {
+		if actual := dataprocFirewallRulesAllow(tc.Rules, tc.Tag, tc.Protocol, tc.Cidr); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %t, got %t", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestCheckDataprocFirewallRules_tagNotOnInstances(t *testing.T) {
+	t.Parallel()
+
+	gcc := &dataproc.GceClusterConfig{Tags: []string{"dataproc"}}
+	err := checkDataprocFirewallRules(nil, "my-project", "us-central1", gcc, []string{"dataproc", "other"})
+	if err == nil || !strings.Contains(err.Error(), `"other", which isn't in the cluster's tags`) {
+		t.Fatalf("expected an error for the tag missing from tags, got %v", err)
+	}
+}
+
+func TestParseDataprocSubnetwork(t *testing.T) {
+	cases := map[string]struct {
+		Subnetwork            string
+		Project, Region, Name string
+	}{
+		"name": {
+			Subnetwork: "dataproc",
+			Project:    "service-project", Region: "us-central1", Name: "dataproc",
+		},
+		"shared vpc self link": {
+			Subnetwork: "https://www.googleapis.com/compute/v1/projects/host-project/regions/europe-west1/subnetworks/shared",
+			Project:    "host-project", Region: "europe-west1", Name: "shared",
+		},
+		"shared vpc relative link": {
+			Subnetwork: "projects/host-project/regions/europe-west1/subnetworks/shared",
+			Project:    "host-project", Region: "europe-west1", Name: "shared",
+		},
+	}
+
+	for tn, tc := range cases {
+		project, region, name := parseDataprocSubnetwork(tc.Subnetwork, "service-project", "us-central1")
+		if project != tc.Project || region != tc.Region || name != tc.Name {
+			t.Errorf("%s: expected %s/%s/%s, got %s/%s/%s", tn, tc.Project, tc.Region, tc.Name, project, region, name)
+		}
+	}
+
+	if project := getProjectFromNetworkLink("https://www.googleapis.com/compute/v1/projects/host-project/global/networks/shared"); project != "host-project" {
+		t.Errorf("Expected the network's project to be host-project, got %q", project)
+	}
+	if project := getProjectFromNetworkLink("shared"); project != "" {
+		t.Errorf("Expected no project for a network name, got %q", project)
+	}
+}
+
+func TestFormatDataprocClusterOperations(t *testing.T) {
+	t.Parallel()
+
+	ops := []*dataproc.Operation{
+		{
+			Name:     "projects/p/regions/r/operations/create",
+			Metadata: googleapi.RawMessage(`{"clusterName": "test", "operationType": "CREATE", "status": {"state": "DONE", "stateStartTime": "2017-12-01T10:00:00Z"}}`),
+			Error:    &dataproc.Status{Code: 8, Message: "Insufficient 'CPUS' quota. Requested 24.0, available 8.0."},
+		},
+		{
+			Name:     "projects/p/regions/r/operations/other",
+			Metadata: googleapi.RawMessage(`{"clusterName": "other", "operationType": "CREATE", "status": {"state": "DONE", "stateStartTime": "2017-12-01T11:00:00Z"}}`),
+		},
+		{
+			Name:     "projects/p/regions/r/operations/delete",
+			Metadata: googleapi.RawMessage(`{"clusterName": "test", "operationType": "DELETE", "status": {"state": "RUNNING", "details": "Deleting instances", "stateStartTime": "2017-12-01T10:05:00Z"}}`),
+		},
+	}
+
+	expected := "  2017-12-01T10:05:00Z DELETE: RUNNING (Deleting instances) [projects/p/regions/r/operations/delete]\n" +
+		"  2017-12-01T10:00:00Z CREATE: DONE, error code 8: Insufficient 'CPUS' quota. Requested 24.0, available 8.0. [projects/p/regions/r/operations/create]"
+	if actual := formatDataprocClusterOperations(ops, "test", 5); actual != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+
+	expected = "  2017-12-01T10:05:00Z DELETE: RUNNING (Deleting instances) [projects/p/regions/r/operations/delete]"
+	if actual := formatDataprocClusterOperations(ops, "test", 1); actual != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+
+	if actual := formatDataprocClusterOperations(ops, "missing", 5); actual != "  (none found)" {
+		t.Fatalf("Expected no operations, got:\n%s", actual)
+	}
+}
+
+func TestIsDataprocServiceAccountNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Err      error
+		Expected bool
+	}{
+		"service account not found": {
+			Err: &googleapi.Error{
+				Code:    400,
+				Message: "Service account dproc-sa@my-project.iam.gserviceaccount.com does not exist.",
+			},
+			Expected: true,
+		},
+		"other bad request": {
+			Err: &googleapi.Error{
+				Code:    400,
+				Message: "Multiple validation errors: Insufficient 'CPUS' quota.",
+			},
+			Expected: false,
+		},
+		"not found": {
+			Err: &googleapi.Error{
+				Code:    404,
+				Message: "Service account dproc-sa@my-project.iam.gserviceaccount.com does not exist.",
+			},
+			Expected: false,
+		},
+		"not an api error": {
+			Err:      fmt.Errorf("Service account does not exist"),
+			Expected: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		if actual := isDataprocServiceAccountNotFoundError(tc.Err); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %t, got %t", tn, tc.Expected, actual)
+		}
+	}
+}
+
 func TestExtractInitTimeout(t *testing.T) {
 	t.Parallel()
 
@@ -58,6 +347,314 @@ func TestExtractInitTimeout_empty(t *testing.T) {
 	t.Fatalf("Expected an error with message '%s', but got %v", expected, err.Error())
 }
 
+func TestFlattenOverrideProperties(t *testing.T) {
+	t.Parallel()
+
+	overrides := map[string]interface{}{
+		"spark:spark.executor.memory":          "2g",
+		"dataproc:dataproc.allow.zero.workers": "true",
+	}
+	props := map[string]string{
+		"spark:spark.executor.memory":              "4g",
+		"dataproc:dataproc.allow.zero.workers":     "false",
+		"yarn:yarn.nodemanager.resource.memory-mb": "3072",
+	}
+
+	cases := map[string]struct {
+		Filter   *schema.Set
+		Expected map[string]interface{}
+	}{
+		"no filter": {
+			Filter: nil,
+			Expected: map[string]interface{}{
+				"spark:spark.executor.memory":          "2g",
+				"dataproc:dataproc.allow.zero.workers": "true",
+			},
+		},
+		"filtered key drifts": {
+			Filter: schema.NewSet(schema.HashString, []interface{}{"spark:spark.executor.memory"}),
+			Expected: map[string]interface{}{
+				"spark:spark.executor.memory":          "4g",
+				"dataproc:dataproc.allow.zero.workers": "true",
+			},
+		},
+		"filtered key not overridden": {
+			Filter: schema.NewSet(schema.HashString, []interface{}{"yarn:yarn.nodemanager.resource.memory-mb"}),
+			Expected: map[string]interface{}{
+				"spark:spark.executor.memory":          "2g",
+				"dataproc:dataproc.allow.zero.workers": "true",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		actual := flattenOverrideProperties(overrides, props, tc.Filter)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("bad: %s, expected %v, got %v", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestCheckDataprocClusterZone(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Region, Zone string
+		Error        string
+	}{
+		"global without zone": {
+			Region: "global",
+			Error:  "zone is mandatory when region is set to 'global'",
+		},
+		"global with zone": {
+			Region: "global",
+			Zone:   "us-central1-a",
+		},
+		"regional without zone": {
+			Region: "us-central1",
+		},
+		"zone in region": {
+			Region: "us-central1",
+			Zone:   "us-central1-f",
+		},
+		"zone self link in region": {
+			Region: "europe-west1",
+			Zone:   "https://www.googleapis.com/compute/v1/projects/my-project/zones/europe-west1-b",
+		},
+		"zone in other region": {
+			Region: "us-central1",
+			Zone:   "europe-west1-b",
+			Error:  `zone "europe-west1-b" is not in region "us-central1"`,
+		},
+	}
+
+	for tn, tc := range cases {
+		err := checkDataprocClusterZone(tc.Region, tc.Zone)
+		if tc.Error == "" && err != nil {
+			t.Errorf("bad: %s, unexpected error %s", tn, err)
+		}
+		if tc.Error != "" && (err == nil || err.Error() != tc.Error) {
+			t.Errorf("bad: %s, expected error %q, got %v", tn, tc.Error, err)
+		}
+	}
+}
+
+func TestDataprocClusterUserLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{
+		"key1":                       "value1",
+		"goog-dataproc-cluster-name": "my-cluster",
+		"goog-dataproc-cluster-uuid": "0a1b2c3d",
+		"goog-other":                 "kept",
+	}
+	expected := map[string]string{
+		"key1":       "value1",
+		"goog-other": "kept",
+	}
+
+	if actual := dataprocClusterUserLabels(labels); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: expected %v, got %v", expected, actual)
+	}
+}
+
+func TestDataprocClusterUpdateMask(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		FieldsInSchema map[string]interface{}
+		UpdatedFields  []string
+		ExpectedMask   []string
+		Check          func(c *dataproc.Cluster) bool
+	}{
+		"no changes": {
+			FieldsInSchema: map[string]interface{}{
+				"labels": map[string]interface{}{"key1": "value1"},
+			},
+			ExpectedMask: []string{},
+		},
+		"labels": {
+			FieldsInSchema: map[string]interface{}{
+				"labels": map[string]interface{}{"key1": "value1"},
+			},
+			UpdatedFields: []string{"labels"},
+			ExpectedMask:  []string{"labels"},
+			Check: func(c *dataproc.Cluster) bool {
+				return c.Labels["key1"] == "value1"
+			},
+		},
+		"all updatable fields together": {
+			FieldsInSchema: map[string]interface{}{
+				"labels": map[string]interface{}{"key1": "value1"},
+				"cluster_config.0.worker_config.0.num_instances":             3,
+				"cluster_config.0.preemptible_worker_config.0.num_instances": 2,
+			},
+			UpdatedFields: []string{
+				"labels",
+				"cluster_config.0.worker_config.0.num_instances",
+				"cluster_config.0.preemptible_worker_config.0.num_instances",
+			},
+			ExpectedMask: []string{
+				"labels",
+				"config.worker_config.num_instances",
+				"config.secondary_worker_config.num_instances",
+			},
+			Check: func(c *dataproc.Cluster) bool {
+				return c.Config.WorkerConfig.NumInstances == 3 && c.Config.SecondaryWorkerConfig.NumInstances == 2
+			},
+		},
+		"scale preemptible workers to zero": {
+			FieldsInSchema: map[string]interface{}{
+				"cluster_config.0.preemptible_worker_config.0.num_instances": 0,
+			},
+			UpdatedFields: []string{
+				"cluster_config.0.preemptible_worker_config.0.num_instances",
+			},
+			ExpectedMask: []string{
+				"config.secondary_worker_config.num_instances",
+			},
+			Check: func(c *dataproc.Cluster) bool {
+				return c.Config.WorkerConfig == nil && c.Config.SecondaryWorkerConfig.NumInstances == 0
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDataMock{
+			FieldsInSchema:      tc.FieldsInSchema,
+			FieldsWithHasChange: tc.UpdatedFields,
+		}
+
+		cluster := &dataproc.Cluster{}
+		mask := expandDataprocClusterUpdate(d, cluster)
+		if !reflect.DeepEqual(mask, tc.ExpectedMask) {
+			t.Errorf("bad: %s, expected update mask %v, got %v", tn, tc.ExpectedMask, mask)
+		}
+		if tc.Check != nil && !tc.Check(cluster) {
+			t.Errorf("bad: %s, unexpected cluster in patch request: %+v", tn, cluster.Config)
+		}
+	}
+}
+
+func TestExpandPackageInstallConfig(t *testing.T) {
+	t.Parallel()
+
+	conf := &dataproc.ClusterConfig{
+		GceClusterConfig: &dataproc.GceClusterConfig{},
+		InitializationActions: []*dataproc.NodeInitializationAction{
+			{ExecutableFile: "gs://my-bucket/init.sh"},
+		},
+	}
+	expandPackageInstallConfig(map[string]interface{}{
+		"python_packages": []interface{}{"pandas==0.21.0", "requests"},
+		"conda_packages":  []interface{}{},
+	}, conf)
+
+	expectedMetadata := map[string]string{"PIP_PACKAGES": "pandas==0.21.0 requests"}
+	if !reflect.DeepEqual(conf.GceClusterConfig.Metadata, expectedMetadata) {
+		t.Fatalf("Expected metadata %v, got %v", expectedMetadata, conf.GceClusterConfig.Metadata)
+	}
+
+	scripts := []string{}
+	for _, a := range conf.InitializationActions {
+		scripts = append(scripts, a.ExecutableFile)
+	}
+	expectedScripts := append(append([]string{}, dataprocPackageInstallActions...), "gs://my-bucket/init.sh")
+	if !reflect.DeepEqual(scripts, expectedScripts) {
+		t.Fatalf("Expected initialization actions %v, got %v", expectedScripts, scripts)
+	}
+
+	filtered := filterPackageInstallActions(conf.InitializationActions)
+	if len(filtered) != 1 || filtered[0].ExecutableFile != "gs://my-bucket/init.sh" {
+		t.Fatalf("Expected only the user initialization action after filtering, got %v", filtered)
+	}
+}
+
+func TestExpandPackageInstallConfig_empty(t *testing.T) {
+	t.Parallel()
+
+	conf := &dataproc.ClusterConfig{
+		GceClusterConfig: &dataproc.GceClusterConfig{},
+	}
+	expandPackageInstallConfig(map[string]interface{}{}, conf)
+
+	if conf.GceClusterConfig.Metadata != nil || len(conf.InitializationActions) != 0 {
+		t.Fatalf("Expected no metadata or initialization actions, got %v and %v", conf.GceClusterConfig.Metadata, conf.InitializationActions)
+	}
+}
+
+func TestExpandWireEncryptionProperties(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Config   []interface{}
+		Expected map[string]string
+	}{
+		"not set": {
+			Config:   []interface{}{},
+			Expected: map[string]string{},
+		},
+		"rpc protection only": {
+			Config: []interface{}{
+				map[string]interface{}{
+					"rpc_protection": "privacy",
+				},
+			},
+			Expected: map[string]string{
+				"core:hadoop.rpc.protection": "privacy",
+			},
+		},
+		"everything": {
+			Config: []interface{}{
+				map[string]interface{}{
+					"rpc_protection":        "integrity",
+					"encrypt_data_transfer": true,
+					"spark_authenticate":    false,
+					"encrypted_shuffle":     true,
+				},
+			},
+			Expected: map[string]string{
+				"core:hadoop.rpc.protection":         "integrity",
+				"hdfs:dfs.encrypt.data.transfer":     "true",
+				"spark:spark.authenticate":           "true",
+				"spark:spark.network.crypto.enabled": "true",
+				"spark:spark.io.encryption.enabled":  "true",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		actual := expandWireEncryptionProperties(tc.Config)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("bad: %s, expected %v, got %v", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestExpandSoftwareConfig_overridesWinOverWireEncryption(t *testing.T) {
+	t.Parallel()
+
+	conf := expandSoftwareConfig(map[string]interface{}{
+		"override_properties": map[string]interface{}{
+			"core:hadoop.rpc.protection": "authentication",
+		},
+		"wire_encryption": []interface{}{
+			map[string]interface{}{
+				"rpc_protection":     "privacy",
+				"spark_authenticate": true,
+			},
+		},
+	})
+
+	expected := map[string]string{
+		"core:hadoop.rpc.protection": "authentication",
+		"spark:spark.authenticate":   "true",
+	}
+	if !reflect.DeepEqual(conf.Properties, expected) {
+		t.Fatalf("Expected properties %v, got %v", expected, conf.Properties)
+	}
+}
+
 func TestAccDataprocCluster_missingZoneGlobalRegion1(t *testing.T) {
 	t.Parallel()
 
@@ -99,7 +696,7 @@ func TestAccDataprocCluster_basic(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_basic(rnd),
 				Check: resource.ComposeTestCheckFunc(
@@ -130,7 +727,7 @@ func TestAccDataprocCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("google_dataproc_cluster.basic", "cluster_config.0.preemptible_worker_config.0.instance_names.#", "0"),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -143,7 +740,7 @@ func TestAccDataprocCluster_basicWithAutogenDeleteTrue(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(true),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_basicWithAutogenDeleteTrue(rnd),
 				Check: resource.ComposeTestCheckFunc(
@@ -156,7 +753,7 @@ func TestAccDataprocCluster_basicWithAutogenDeleteTrue(t *testing.T) {
 				Config: emptyTFDefinition,
 				Check:  testAccCheckDataprocAutogenBucketDeleted(&cluster),
 			},
-		},
+		}),
 	})
 }
 
@@ -169,7 +766,7 @@ func TestAccDataprocCluster_singleNodeCluster(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_singleNodeCluster(rnd),
 				Check: resource.ComposeTestCheckFunc(
@@ -182,7 +779,7 @@ func TestAccDataprocCluster_singleNodeCluster(t *testing.T) {
 					resource.TestCheckResourceAttrSet("google_dataproc_cluster.single_node_cluster", "cluster_config.0.software_config.0.properties.%"),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -196,7 +793,7 @@ func TestAccDataprocCluster_updatable(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_updatable(rnd, 2, 1),
 				Check: resource.ComposeTestCheckFunc(
@@ -212,10 +809,47 @@ func TestAccDataprocCluster_updatable(t *testing.T) {
 					resource.TestCheckResourceAttr("google_dataproc_cluster.updatable", "cluster_config.0.worker_config.0.num_instances", "3"),
 					resource.TestCheckResourceAttr("google_dataproc_cluster.updatable", "cluster_config.0.preemptible_worker_config.0.num_instances", "2")),
 			},
-		},
+		}),
+	})
+}
+
+func TestAccDataprocCluster_tagsWithoutRecreate(t *testing.T) {
+	t.Parallel()
+
+	rnd := acctest.RandString(10)
+	var cluster, updated dataproc.Cluster
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
+			{
+				Config: testAccDataprocCluster_tags(rnd, `["foo"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataprocClusterExists("google_dataproc_cluster.tags", &cluster),
+					resource.TestCheckResourceAttr("google_dataproc_cluster.tags", "cluster_config.0.gce_cluster_config.0.tags.#", "1")),
+			},
+			{
+				Config: testAccDataprocCluster_tags(rnd, `["foo", "bar"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataprocClusterExists("google_dataproc_cluster.tags", &updated),
+					testAccCheckDataprocClusterNotRecreated(&cluster, &updated),
+					resource.TestCheckResourceAttr("google_dataproc_cluster.tags", "cluster_config.0.gce_cluster_config.0.tags.#", "1")),
+			},
+		}),
 	})
 }
 
+func testAccCheckDataprocClusterNotRecreated(before, after *dataproc.Cluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ClusterUuid != after.ClusterUuid {
+			return fmt.Errorf("Cluster was recreated, UUID changed from %s to %s", before.ClusterUuid, after.ClusterUuid)
+		}
+		return nil
+	}
+}
+
 func TestAccDataprocCluster_withStagingBucket(t *testing.T) {
 	t.Parallel()
 
@@ -228,7 +862,7 @@ func TestAccDataprocCluster_withStagingBucket(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_withStagingBucketAndCluster(clusterName, bucketName),
 				Check: resource.ComposeTestCheckFunc(
@@ -244,7 +878,7 @@ func TestAccDataprocCluster_withStagingBucket(t *testing.T) {
 					testAccCheckDataprocStagingBucketExists(bucketName),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -259,7 +893,7 @@ func TestAccDataprocCluster_withInitAction(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_withInitAction(rnd, bucketName, objectName),
 				Check: resource.ComposeTestCheckFunc(
@@ -269,7 +903,7 @@ func TestAccDataprocCluster_withInitAction(t *testing.T) {
 					testAccCheckDataprocClusterInitActionSucceeded(bucketName, objectName),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -282,7 +916,7 @@ func TestAccDataprocCluster_withConfigOverrides(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_withConfigOverrides(rnd),
 				Check: resource.ComposeTestCheckFunc(
@@ -290,7 +924,7 @@ func TestAccDataprocCluster_withConfigOverrides(t *testing.T) {
 					validateDataprocCluster_withConfigOverrides("google_dataproc_cluster.with_config_overrides", &cluster),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -308,7 +942,7 @@ func TestAccDataprocCluster_withServiceAcc(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_withServiceAcc(sa, rnd),
 				Check: resource.ComposeTestCheckFunc(
@@ -323,7 +957,7 @@ func TestAccDataprocCluster_withServiceAcc(t *testing.T) {
 					resource.TestCheckResourceAttr("google_dataproc_cluster.with_service_account", "cluster_config.0.gce_cluster_config.0.service_account", saEmail),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -336,7 +970,7 @@ func TestAccDataprocCluster_withImageVersion(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_withImageVersion(rnd),
 				Check: resource.ComposeTestCheckFunc(
@@ -344,7 +978,7 @@ func TestAccDataprocCluster_withImageVersion(t *testing.T) {
 					resource.TestCheckResourceAttr("google_dataproc_cluster.with_image_version", "cluster_config.0.software_config.0.image_version", "preview"),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -357,24 +991,22 @@ func TestAccDataprocCluster_withLabels(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_withLabels(rnd),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckDataprocClusterExists("google_dataproc_cluster.with_labels", &cluster),
 
-					// We only provide one, but GCP adds two, so expect 3. This means unfortunately a
-					// diff will exist unless the user adds these in. An alternative approach would
-					// be to follow the same approach as properties, i.e. split in into labels
-					// and override_labels
-					//
-					// The config is currently configured with ignore_changes = ["labels"] to handle this
-					//
-					resource.TestCheckResourceAttr("google_dataproc_cluster.with_labels", "labels.%", "3"),
+					// We only provide one, GCP adds goog-dataproc-cluster-name and
+					// goog-dataproc-cluster-uuid, which only show up in effective_labels.
+					resource.TestCheckResourceAttr("google_dataproc_cluster.with_labels", "labels.%", "1"),
 					resource.TestCheckResourceAttr("google_dataproc_cluster.with_labels", "labels.key1", "value1"),
+					resource.TestCheckResourceAttr("google_dataproc_cluster.with_labels", "effective_labels.%", "3"),
+					resource.TestCheckResourceAttr("google_dataproc_cluster.with_labels", "effective_labels.key1", "value1"),
+					resource.TestCheckResourceAttr("google_dataproc_cluster.with_labels", "effective_labels.goog-dataproc-cluster-name", "dproc-cluster-test-"+rnd),
 				),
 			},
-		},
+		}),
 	})
 }
 
@@ -388,7 +1020,7 @@ func TestAccDataprocCluster_withNetworkRefs(t *testing.T) {
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckDataprocClusterDestroy(false),
-		Steps: []resource.TestStep{
+		Steps: testAccDataprocClusterWithImportSteps("us-central1", []resource.TestStep{
 			{
 				Config: testAccDataprocCluster_withNetworkRefs(rnd, netName),
 				Check: resource.ComposeTestCheckFunc(
@@ -397,6 +1029,31 @@ func TestAccDataprocCluster_withNetworkRefs(t *testing.T) {
 					testAccCheckDataprocClusterExists("google_dataproc_cluster.with_net_ref_by_name", &c2),
 				),
 			},
+		}),
+	})
+}
+
+func TestAccDataprocCluster_sharedVpcSubnetwork(t *testing.T) {
+	skipIfEnvNotSet(t, "GOOGLE_ORG", "GOOGLE_BILLING_ACCOUNT")
+	billingId := os.Getenv("GOOGLE_BILLING_ACCOUNT")
+
+	rnd := acctest.RandString(10)
+	hostProject := "dproc-host-" + rnd
+	serviceProject := "dproc-service-" + rnd
+
+	// No CheckDestroy, the cluster's project is deleted along with it.
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataprocCluster_sharedVpcSubnetwork(rnd, hostProject, serviceProject, org, billingId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("google_dataproc_cluster.shared_vpc",
+						"cluster_config.0.gce_cluster_config.0.subnetwork",
+						regexp.MustCompile("projects/"+hostProject+"/regions/us-central1/subnetworks/dproc-shared-"+rnd+"$")),
+				),
+			},
 		},
 	})
 }
@@ -423,7 +1080,7 @@ func testAccCheckDataprocClusterDestroy(expectedBucketDestroy bool) resource.Tes
 
 			// 1. Verify actual cluster deleted
 			if err := validateClusterDeleted(project, attributes["region"], rs.Primary.ID, config); err != nil {
-				return err
+				return withDataprocClusterOperations(config, project, attributes["region"], rs.Primary.ID, err)
 			}
 
 			// 2. Depending on delete_autogen_bucket setting, check if
@@ -434,7 +1091,7 @@ func testAccCheckDataprocClusterDestroy(expectedBucketDestroy bool) resource.Tes
 
 			// 3. Many of the tests use the default delete_autogen_bucket setting (false)
 			//    Clean up to avoid dangling resources after test.
-			if err := emptyAndDeleteStorageBucket(config, computedBucket); err != nil {
+			if err := emptyAndDeleteStorageBucket(config, computedBucket, ""); err != nil {
 				return fmt.Errorf("Error occured trying to clean up autogenerate bucket after test %v", err)
 			}
 		}
@@ -454,6 +1111,68 @@ func testAccCheckDataprocClusterHasServiceScopes(t *testing.T, cluster *dataproc
 	}
 }
 
+// withDataprocClusterOperations adds the latest operations on a cluster,
+// and how they ended, to err. The operations usually explain failures such
+// as running out of quota, which otherwise need console access to find.
+func withDataprocClusterOperations(config *Config, project, region, clusterName string, err error) error {
+	var ops []*dataproc.Operation
+	listErr := config.clientDataproc.Projects.Regions.Operations.List(
+		fmt.Sprintf("projects/%s/regions/%s/operations", project, region)).Pages(context.Background(), func(resp *dataproc.ListOperationsResponse) error {
+		ops = append(ops, resp.Operations...)
+		return nil
+	})
+	if listErr != nil {
+		return fmt.Errorf("%s\n\n(unable to list operations of cluster %s: %s)", err, clusterName, listErr)
+	}
+
+	return fmt.Errorf("%s\n\nLatest operations of cluster %s:\n%s", err, clusterName, formatDataprocClusterOperations(ops, clusterName, 5))
+}
+
+// formatDataprocClusterOperations describes the last max operations on
+// clusterName, newest first.
+func formatDataprocClusterOperations(ops []*dataproc.Operation, clusterName string, max int) string {
+	type clusterOperation struct {
+		op       *dataproc.Operation
+		metadata dataproc.ClusterOperationMetadata
+	}
+
+	var clusterOps []clusterOperation
+	for _, op := range ops {
+		var metadata dataproc.ClusterOperationMetadata
+		if err := json.Unmarshal(op.Metadata, &metadata); err != nil || metadata.ClusterName != clusterName {
+			continue
+		}
+		if metadata.Status == nil {
+			metadata.Status = &dataproc.ClusterOperationStatus{}
+		}
+		clusterOps = append(clusterOps, clusterOperation{op, metadata})
+	}
+
+	if len(clusterOps) == 0 {
+		return "  (none found)"
+	}
+
+	sort.Slice(clusterOps, func(i, j int) bool {
+		return clusterOps[i].metadata.Status.StateStartTime > clusterOps[j].metadata.Status.StateStartTime
+	})
+	if len(clusterOps) > max {
+		clusterOps = clusterOps[:max]
+	}
+
+	var buf bytes.Buffer
+	for _, o := range clusterOps {
+		fmt.Fprintf(&buf, "  %s %s: %s", o.metadata.Status.StateStartTime, o.metadata.OperationType, o.metadata.Status.State)
+		if o.metadata.Status.Details != "" {
+			fmt.Fprintf(&buf, " (%s)", o.metadata.Status.Details)
+		}
+		if o.op.Error != nil {
+			fmt.Fprintf(&buf, ", error code %d: %s", o.op.Error.Code, o.op.Error.Message)
+		}
+		fmt.Fprintf(&buf, " [%s]\n", o.op.Name)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
 func validateClusterDeleted(project, region, clusterName string, config *Config) error {
 	_, err := config.clientDataproc.Projects.Regions.Clusters.Get(
 		project, region, clusterName).Do()
@@ -606,7 +1325,7 @@ func testAccCheckDataprocClusterExists(n string, cluster *dataproc.Cluster) reso
 		found, err := config.clientDataproc.Projects.Regions.Clusters.Get(
 			project, rs.Primary.Attributes["region"], rs.Primary.ID).Do()
 		if err != nil {
-			return err
+			return withDataprocClusterOperations(config, project, rs.Primary.Attributes["region"], rs.Primary.ID, err)
 		}
 
 		if found.ClusterName != rs.Primary.ID {
@@ -664,56 +1383,27 @@ resource "google_dataproc_cluster" "basic" {
 }
 
 func testAccDataprocCluster_singleNodeCluster(rnd string) string {
-	return fmt.Sprintf(`
-resource "google_dataproc_cluster" "single_node_cluster" {
-	name   = "dproc-cluster-test-%s"
-	region = "us-central1"
-
-	cluster_config {
-		# Keep the costs down with smallest config we can get away with
-		software_config {
-			override_properties = {
-				"dataproc:dataproc.allow.zero.workers" = "true"
-			}
-		}
-	}
-}
-`, rnd)
+	return fixtures.DataprocCluster{
+		ResourceName: "single_node_cluster",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+	}.Config()
 }
 
 func testAccDataprocCluster_withConfigOverrides(rnd string) string {
-	return fmt.Sprintf(`
-resource "google_dataproc_cluster" "with_config_overrides" {
-	name   = "dproc-cluster-test-%s"
-	region = "us-central1"
-
-	cluster_config {
-
-		master_config {
-			num_instances     = 3
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-
-		worker_config {
-			num_instances     = 3
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 11
-				num_local_ssds    = 1
-			}
-		}
-
-		preemptible_worker_config {
-			num_instances     = 1
-			disk_config {
-				boot_disk_size_gb = 12
-			}
-		}
-	}
-}`, rnd)
+	return fixtures.DataprocCluster{
+		ResourceName: "with_config_overrides",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		Master:       fixtures.DataprocInstanceGroup{NumInstances: 3},
+		Worker: &fixtures.DataprocInstanceGroup{
+			NumInstances:   3,
+			BootDiskSizeGb: 11,
+			NumLocalSsds:   1,
+		},
+		PreemptibleWorker: &fixtures.DataprocInstanceGroup{
+			NumInstances:   1,
+			BootDiskSizeGb: 12,
+		},
+	}.Config()
 }
 
 func testAccDataprocCluster_withInitAction(rnd, bucket, objName string) string {
@@ -733,70 +1423,39 @@ gsutil cp /tmp/%s ${google_storage_bucket.init_bucket.url}
 EOL
 
 }
-
-resource "google_dataproc_cluster" "with_init_action" {
-	name   = "dproc-cluster-test-%s"
-	region = "us-central1"
-
-	cluster_config {
-		# Keep the costs down with smallest config we can get away with
-		software_config {
-			override_properties = {
-				"dataproc:dataproc.allow.zero.workers" = "true"
-			}
-		}
-
-		master_config {
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-
+%s`, bucket, rnd, objName, objName, fixtures.DataprocCluster{
+		ResourceName: "with_init_action",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		ClusterConfig: `
 		initialization_action {
 			script      = "${google_storage_bucket.init_bucket.url}/${google_storage_bucket_object.init_script.name}"
 			timeout_sec = 500
 		}
 		initialization_action {
 			script      = "${google_storage_bucket.init_bucket.url}/${google_storage_bucket_object.init_script.name}"
-		}
-	}
-}`, bucket, rnd, objName, objName, rnd)
+		}`,
+	}.Config())
 }
 
 func testAccDataprocCluster_updatable(rnd string, w, p int) string {
-	return fmt.Sprintf(`
-resource "google_dataproc_cluster" "updatable" {
-	name   = "dproc-cluster-test-%s"
-	region = "us-central1"
-
-	cluster_config {
-
-		master_config {
-			num_instances      = "1"
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-
-		worker_config {
-			num_instances      = "%d"
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-
-		preemptible_worker_config {
-			num_instances      = "%d"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-	}
+	return fixtures.DataprocCluster{
+		ResourceName:      "updatable",
+		Name:              fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		Master:            fixtures.DataprocInstanceGroup{NumInstances: 1},
+		Worker:            &fixtures.DataprocInstanceGroup{NumInstances: w},
+		PreemptibleWorker: &fixtures.DataprocInstanceGroup{NumInstances: p},
+	}.Config()
+}
 
-}`, rnd, w, p)
+func testAccDataprocCluster_tags(rnd, tags string) string {
+	return fixtures.DataprocCluster{
+		ResourceName: "tags",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		Master:       fixtures.DataprocInstanceGroup{NumInstances: 1},
+		Worker:       &fixtures.DataprocInstanceGroup{NumInstances: 2},
+		GceClusterConfig: fmt.Sprintf(`			tags                   = %s
+			recreate_on_tag_change = false`, tags),
+	}.Config()
 }
 
 func testAccDataprocCluster_withStagingBucketOnly(bucketName string) string {
@@ -808,64 +1467,27 @@ resource "google_storage_bucket" "bucket" {
 }
 
 func testAccDataprocCluster_withStagingBucketAndCluster(clusterName, bucketName string) string {
-	return fmt.Sprintf(`
-%s
-
-resource "google_dataproc_cluster" "with_bucket" {
-	name   = "%s"
-	region = "us-central1"
-
-	cluster_config {
-		staging_bucket = "${google_storage_bucket.bucket.name}"
-
-		# Keep the costs down with smallest config we can get away with
-		software_config {
-			override_properties = {
-				"dataproc:dataproc.allow.zero.workers" = "true"
-			}
-		}
-
-		master_config {
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-	}
-}`, testAccDataprocCluster_withStagingBucketOnly(bucketName), clusterName)
+	return testAccDataprocCluster_withStagingBucketOnly(bucketName) + fixtures.DataprocCluster{
+		ResourceName:  "with_bucket",
+		Name:          clusterName,
+		StagingBucket: "${google_storage_bucket.bucket.name}",
+	}.Config()
 }
 
 func testAccDataprocCluster_withLabels(rnd string) string {
-	return fmt.Sprintf(`
-resource "google_dataproc_cluster" "with_labels" {
-	name   = "dproc-cluster-test-%s"
-	region = "us-central1"
-
-	labels {
-		key1 = "value1"
-	}
-
-	# This is because GCP automatically adds its own labels as well.
-	# In this case we just want to test our newly added label is there
-	lifecycle {
-	    ignore_changes = ["labels"]
-	}
-
-}`, rnd)
+	return fixtures.DataprocCluster{
+		ResourceName: "with_labels",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		Labels:       map[string]string{"key1": "value1"},
+	}.Config()
 }
 
 func testAccDataprocCluster_withImageVersion(rnd string) string {
-	return fmt.Sprintf(`
-resource "google_dataproc_cluster" "with_image_version" {
-	name   = "dproc-cluster-test-%s"
-	region = "us-central1"
-
-	cluster_config {
-		software_config {
-			image_version = "preview"
-		}
-	}
-}`, rnd)
+	return fixtures.DataprocCluster{
+		ResourceName: "with_image_version",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		ImageVersion: "preview",
+	}.Config()
 }
 
 func testAccDataprocCluster_withServiceAcc(sa string, rnd string) string {
@@ -878,26 +1500,11 @@ resource "google_project_iam_member" "service_account" {
 	role = "roles/dataproc.worker"
 	member = "serviceAccount:${google_service_account.service_account.email}"
 }
-
-resource "google_dataproc_cluster" "with_service_account" {
-	name   = "dproc-cluster-test-%s"
-	region = "us-central1"
-
-	cluster_config {
-		# Keep the costs down with smallest config we can get away with
-		software_config {
-			override_properties = {
-				"dataproc:dataproc.allow.zero.workers" = "true"
-			}
-		}
-
-		master_config {
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-
+`, sa) + fixtures.DataprocCluster{
+		ResourceName: "with_service_account",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		DependsOn:    []string{"google_project_iam_member.service_account"},
+		ClusterConfig: `
 		gce_cluster_config {
 			service_account = "${google_service_account.service_account.email}"
 			service_account_scopes = [
@@ -911,12 +1518,106 @@ resource "google_dataproc_cluster" "with_service_account" {
 				#		logging-write:   https://www.googleapis.com/auth/logging.write
 				"useraccounts-ro","storage-rw","logging-write"
 			]
-		}
+		}`,
+	}.Config()
+}
+
+func testAccDataprocCluster_sharedVpcSubnetwork(rnd, hostProject, serviceProject, org, billing string) string {
+	return fmt.Sprintf(`
+resource "google_project" "host" {
+	project_id      = "%s"
+	name            = "%s"
+	org_id          = "%s"
+	billing_account = "%s"
+}
+
+resource "google_project" "service" {
+	project_id      = "%s"
+	name            = "%s"
+	org_id          = "%s"
+	billing_account = "%s"
+}
+
+resource "google_project_services" "host" {
+	project  = "${google_project.host.project_id}"
+	services = ["compute.googleapis.com"]
+}
+
+resource "google_project_services" "service" {
+	project  = "${google_project.service.project_id}"
+	services = ["compute.googleapis.com", "dataproc.googleapis.com", "storage-api.googleapis.com"]
+}
+
+resource "google_compute_shared_vpc_host_project" "host" {
+	project    = "${google_project.host.project_id}"
+	depends_on = ["google_project_services.host"]
+}
+
+resource "google_compute_shared_vpc_service_project" "service" {
+	host_project    = "${google_project.host.project_id}"
+	service_project = "${google_project.service.project_id}"
+	depends_on      = ["google_compute_shared_vpc_host_project.host", "google_project_services.service"]
+}
+
+resource "google_compute_network" "shared" {
+	project                 = "${google_project.host.project_id}"
+	name                    = "dproc-shared-%s"
+	auto_create_subnetworks = false
+	depends_on              = ["google_project_services.host"]
+}
+
+resource "google_compute_subnetwork" "shared" {
+	project       = "${google_project.host.project_id}"
+	name          = "dproc-shared-%s"
+	region        = "us-central1"
+	network       = "${google_compute_network.shared.self_link}"
+	ip_cidr_range = "10.10.0.0/20"
+}
+
+resource "google_compute_firewall" "shared" {
+	project = "${google_project.host.project_id}"
+	name    = "dproc-shared-%s-allow-internal"
+	network = "${google_compute_network.shared.name}"
+
+	source_ranges = ["10.10.0.0/20"]
+
+	allow {
+		protocol = "tcp"
+		ports    = ["0-65535"]
 	}
 
-	depends_on = ["google_project_iam_member.service_account"]
+	allow {
+		protocol = "udp"
+		ports    = ["0-65535"]
+	}
+}
+
+# The service project's Google APIs and Dataproc service accounts create the
+# cluster's instances in the host project's subnetwork.
+resource "google_project_iam_member" "cloudservices" {
+	project = "${google_project.host.project_id}"
+	role    = "roles/compute.networkUser"
+	member  = "serviceAccount:${google_project.service.number}@cloudservices.gserviceaccount.com"
+}
 
-}`, sa, rnd)
+resource "google_project_iam_member" "dataproc" {
+	project = "${google_project.host.project_id}"
+	role    = "roles/compute.networkUser"
+	member  = "serviceAccount:service-${google_project.service.number}@dataproc-accounts.iam.gserviceaccount.com"
+}
+
+`, hostProject, hostProject, org, billing, serviceProject, serviceProject, org, billing, rnd, rnd, rnd) + fixtures.DataprocCluster{
+		ResourceName: "shared_vpc",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s", rnd),
+		Project:      "${google_project.service.project_id}",
+		Subnetwork:   "${google_compute_subnetwork.shared.self_link}",
+		DependsOn: []string{
+			"google_compute_shared_vpc_service_project.service",
+			"google_compute_firewall.shared",
+			"google_project_iam_member.cloudservices",
+			"google_project_iam_member.dataproc",
+		},
+	}.Config()
 }
 
 func testAccDataprocCluster_withNetworkRefs(rnd, netName string) string {
@@ -953,57 +1654,15 @@ resource "google_compute_firewall" "dataproc_network_firewall" {
 	}
 }
 
-resource "google_dataproc_cluster" "with_net_ref_by_name" {
-	name   = "dproc-cluster-test-%s-name"
-	region = "us-central1"
-	depends_on = ["google_compute_firewall.dataproc_network_firewall"]
-
-	cluster_config {
-		# Keep the costs down with smallest config we can get away with
-		software_config {
-			override_properties = {
-				"dataproc:dataproc.allow.zero.workers" = "true"
-			}
-		}
-
-		master_config {
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-
-		gce_cluster_config {
-			network = "${google_compute_network.dataproc_network.name}"
-		}
-	}
-}
-
-resource "google_dataproc_cluster" "with_net_ref_by_url" {
-	name   = "dproc-cluster-test-%s-url"
-	region = "us-central1"
-	depends_on = ["google_compute_firewall.dataproc_network_firewall"]
-
-	cluster_config {
-		# Keep the costs down with smallest config we can get away with
-		software_config {
-			override_properties = {
-				"dataproc:dataproc.allow.zero.workers" = "true"
-			}
-		}
-
-		master_config {
-			machine_type      = "n1-standard-1"
-			disk_config {
-				boot_disk_size_gb = 10
-			}
-		}
-
-		gce_cluster_config {
-			network = "${google_compute_network.dataproc_network.self_link}"
-		}
-	}
-}
-
-`, netName, rnd, rnd, rnd)
+`, netName, rnd) + fixtures.DataprocCluster{
+		ResourceName: "with_net_ref_by_name",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s-name", rnd),
+		Network:      "${google_compute_network.dataproc_network.name}",
+		DependsOn:    []string{"google_compute_firewall.dataproc_network_firewall"},
+	}.Config() + fixtures.DataprocCluster{
+		ResourceName: "with_net_ref_by_url",
+		Name:         fmt.Sprintf("dproc-cluster-test-%s-url", rnd),
+		Network:      "${google_compute_network.dataproc_network.self_link}",
+		DependsOn:    []string{"google_compute_firewall.dataproc_network_firewall"},
+	}.Config()
 }
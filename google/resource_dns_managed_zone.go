@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"google.golang.org/api/dns/v1"
 )
 
@@ -12,6 +13,7 @@ func resourceDnsManagedZone() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDnsManagedZoneCreate,
 		Read:   resourceDnsManagedZoneRead,
+		Update: resourceDnsManagedZoneUpdate,
 		Delete: resourceDnsManagedZoneDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -51,6 +53,37 @@ func resourceDnsManagedZone() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+
+			"visibility": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "public",
+				ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+			},
+
+			"private_visibility_config": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"networks": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							Set:      dnsManagedZonePrivateVisibilityNetworkHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"network_url": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -77,7 +110,14 @@ func resourceDnsManagedZoneCreate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	log.Printf("[DEBUG] DNS ManagedZone create request: %#v", zone)
-	zone, err = config.clientDns.ManagedZones.Create(project, zone).Do()
+	if d.Get("visibility").(string) == "private" {
+		zone, err = createPrivateDnsManagedZone(config, project, zone, expandDnsManagedZonePrivateVisibilityConfig(d.Get("private_visibility_config").([]interface{})))
+	} else {
+		if _, ok := d.GetOk("private_visibility_config"); ok {
+			return fmt.Errorf("private_visibility_config can only be set on private zones")
+		}
+		zone, err = config.clientDns.ManagedZones.Create(project, zone).Do()
+	}
 	if err != nil {
 		return fmt.Errorf("Error creating DNS ManagedZone: %s", err)
 	}
@@ -106,9 +146,44 @@ func resourceDnsManagedZoneRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("dns_name", zone.DnsName)
 	d.Set("description", zone.Description)
 
+	visibility, err := getDnsManagedZoneVisibility(config, project, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading visibility of DNS ManagedZone: %s", err)
+	}
+	d.Set("visibility", visibility.Visibility)
+	if err := d.Set("private_visibility_config", flattenDnsManagedZonePrivateVisibilityConfig(visibility.PrivateVisibilityConfig)); err != nil {
+		return fmt.Errorf("Error setting private_visibility_config in state: %s", err)
+	}
+
 	return nil
 }
 
+func resourceDnsManagedZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("private_visibility_config") {
+		if d.Get("visibility").(string) != "private" {
+			return fmt.Errorf("private_visibility_config can only be set on private zones")
+		}
+
+		body := map[string]interface{}{
+			"privateVisibilityConfig": expandDnsManagedZonePrivateVisibilityConfig(d.Get("private_visibility_config").([]interface{})),
+		}
+		log.Printf("[DEBUG] DNS ManagedZone patch request: %#v", body)
+		err = sendJsonRequest(config.client, config.clientDns.UserAgent, "PATCH", config.clientDns.BasePath+project+"/managedZones/"+d.Id(), body, nil)
+		if err != nil {
+			return fmt.Errorf("Error updating DNS ManagedZone: %s", err)
+		}
+	}
+
+	return resourceDnsManagedZoneRead(d, meta)
+}
+
 func resourceDnsManagedZoneDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -125,3 +200,87 @@ func resourceDnsManagedZoneDelete(d *schema.ResourceData, meta interface{}) erro
 	d.SetId("")
 	return nil
 }
+
+// The vendored dns client predates private zones, so private zones are
+// created as raw JSON, and the visibility is read back separately.
+
+type dnsManagedZoneVisibility struct {
+	Visibility              string                                 `json:"visibility,omitempty"`
+	PrivateVisibilityConfig *dnsManagedZonePrivateVisibilityConfig `json:"privateVisibilityConfig,omitempty"`
+}
+
+type dnsManagedZonePrivateVisibilityConfig struct {
+	Networks []*dnsManagedZonePrivateVisibilityConfigNetwork `json:"networks"`
+}
+
+type dnsManagedZonePrivateVisibilityConfigNetwork struct {
+	NetworkUrl string `json:"networkUrl,omitempty"`
+}
+
+func createPrivateDnsManagedZone(config *Config, project string, zone *dns.ManagedZone, privateVisibilityConfig *dnsManagedZonePrivateVisibilityConfig) (*dns.ManagedZone, error) {
+	body, err := toJsonMap(zone)
+	if err != nil {
+		return nil, err
+	}
+	body["visibility"] = "private"
+	if privateVisibilityConfig != nil {
+		body["privateVisibilityConfig"] = privateVisibilityConfig
+	}
+
+	res := &dns.ManagedZone{}
+	err = sendJsonRequest(config.client, config.clientDns.UserAgent, "POST", config.clientDns.BasePath+project+"/managedZones", body, res)
+	return res, err
+}
+
+func getDnsManagedZoneVisibility(config *Config, project, name string) (*dnsManagedZoneVisibility, error) {
+	res := &dnsManagedZoneVisibility{}
+	err := sendJsonRequest(config.client, config.clientDns.UserAgent, "GET", config.clientDns.BasePath+project+"/managedZones/"+name, nil, res)
+	if err != nil {
+		return nil, err
+	}
+
+	// Zones created before private zones existed have no visibility set.
+	if res.Visibility == "" {
+		res.Visibility = "public"
+	}
+	return res, nil
+}
+
+func expandDnsManagedZonePrivateVisibilityConfig(configured []interface{}) *dnsManagedZonePrivateVisibilityConfig {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+	raw := configured[0].(map[string]interface{})
+
+	config := &dnsManagedZonePrivateVisibilityConfig{
+		Networks: make([]*dnsManagedZonePrivateVisibilityConfigNetwork, 0),
+	}
+	for _, v := range raw["networks"].(*schema.Set).List() {
+		network := v.(map[string]interface{})
+		config.Networks = append(config.Networks, &dnsManagedZonePrivateVisibilityConfigNetwork{
+			NetworkUrl: network["network_url"].(string),
+		})
+	}
+	return config
+}
+
+func flattenDnsManagedZonePrivateVisibilityConfig(config *dnsManagedZonePrivateVisibilityConfig) []map[string]interface{} {
+	if config == nil || len(config.Networks) == 0 {
+		return nil
+	}
+
+	networks := schema.NewSet(dnsManagedZonePrivateVisibilityNetworkHash, []interface{}{})
+	for _, network := range config.Networks {
+		networks.Add(map[string]interface{}{
+			"network_url": network.NetworkUrl,
+		})
+	}
+	return []map[string]interface{}{
+		{"networks": networks},
+	}
+}
+
+func dnsManagedZonePrivateVisibilityNetworkHash(v interface{}) int {
+	raw := v.(map[string]interface{})
+	return selfLinkRelativePathHash(raw["network_url"])
+}
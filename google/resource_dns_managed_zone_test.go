@@ -31,6 +31,49 @@ func TestAccDnsManagedZone_basic(t *testing.T) {
 	})
 }
 
+func TestAccDnsManagedZone_private(t *testing.T) {
+	t.Parallel()
+
+	var zone dns.ManagedZone
+
+	zoneName := fmt.Sprintf("mzone-test-%s", acctest.RandString(10))
+	network1 := fmt.Sprintf("network-test-%s", acctest.RandString(10))
+	network2 := fmt.Sprintf("network-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDnsManagedZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDnsManagedZone_private(zoneName, network1, network2, "network-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDnsManagedZoneExists(
+						"google_dns_managed_zone.private", &zone),
+					resource.TestCheckResourceAttr(
+						"google_dns_managed_zone.private", "visibility", "private"),
+					resource.TestCheckResourceAttr(
+						"google_dns_managed_zone.private", "private_visibility_config.0.networks.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDnsManagedZone_private(zoneName, network1, network2, "network-2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDnsManagedZoneExists(
+						"google_dns_managed_zone.private", &zone),
+					resource.TestCheckResourceAttr(
+						"google_dns_managed_zone.private", "private_visibility_config.0.networks.#", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_dns_managed_zone.private",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckDnsManagedZoneDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -83,3 +126,27 @@ resource "google_dns_managed_zone" "foobar" {
 	name = "mzone-test-%s"
 	dns_name = "hashicorptest.com."
 }`, acctest.RandString(10))
+
+func testAccDnsManagedZone_private(zoneName, network1, network2, network string) string {
+	return fmt.Sprintf(`
+resource "google_dns_managed_zone" "private" {
+	name = "%s"
+	dns_name = "private.hashicorptest.com."
+	visibility = "private"
+	private_visibility_config {
+		networks {
+			network_url = "${google_compute_network.%s.self_link}"
+		}
+	}
+}
+
+resource "google_compute_network" "network-1" {
+	name = "%s"
+	auto_create_subnetworks = false
+}
+
+resource "google_compute_network" "network-2" {
+	name = "%s"
+	auto_create_subnetworks = false
+}`, zoneName, network, network1, network2)
+}
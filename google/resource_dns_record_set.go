@@ -3,6 +3,9 @@ package google
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"google.golang.org/api/dns/v1"
@@ -14,6 +17,9 @@ func resourceDnsRecordSet() *schema.Resource {
 		Read:   resourceDnsRecordSetRead,
 		Delete: resourceDnsRecordSetDelete,
 		Update: resourceDnsRecordSetUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceDnsRecordSetImportState,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"managed_zone": &schema.Schema{
@@ -34,6 +40,7 @@ func resourceDnsRecordSet() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
+				DiffSuppressFunc: rrdatasDnsDiffSuppress,
 			},
 
 			"ttl": &schema.Schema{
@@ -50,6 +57,7 @@ func resourceDnsRecordSet() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
+				Computed: true,
 			},
 		},
 	}
@@ -110,7 +118,7 @@ func resourceDnsRecordSetCreate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating DNS RecordSet: %s", err)
 	}
 
-	d.SetId(chg.Id)
+	d.SetId(fmt.Sprintf("%s/%s/%s", zone, d.Get("name").(string), d.Get("type").(string)))
 
 	w := &DnsChangeWaiter{
 		Service:     config.clientDns,
@@ -157,6 +165,7 @@ func resourceDnsRecordSetRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("ttl", resp.Rrsets[0].Ttl)
 	d.Set("rrdatas", resp.Rrsets[0].Rrdatas)
+	d.Set("project", project)
 
 	return nil
 }
@@ -269,6 +278,8 @@ func resourceDnsRecordSetUpdate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error waiting for Google DNS change: %s", err)
 	}
 
+	d.SetId(fmt.Sprintf("%s/%s/%s", zone, recordName, newType.(string)))
+
 	return resourceDnsRecordSetRead(d, meta)
 }
 
@@ -282,3 +293,35 @@ func rrdata(
 	}
 	return data
 }
+
+func resourceDnsRecordSetImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Invalid DNS record set specifier %q, expected {managed_zone}/{name}/{type}", d.Id())
+	}
+
+	d.Set("managed_zone", parts[0])
+	d.Set("name", parts[1])
+	d.Set("type", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// The API may return rrdatas in a different order than they were sent, and
+// quotes TXT data, so rrdatas are compared ignoring both.
+func rrdatasDnsDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	o, n := d.GetChange("rrdatas")
+	oldRrdatas := normalizeDnsRrdatas(convertStringArr(o.([]interface{})))
+	newRrdatas := normalizeDnsRrdatas(convertStringArr(n.([]interface{})))
+
+	return reflect.DeepEqual(oldRrdatas, newRrdatas)
+}
+
+func normalizeDnsRrdatas(rrdatas []string) []string {
+	normalized := make([]string, 0, len(rrdatas))
+	for _, rrdata := range rrdatas {
+		normalized = append(normalized, strings.Trim(rrdata, `"`))
+	}
+	sort.Strings(normalized)
+	return normalized
+}
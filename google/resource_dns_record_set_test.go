@@ -2,6 +2,7 @@ package google
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -9,6 +10,46 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func TestNormalizeDnsRrdatas(t *testing.T) {
+	cases := map[string]struct {
+		Old, New []string
+		Equal    bool
+	}{
+		"same order": {
+			Old:   []string{"127.0.0.1", "127.0.0.2"},
+			New:   []string{"127.0.0.1", "127.0.0.2"},
+			Equal: true,
+		},
+		"different order": {
+			Old:   []string{"127.0.0.2", "127.0.0.1"},
+			New:   []string{"127.0.0.1", "127.0.0.2"},
+			Equal: true,
+		},
+		"quoted TXT": {
+			Old:   []string{`"v=spf1 -all"`},
+			New:   []string{"v=spf1 -all"},
+			Equal: true,
+		},
+		"different values": {
+			Old:   []string{"127.0.0.1", "127.0.0.2"},
+			New:   []string{"127.0.0.1", "127.0.0.3"},
+			Equal: false,
+		},
+		"different lengths": {
+			Old:   []string{"127.0.0.1"},
+			New:   []string{"127.0.0.1", "127.0.0.1"},
+			Equal: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		equal := reflect.DeepEqual(normalizeDnsRrdatas(tc.Old), normalizeDnsRrdatas(tc.New))
+		if equal != tc.Equal {
+			t.Errorf("%s: expected equal to be %t, got %t", tn, tc.Equal, equal)
+		}
+	}
+}
+
 func TestAccDnsRecordSet_basic(t *testing.T) {
 	t.Parallel()
 
@@ -25,6 +66,12 @@ func TestAccDnsRecordSet_basic(t *testing.T) {
 						"google_dns_record_set.foobar", zoneName),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "google_dns_record_set.foobar",
+				ImportStateId:     fmt.Sprintf("%s/test-record.hashicorptest.com./A", zoneName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,141 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	resourceManagerV2Beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+)
+
+func resourceGoogleFolderIamBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleFolderIamBindingCreate,
+		Read:   resourceGoogleFolderIamBindingRead,
+		Update: resourceGoogleFolderIamBindingUpdate,
+		Delete: resourceGoogleFolderIamBindingDelete,
+
+		Schema: map[string]*schema.Schema{
+			"folder": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleFolderIamBindingCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	folder := d.Get("folder").(string)
+
+	binding := getFolderIamBinding(d)
+	err := folderIamPolicyReadModifyWrite(config, folder, func(p *resourceManagerV2Beta1.Policy) error {
+		p.Bindings = setFolderIamBinding(p.Bindings, binding)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(folder + "/" + binding.Role)
+	return resourceGoogleFolderIamBindingRead(d, meta)
+}
+
+func resourceGoogleFolderIamBindingRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	folder := d.Get("folder").(string)
+	role := d.Get("role").(string)
+
+	p, err := config.clientResourceManagerV2Beta1.Folders.GetIamPolicy(folder, &resourceManagerV2Beta1.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM binding for role %q on folder %q", role, folder))
+	}
+
+	var binding *resourceManagerV2Beta1.Binding
+	for _, b := range p.Bindings {
+		if b.Role == role {
+			binding = b
+			break
+		}
+	}
+	if binding == nil {
+		log.Printf("[DEBUG]: Binding for role %q not found in policy for folder %q, removing from state file.\n", role, folder)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("etag", p.Etag)
+	d.Set("members", binding.Members)
+	return nil
+}
+
+func resourceGoogleFolderIamBindingUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	folder := d.Get("folder").(string)
+
+	binding := getFolderIamBinding(d)
+	err := folderIamPolicyReadModifyWrite(config, folder, func(p *resourceManagerV2Beta1.Policy) error {
+		p.Bindings = setFolderIamBinding(p.Bindings, binding)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceGoogleFolderIamBindingRead(d, meta)
+}
+
+func resourceGoogleFolderIamBindingDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	folder := d.Get("folder").(string)
+	role := d.Get("role").(string)
+
+	err := folderIamPolicyReadModifyWrite(config, folder, func(p *resourceManagerV2Beta1.Policy) error {
+		p.Bindings = setFolderIamBinding(p.Bindings, &resourceManagerV2Beta1.Binding{Role: role})
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM binding for role %q on folder %q", role, folder))
+	}
+
+	return nil
+}
+
+func getFolderIamBinding(d *schema.ResourceData) *resourceManagerV2Beta1.Binding {
+	return &resourceManagerV2Beta1.Binding{
+		Role:    d.Get("role").(string),
+		Members: convertStringArr(d.Get("members").(*schema.Set).List()),
+	}
+}
+
+// setFolderIamBinding replaces the binding for the role of binding
+// in bindings, removing it altogether if binding has no members.
+func setFolderIamBinding(bindings []*resourceManagerV2Beta1.Binding, binding *resourceManagerV2Beta1.Binding) []*resourceManagerV2Beta1.Binding {
+	result := make([]*resourceManagerV2Beta1.Binding, 0, len(bindings)+1)
+	for _, b := range bindings {
+		if b.Role != binding.Role {
+			result = append(result, b)
+		}
+	}
+	if len(binding.Members) > 0 {
+		result = append(result, binding)
+	}
+	return result
+}
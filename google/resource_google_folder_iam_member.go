@@ -0,0 +1,134 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	resourceManagerV2Beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+)
+
+func resourceGoogleFolderIamMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleFolderIamMemberCreate,
+		Read:   resourceGoogleFolderIamMemberRead,
+		Delete: resourceGoogleFolderIamMemberDelete,
+
+		Schema: map[string]*schema.Schema{
+			"folder": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"member": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleFolderIamMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	folder := d.Get("folder").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	err := folderIamPolicyReadModifyWrite(config, folder, func(p *resourceManagerV2Beta1.Policy) error {
+		p.Bindings = addFolderIamMember(p.Bindings, role, member)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(folder + "/" + role + "/" + member)
+	return resourceGoogleFolderIamMemberRead(d, meta)
+}
+
+func resourceGoogleFolderIamMemberRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	folder := d.Get("folder").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	p, err := config.clientResourceManagerV2Beta1.Folders.GetIamPolicy(folder, &resourceManagerV2Beta1.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM member %q for role %q on folder %q", member, role, folder))
+	}
+
+	for _, b := range p.Bindings {
+		if b.Role == role && stringInSlice(b.Members, member) {
+			d.Set("etag", p.Etag)
+			return nil
+		}
+	}
+
+	log.Printf("[DEBUG]: Member %q for role %q not found in policy for folder %q, removing from state file.\n", member, role, folder)
+	d.SetId("")
+	return nil
+}
+
+func resourceGoogleFolderIamMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	folder := d.Get("folder").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	err := folderIamPolicyReadModifyWrite(config, folder, func(p *resourceManagerV2Beta1.Policy) error {
+		p.Bindings = removeFolderIamMember(p.Bindings, role, member)
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM member %q for role %q on folder %q", member, role, folder))
+	}
+
+	return nil
+}
+
+func addFolderIamMember(bindings []*resourceManagerV2Beta1.Binding, role, member string) []*resourceManagerV2Beta1.Binding {
+	for _, b := range bindings {
+		if b.Role == role {
+			if !stringInSlice(b.Members, member) {
+				b.Members = append(b.Members, member)
+			}
+			return bindings
+		}
+	}
+	return append(bindings, &resourceManagerV2Beta1.Binding{
+		Role:    role,
+		Members: []string{member},
+	})
+}
+
+// removeFolderIamMember removes member from the binding for role,
+// dropping the binding once it has no members left.
+func removeFolderIamMember(bindings []*resourceManagerV2Beta1.Binding, role, member string) []*resourceManagerV2Beta1.Binding {
+	result := make([]*resourceManagerV2Beta1.Binding, 0, len(bindings))
+	for _, b := range bindings {
+		if b.Role == role {
+			members := make([]string, 0, len(b.Members))
+			for _, m := range b.Members {
+				if m != member {
+					members = append(members, m)
+				}
+			}
+			if len(members) == 0 {
+				continue
+			}
+			b.Members = members
+		}
+		result = append(result, b)
+	}
+	return result
+}
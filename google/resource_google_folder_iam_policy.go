@@ -5,6 +5,9 @@ import (
 
 	"encoding/json"
 	"fmt"
+	"log"
+	"time"
+
 	resourceManagerV2Beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
 )
 
@@ -117,6 +120,9 @@ func unmarshalV2IamPolicy(policyData string) (*resourceManagerV2Beta1.Policy, er
 	if err := json.Unmarshal([]byte(policyData), policy); err != nil {
 		return nil, fmt.Errorf("Could not unmarshal policy data %s:\n%s", policyData, err)
 	}
+	if err := checkIamPolicyUnconditional(policyData); err != nil {
+		return nil, err
+	}
 	return policy, nil
 }
 
@@ -127,3 +133,53 @@ func validateV2IamPolicy(i interface{}, k string) (s []string, es []error) {
 	}
 	return
 }
+
+type folderIamPolicyModifyFunc func(p *resourceManagerV2Beta1.Policy) error
+
+// folderIamPolicyReadModifyWrite applies modify to the current IAM policy of
+// folder. Changes made by the folder IAM binding and member resources are
+// serialized per folder, and the whole read-modify-write is restarted if the
+// policy was changed by someone else in the meantime.
+func folderIamPolicyReadModifyWrite(config *Config, folder string, modify folderIamPolicyModifyFunc) error {
+	mutexKV.Lock(folderIamMutexKey(folder))
+	defer mutexKV.Unlock(folderIamMutexKey(folder))
+
+	backoff := time.Second
+	for {
+		log.Printf("[DEBUG]: Retrieving IAM policy for folder %q\n", folder)
+		p, err := config.clientResourceManagerV2Beta1.Folders.GetIamPolicy(folder, &resourceManagerV2Beta1.GetIamPolicyRequest{}).Do()
+		if err != nil {
+			return err
+		}
+
+		if err := modify(p); err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG]: Setting IAM policy for folder %q to %+v\n", folder, p)
+		_, err = config.clientResourceManagerV2Beta1.Folders.SetIamPolicy(folder, &resourceManagerV2Beta1.SetIamPolicyRequest{
+			Policy:     p,
+			UpdateMask: "bindings,etag",
+		}).Do()
+		if err == nil {
+			break
+		}
+		if isConflictError(err) {
+			log.Printf("[DEBUG]: Concurrent policy changes, restarting read-modify-write after %s\n", backoff)
+			time.Sleep(backoff)
+			backoff = backoff * 2
+			if backoff > 30*time.Second {
+				return fmt.Errorf("Error applying IAM policy to folder %q: too many concurrent policy changes", folder)
+			}
+			continue
+		}
+		return fmt.Errorf("Error applying IAM policy to folder %q: %s", folder, err)
+	}
+	log.Printf("[DEBUG]: Set IAM policy for folder %q\n", folder)
+
+	return nil
+}
+
+func folderIamMutexKey(folder string) string {
+	return fmt.Sprintf("google-folder-iam-%s", folder)
+}
@@ -0,0 +1,169 @@
+package google
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	resourceManagerV2Beta1 "google.golang.org/api/cloudresourcemanager/v2beta1"
+)
+
+func TestFolderIamBindings(t *testing.T) {
+	t.Parallel()
+
+	bindings := func() []*resourceManagerV2Beta1.Binding {
+		return []*resourceManagerV2Beta1.Binding{
+			{Role: "roles/resourcemanager.folderViewer", Members: []string{"user:a@example.com"}},
+			{Role: "roles/resourcemanager.projectCreator", Members: []string{"user:a@example.com", "user:b@example.com"}},
+		}
+	}
+
+	actual := addFolderIamMember(bindings(), "roles/resourcemanager.projectCreator", "user:c@example.com")
+	expected := []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}
+	if !reflect.DeepEqual(actual[1].Members, expected) {
+		t.Errorf("Expected members %v after adding, got %v", expected, actual[1].Members)
+	}
+
+	actual = removeFolderIamMember(bindings(), "roles/resourcemanager.folderViewer", "user:a@example.com")
+	if len(actual) != 1 || actual[0].Role != "roles/resourcemanager.projectCreator" {
+		t.Errorf("Expected the emptied binding to be removed, got %+v", actual)
+	}
+
+	actual = setFolderIamBinding(bindings(), &resourceManagerV2Beta1.Binding{
+		Role:    "roles/resourcemanager.projectCreator",
+		Members: []string{"user:c@example.com"},
+	})
+	if len(actual) != 2 || !reflect.DeepEqual(actual[1].Members, []string{"user:c@example.com"}) {
+		t.Errorf("Expected the binding to be replaced, got %+v", actual)
+	}
+}
+
+func TestAccGoogleFolderIamBinding(t *testing.T) {
+	t.Parallel()
+
+	skipIfEnvNotSet(t, "GOOGLE_ORG")
+
+	folder := "tf-test-" + acctest.RandString(10)
+	account := "tf-test-" + acctest.RandString(10)
+	parent := "organizations/" + os.Getenv("GOOGLE_ORG")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGoogleFolderIamBinding(folder, parent, account, false),
+				Check: testAccCheckGoogleFolderIam("google_folder.folder", "roles/resourcemanager.folderViewer", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+			{
+				Config: testAccGoogleFolderIamBinding(folder, parent, account, true),
+				Check: testAccCheckGoogleFolderIam("google_folder.folder", "roles/resourcemanager.folderViewer", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+					fmt.Sprintf("serviceAccount:%s-2@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+		},
+	})
+}
+
+func TestAccGoogleFolderIamMember(t *testing.T) {
+	t.Parallel()
+
+	skipIfEnvNotSet(t, "GOOGLE_ORG")
+
+	folder := "tf-test-" + acctest.RandString(10)
+	account := "tf-test-" + acctest.RandString(10)
+	parent := "organizations/" + os.Getenv("GOOGLE_ORG")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGoogleFolderIamMember(folder, parent, account),
+				Check: testAccCheckGoogleFolderIam("google_folder.folder", "roles/resourcemanager.folderViewer", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+		},
+	})
+}
+
+func testAccCheckGoogleFolderIam(n, role string, members []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		p, err := config.clientResourceManagerV2Beta1.Folders.GetIamPolicy(rs.Primary.ID, &resourceManagerV2Beta1.GetIamPolicyRequest{}).Do()
+		if err != nil {
+			return err
+		}
+
+		for _, b := range p.Bindings {
+			if b.Role != role {
+				continue
+			}
+
+			sort.Strings(members)
+			sort.Strings(b.Members)
+			if reflect.DeepEqual(members, b.Members) {
+				return nil
+			}
+			return fmt.Errorf("Binding for role %q has members %v, expected %v", role, b.Members, members)
+		}
+
+		return fmt.Errorf("No binding for role %q on folder %q", role, rs.Primary.ID)
+	}
+}
+
+func testAccGoogleFolderIamFolder(folder, parent, account string) string {
+	return fmt.Sprintf(`
+resource "google_folder" "folder" {
+	display_name = "%s"
+	parent       = "%s"
+}
+
+resource "google_service_account" "test_1" {
+	account_id   = "%s-1"
+	display_name = "Folder IAM test 1"
+}
+
+resource "google_service_account" "test_2" {
+	account_id   = "%s-2"
+	display_name = "Folder IAM test 2"
+}
+`, folder, parent, account, account)
+}
+
+func testAccGoogleFolderIamBinding(folder, parent, account string, both bool) string {
+	members := `"serviceAccount:${google_service_account.test_1.email}"`
+	if both {
+		members += `, "serviceAccount:${google_service_account.test_2.email}"`
+	}
+
+	return testAccGoogleFolderIamFolder(folder, parent, account) + fmt.Sprintf(`
+resource "google_folder_iam_binding" "binding" {
+	folder  = "${google_folder.folder.name}"
+	role    = "roles/resourcemanager.folderViewer"
+	members = [%s]
+}
+`, members)
+}
+
+func testAccGoogleFolderIamMember(folder, parent, account string) string {
+	return testAccGoogleFolderIamFolder(folder, parent, account) + `
+resource "google_folder_iam_member" "member" {
+	folder = "${google_folder.folder.name}"
+	role   = "roles/resourcemanager.folderViewer"
+	member = "serviceAccount:${google_service_account.test_1.email}"
+}
+`
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iam/v1"
 )
 
@@ -20,9 +21,10 @@ func resourceGoogleOrganizationIamCustomRole() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"role_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIAMCustomRoleID,
 			},
 			"org_id": {
 				Type:     schema.TypeString,
@@ -65,6 +67,25 @@ func resourceGoogleOrganizationIamCustomRoleCreate(d *schema.ResourceData, meta
 		return fmt.Errorf("Cannot create a custom organization role with a deleted state. `deleted` field should be false.")
 	}
 
+	// Custom roles are only soft-deleted for a while, during which their ID
+	// can't be reused. Bring a deleted role back instead, and update it.
+	roleName := fmt.Sprintf("organizations/%s/roles/%s", d.Get("org_id").(string), d.Get("role_id").(string))
+	existing, err := config.clientIAM.Organizations.Roles.Get(roleName).Do()
+	if err == nil {
+		if !existing.Deleted {
+			return fmt.Errorf("Custom organization role %s already exists and must be imported", roleName)
+		}
+
+		d.SetId(existing.Name)
+		if err := resourceGoogleOrganizationIamCustomRoleUndelete(d, meta); err != nil {
+			return err
+		}
+		return resourceGoogleOrganizationIamCustomRoleUpdate(d, meta)
+	}
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 404 {
+		return fmt.Errorf("Error checking for an existing custom organization role %s: %s", roleName, err)
+	}
+
 	role, err := config.clientIAM.Organizations.Roles.Create("organizations/"+d.Get("org_id").(string), &iam.CreateRoleRequest{
 		RoleId: d.Get("role_id").(string),
 		Role: &iam.Role{
@@ -145,7 +166,7 @@ func resourceGoogleOrganizationIamCustomRoleUpdate(d *schema.ResourceData, meta
 
 	d.Partial(false)
 
-	return nil
+	return resourceGoogleOrganizationIamCustomRoleRead(d, meta)
 }
 
 func resourceGoogleOrganizationIamCustomRoleDelete(d *schema.ResourceData, meta interface{}) error {
@@ -2,8 +2,11 @@ package google
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iam/v1"
 )
 
@@ -20,9 +23,10 @@ func resourceGoogleProjectIamCustomRole() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"role_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIAMCustomRoleID,
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -38,6 +42,7 @@ func resourceGoogleProjectIamCustomRole() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
+				Computed: true,
 			},
 			"stage": {
 				Type:         schema.TypeString,
@@ -70,6 +75,25 @@ func resourceGoogleProjectIamCustomRoleCreate(d *schema.ResourceData, meta inter
 		return fmt.Errorf("Cannot create a custom project role with a deleted state. `deleted` field should be false.")
 	}
 
+	// Custom roles are only soft-deleted for a while, during which their ID
+	// can't be reused. Bring a deleted role back instead, and update it.
+	roleName := fmt.Sprintf("projects/%s/roles/%s", project, d.Get("role_id").(string))
+	existing, err := config.clientIAM.Projects.Roles.Get(roleName).Do()
+	if err == nil {
+		if !existing.Deleted {
+			return fmt.Errorf("Custom project role %s already exists and must be imported", roleName)
+		}
+
+		d.SetId(existing.Name)
+		if err := resourceGoogleProjectIamCustomRoleUndelete(d, meta); err != nil {
+			return err
+		}
+		return resourceGoogleProjectIamCustomRoleUpdate(d, meta)
+	}
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 404 {
+		return fmt.Errorf("Error checking for an existing custom project role %s: %s", roleName, err)
+	}
+
 	role, err := config.clientIAM.Projects.Roles.Create("projects/"+project, &iam.CreateRoleRequest{
 		RoleId: d.Get("role_id").(string),
 		Role: &iam.Role{
@@ -98,6 +122,7 @@ func resourceGoogleProjectIamCustomRoleRead(d *schema.ResourceData, meta interfa
 	}
 
 	d.Set("role_id", GetResourceNameFromSelfLink(role.Name))
+	d.Set("project", strings.Split(role.Name, "/")[1])
 	d.Set("title", role.Title)
 	d.Set("description", role.Description)
 	d.Set("permissions", role.IncludedPermissions)
@@ -144,7 +169,7 @@ func resourceGoogleProjectIamCustomRoleUpdate(d *schema.ResourceData, meta inter
 
 	d.Partial(false)
 
-	return nil
+	return resourceGoogleProjectIamCustomRoleRead(d, meta)
 }
 
 func resourceGoogleProjectIamCustomRoleDelete(d *schema.ResourceData, meta interface{}) error {
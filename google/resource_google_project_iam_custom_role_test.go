@@ -70,6 +70,38 @@ func TestAccGoogleProjectIamCustomRole_undelete(t *testing.T) {
 	})
 }
 
+func TestAccGoogleProjectIamCustomRole_createAfterDestroy(t *testing.T) {
+	t.Parallel()
+
+	roleId := "tfIamCustomRole" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGoogleProjectIamCustomRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckGoogleProjectIamCustomRole_basic(roleId),
+				Check:  testAccCheckGoogleProjectIamCustomRoleDeletionStatus("google_project_iam_custom_role.foo", false),
+			},
+			{
+				Config:  testAccCheckGoogleProjectIamCustomRole_basic(roleId),
+				Destroy: true,
+			},
+			// Re-creating the soft-deleted role undeletes and updates it
+			{
+				Config: testAccCheckGoogleProjectIamCustomRole_update(roleId),
+				Check: testAccCheckGoogleProjectIamCustomRole(
+					"google_project_iam_custom_role.foo",
+					"My Custom Role Updated",
+					"bar",
+					"BETA",
+					[]string{"iam.roles.list", "iam.roles.create", "iam.roles.delete"}),
+			},
+		},
+	})
+}
+
 func testAccCheckGoogleProjectIamCustomRoleDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
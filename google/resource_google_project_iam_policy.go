@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/errwrap"
@@ -29,6 +31,7 @@ func resourceGoogleProjectIamPolicy() *schema.Resource {
 				Type:             schema.TypeString,
 				Required:         true,
 				DiffSuppressFunc: jsonPolicyDiffSuppress,
+				ValidateFunc:     validateIamPolicyUnconditional,
 			},
 			"authoritative": &schema.Schema{
 				Type:       schema.TypeBool,
@@ -286,6 +289,9 @@ func getResourceIamPolicy(d *schema.ResourceData) (*cloudresourcemanager.Policy,
 	if err := json.Unmarshal([]byte(ps), policy); err != nil {
 		return nil, fmt.Errorf("Could not unmarshal %s:\n: %v", ps, err)
 	}
+	if err := checkIamPolicyUnconditional(ps); err != nil {
+		return nil, err
+	}
 	return policy, nil
 }
 
@@ -379,6 +385,56 @@ func mergeBindings(bindings []*cloudresourcemanager.Binding) []*cloudresourceman
 	return rb
 }
 
+// checkIamPolicyUnconditional returns an error if policyData has a binding
+// with an IAM Condition. The vendored cloudresourcemanager Binding types have
+// no condition, so sending such a binding through them would drop the
+// condition and grant the role unconditionally.
+func checkIamPolicyUnconditional(policyData string) error {
+	var p iamPolicy
+	if err := json.Unmarshal([]byte(policyData), &p); err != nil {
+		// Invalid JSON is reported by the caller's own unmarshalling.
+		return nil
+	}
+	for _, b := range p.Bindings {
+		if b.Condition != nil {
+			return fmt.Errorf("The binding for %s has a condition, which is not supported by this resource", b.Role)
+		}
+	}
+	return nil
+}
+
+func validateIamPolicyUnconditional(i interface{}, k string) (s []string, es []error) {
+	if err := checkIamPolicyUnconditional(i.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
+
+// splitIamConditionalBindings removes the bindings that carry an IAM Condition
+// from policy, which was unmarshalled from policyData, and returns them as
+// sorted strings that are equal for equivalent bindings.
+func splitIamConditionalBindings(policy *cloudresourcemanager.Policy, policyData string) []string {
+	var p iamPolicy
+	if err := json.Unmarshal([]byte(policyData), &p); err != nil || len(p.Bindings) != len(policy.Bindings) {
+		return nil
+	}
+
+	conditional := make([]string, 0)
+	bindings := make([]*cloudresourcemanager.Binding, 0, len(policy.Bindings))
+	for i, b := range p.Bindings {
+		if b.Condition == nil {
+			bindings = append(bindings, policy.Bindings[i])
+			continue
+		}
+		members := append([]string{}, b.Members...)
+		sort.Strings(members)
+		conditional = append(conditional, fmt.Sprintf("%s %s %+v", b.Role, strings.Join(members, ","), *b.Condition))
+	}
+	sort.Strings(conditional)
+	policy.Bindings = bindings
+	return conditional
+}
+
 func jsonPolicyDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 	var oldPolicy, newPolicy cloudresourcemanager.Policy
 	if err := json.Unmarshal([]byte(old), &oldPolicy); err != nil {
@@ -389,6 +445,13 @@ func jsonPolicyDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 		log.Printf("[ERROR] Could not unmarshal new policy %s: %v", new, err)
 		return false
 	}
+	// cloudresourcemanager.Binding has no condition, so conditional bindings
+	// are compared on their own and left out of the merge below.
+	oldConditional := splitIamConditionalBindings(&oldPolicy, old)
+	newConditional := splitIamConditionalBindings(&newPolicy, new)
+	if !reflect.DeepEqual(oldConditional, newConditional) {
+		return false
+	}
 	oldPolicy.Bindings = mergeBindings(oldPolicy.Bindings)
 	newPolicy.Bindings = mergeBindings(newPolicy.Bindings)
 	if newPolicy.Etag != oldPolicy.Etag {
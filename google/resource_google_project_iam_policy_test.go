@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -767,3 +768,59 @@ data "google_iam_policy" "expanded" {
     }
 }`, pid, name, org)
 }
+
+func TestCheckIamPolicyUnconditional(t *testing.T) {
+	unconditional := `{"bindings":[{"members":["user:a"],"role":"roles/viewer"}]}`
+	if err := checkIamPolicyUnconditional(unconditional); err != nil {
+		t.Errorf("Expected no error for %s, got %s", unconditional, err)
+	}
+
+	conditional := `{"bindings":[{"members":["user:a"],"role":"roles/viewer"},{"condition":{"expression":"true","title":"t"},"members":["user:b"],"role":"roles/editor"}]}`
+	err := checkIamPolicyUnconditional(conditional)
+	if err == nil || !strings.Contains(err.Error(), "roles/editor has a condition") {
+		t.Errorf("Expected an error about the roles/editor condition for %s, got %v", conditional, err)
+	}
+
+	// Both resources decoding policy_data into cloudresourcemanager types
+	// must reject it rather than drop the condition.
+	if _, es := validateIamPolicyUnconditional(conditional, "policy_data"); len(es) != 1 {
+		t.Errorf("Expected google_project_iam_policy to reject %s, got %v", conditional, es)
+	}
+	if _, es := validateV2IamPolicy(conditional, "policy_data"); len(es) != 1 {
+		t.Errorf("Expected google_folder_iam_policy to reject %s, got %v", conditional, es)
+	}
+}
+
+func TestJsonPolicyDiffSuppress(t *testing.T) {
+	table := []struct {
+		old, new string
+		expect   bool
+	}{
+		{
+			old:    `{"bindings":[{"members":["user:a","user:b"],"role":"role-1"}]}`,
+			new:    `{"bindings":[{"members":["user:b"],"role":"role-1"},{"members":["user:a"],"role":"role-1"}]}`,
+			expect: true,
+		},
+		{
+			old:    `{"bindings":[{"members":["user:a"],"role":"role-1"}]}`,
+			new:    `{"bindings":[{"condition":{"expression":"true","title":"t"},"members":["user:a"],"role":"role-1"}]}`,
+			expect: false,
+		},
+		{
+			old:    `{"bindings":[{"condition":{"expression":"true","title":"t"},"members":["user:a","user:b"],"role":"role-1"}]}`,
+			new:    `{"bindings":[{"condition":{"expression":"true","title":"t"},"members":["user:b","user:a"],"role":"role-1"}]}`,
+			expect: true,
+		},
+		{
+			old:    `{"bindings":[{"condition":{"expression":"true","title":"t"},"members":["user:a"],"role":"role-1"}]}`,
+			new:    `{"bindings":[{"condition":{"expression":"false","title":"t"},"members":["user:a"],"role":"role-1"}]}`,
+			expect: false,
+		},
+	}
+
+	for _, test := range table {
+		if got := jsonPolicyDiffSuppress("policy_data", test.old, test.new, nil); got != test.expect {
+			t.Errorf("Expected diff between %s and %s to be suppressed: %t, got %t", test.old, test.new, test.expect, got)
+		}
+	}
+}
@@ -2,16 +2,24 @@ package google
 
 import (
 	"fmt"
+	"log"
+	"net/http"
 	"strings"
 
+	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/googleapi"
 )
 
 func resourceGoogleProjectService() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGoogleProjectServiceCreate,
 		Read:   resourceGoogleProjectServiceRead,
+		Update: resourceGoogleProjectServiceUpdate,
 		Delete: resourceGoogleProjectServiceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGoogleProjectServiceImportState,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"service": {
@@ -22,8 +30,19 @@ func resourceGoogleProjectService() *schema.Resource {
 			"project": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
+			"disable_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"disable_dependent_services": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -67,6 +86,7 @@ func resourceGoogleProjectServiceRead(d *schema.ResourceData, meta interface{})
 	for _, s := range services {
 		if s == id.service {
 			d.Set("service", s)
+			d.Set("project", project)
 			return nil
 		}
 	}
@@ -76,9 +96,21 @@ func resourceGoogleProjectServiceRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+// The other fields only affect what happens on destroy, so there's nothing to
+// update server-side.
+func resourceGoogleProjectServiceUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceGoogleProjectServiceRead(d, meta)
+}
+
 func resourceGoogleProjectServiceDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
+	if !d.Get("disable_on_destroy").(bool) {
+		log.Printf("[WARN] Project service %q has disable_on_destroy set to false, only removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	project, err := getProject(d, config)
 	if err != nil {
 		return err
@@ -89,14 +121,51 @@ func resourceGoogleProjectServiceDelete(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	if err = disableService(id.service, project, config); err != nil {
-		return fmt.Errorf("Error disabling service: %s", err)
+	if d.Get("disable_dependent_services").(bool) {
+		// Only Service Usage can disable the services depending on this one
+		// along with it.
+		op, err := config.clientServiceUsage.Disable(project, id.service, true)
+		if err == nil {
+			err = serviceUsageOperationWait(config, op, "api to disable")
+		}
+		if err != nil {
+			return fmt.Errorf("Error disabling service %q and the services depending on it for project %q: %s", id.service, project, err)
+		}
+	} else if err := disableService(id.service, project, config); err != nil {
+		if isFailedPreconditionError(err) {
+			return fmt.Errorf("%s. If other enabled services depend on it, set disable_dependent_services to disable them too", err)
+		}
+		return err
 	}
 
 	d.SetId("")
 	return nil
 }
 
+// isFailedPreconditionError reports whether err is an API call rejected with
+// FAILED_PRECONDITION, which is how a service that other enabled services
+// depend on is refused to be disabled.
+func isFailedPreconditionError(err error) bool {
+	if !errwrap.ContainsType(err, &googleapi.Error{}) {
+		return false
+	}
+	gerr := errwrap.GetType(err, &googleapi.Error{}).(*googleapi.Error)
+	return gerr.Code == http.StatusBadRequest && strings.Contains(gerr.Body, "FAILED_PRECONDITION")
+}
+
+func resourceGoogleProjectServiceImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := parseProjectServiceId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project", id.project)
+	d.Set("service", id.service)
+	d.Set("disable_on_destroy", true)
+	d.Set("disable_dependent_services", false)
+	return []*schema.ResourceData{d}, nil
+}
+
 // Parts that make up the id of a `google_project_service` resource.
 // Project is included in order to allow multiple projects to enable the same service within the same Terraform state
 type projectServiceId struct {
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"google.golang.org/api/googleapi"
 )
 
 // Test that services can be enabled and disabled on a project
@@ -25,6 +27,12 @@ func TestAccGoogleProjectService_basic(t *testing.T) {
 					testAccCheckProjectService(services, pid, true),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "google_project_service.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", pid, services[0]),
+				ImportStateVerify: true,
+			},
 			// Use a separate TestStep rather than a CheckDestroy because we need the project to still exist.
 			resource.TestStep{
 				Config: testAccGoogleProject_create(pid, pname, org),
@@ -36,6 +44,58 @@ func TestAccGoogleProjectService_basic(t *testing.T) {
 	})
 }
 
+// Test that services are left enabled when disable_on_destroy is false, and
+// that dependent services are only disabled when asked to.
+func TestAccGoogleProjectService_disableOnDestroy(t *testing.T) {
+	t.Parallel()
+
+	pid := "terraform-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGoogleProjectService_disableOnDestroy(pid, pname, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProjectService([]string{"dataproc.googleapis.com", "compute.googleapis.com", "storage-api.googleapis.com"}, pid, true),
+				),
+			},
+			resource.TestStep{
+				Config: testAccGoogleProject_create(pid, pname, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckProjectService([]string{"storage-api.googleapis.com"}, pid, true),
+					testAccCheckProjectService([]string{"dataproc.googleapis.com", "compute.googleapis.com"}, pid, false),
+				),
+			},
+		},
+	})
+}
+
+func TestIsFailedPreconditionError(t *testing.T) {
+	t.Parallel()
+
+	dependents := &googleapi.Error{
+		Code:    400,
+		Message: "The service compute.googleapis.com is depended on by the following active service(s): dataproc.googleapis.com",
+		Body:    `{"error": {"code": 400, "status": "FAILED_PRECONDITION"}}`,
+	}
+	cases := map[string]struct {
+		Err      error
+		Expected bool
+	}{
+		"failed precondition": {dependents, true},
+		"wrapped":             {errwrap.Wrapf("Error disabling service: {{err}}", dependents), true},
+		"permission denied":   {&googleapi.Error{Code: 403, Body: `{"error": {"code": 403, "status": "PERMISSION_DENIED"}}`}, false},
+		"other error":         {fmt.Errorf("connection refused"), false},
+	}
+
+	for tn, tc := range cases {
+		if actual := isFailedPreconditionError(tc.Err); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %t, got %t", tn, tc.Expected, actual)
+		}
+	}
+}
+
 func testAccCheckProjectService(services []string, pid string, expectEnabled bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		config := testAccProvider.Meta().(*Config)
@@ -83,3 +143,32 @@ resource "google_project_service" "test2" {
 }
 `, pid, name, org, services[0], services[1])
 }
+
+func testAccGoogleProjectService_disableOnDestroy(pid, name, org string) string {
+	return fmt.Sprintf(`
+resource "google_project" "acceptance" {
+  project_id = "%s"
+  name       = "%s"
+  org_id     = "%s"
+}
+
+resource "google_project_service" "storage" {
+  project            = "${google_project.acceptance.project_id}"
+  service            = "storage-api.googleapis.com"
+  disable_on_destroy = false
+}
+
+resource "google_project_service" "compute" {
+  project                    = "${google_project.acceptance.project_id}"
+  service                    = "compute.googleapis.com"
+  disable_dependent_services = true
+}
+
+resource "google_project_service" "dataproc" {
+  project = "${google_project.acceptance.project_id}"
+  service = "dataproc.googleapis.com"
+
+  depends_on = ["google_project_service.compute"]
+}
+`, pid, name, org)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/terraform/helper/schema"
 	"google.golang.org/api/servicemanagement/v1"
 )
@@ -218,7 +219,7 @@ func disableService(s, pid string, config *Config) error {
 		return nil
 	}, 10)
 	if err != nil {
-		return fmt.Errorf("Error disabling service %q for project %q: %v", s, pid, err)
+		return errwrap.Wrapf(fmt.Sprintf("Error disabling service %q for project %q: {{err}}", s, pid), err)
 	}
 	return nil
 }
@@ -0,0 +1,141 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGoogleServiceAccountIamBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleServiceAccountIamBindingCreate,
+		Read:   resourceGoogleServiceAccountIamBindingRead,
+		Update: resourceGoogleServiceAccountIamBindingUpdate,
+		Delete: resourceGoogleServiceAccountIamBindingDelete,
+
+		Schema: map[string]*schema.Schema{
+			"service_account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleServiceAccountIamBindingCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+
+	binding := getServiceAccountIamBinding(d)
+	err := serviceAccountIamPolicyReadModifyWrite(config, serviceAccount, func(p *iamPolicy) error {
+		p.Bindings = setServiceAccountIamBinding(p.Bindings, binding)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(serviceAccount + "/" + binding.Role)
+	return resourceGoogleServiceAccountIamBindingRead(d, meta)
+}
+
+func resourceGoogleServiceAccountIamBindingRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+	role := d.Get("role").(string)
+
+	p, err := readServiceAccountIamPolicy(config, serviceAccount)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM binding for role %q on service account %q", role, serviceAccount))
+	}
+
+	var binding *iamPolicyBinding
+	for _, b := range p.Bindings {
+		if b.Role == role && b.Condition == nil {
+			binding = b
+			break
+		}
+	}
+	if binding == nil {
+		log.Printf("[DEBUG]: Binding for role %q not found in policy for service account %q, removing from state file.\n", role, serviceAccount)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("etag", p.Etag)
+	d.Set("members", binding.Members)
+	return nil
+}
+
+func resourceGoogleServiceAccountIamBindingUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+
+	binding := getServiceAccountIamBinding(d)
+	err := serviceAccountIamPolicyReadModifyWrite(config, serviceAccount, func(p *iamPolicy) error {
+		p.Bindings = setServiceAccountIamBinding(p.Bindings, binding)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceGoogleServiceAccountIamBindingRead(d, meta)
+}
+
+func resourceGoogleServiceAccountIamBindingDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+	role := d.Get("role").(string)
+
+	err := serviceAccountIamPolicyReadModifyWrite(config, serviceAccount, func(p *iamPolicy) error {
+		p.Bindings = setServiceAccountIamBinding(p.Bindings, &iamPolicyBinding{Role: role})
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM binding for role %q on service account %q", role, serviceAccount))
+	}
+
+	return nil
+}
+
+func getServiceAccountIamBinding(d *schema.ResourceData) *iamPolicyBinding {
+	return &iamPolicyBinding{
+		Role:    d.Get("role").(string),
+		Members: convertStringArr(d.Get("members").(*schema.Set).List()),
+	}
+}
+
+// setServiceAccountIamBinding replaces the unconditional binding for the role
+// of binding in bindings, removing it altogether if binding has no members.
+// Conditional bindings for the role are left alone.
+func setServiceAccountIamBinding(bindings []*iamPolicyBinding, binding *iamPolicyBinding) []*iamPolicyBinding {
+	result := make([]*iamPolicyBinding, 0, len(bindings)+1)
+	for _, b := range bindings {
+		if b.Role != binding.Role || b.Condition != nil {
+			result = append(result, b)
+		}
+	}
+	if len(binding.Members) > 0 {
+		result = append(result, binding)
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGoogleServiceAccountIamMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleServiceAccountIamMemberCreate,
+		Read:   resourceGoogleServiceAccountIamMemberRead,
+		Delete: resourceGoogleServiceAccountIamMemberDelete,
+
+		Schema: map[string]*schema.Schema{
+			"service_account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"member": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleServiceAccountIamMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	err := serviceAccountIamPolicyReadModifyWrite(config, serviceAccount, func(p *iamPolicy) error {
+		p.Bindings = addServiceAccountIamMember(p.Bindings, role, member)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(serviceAccount + "/" + role + "/" + member)
+	return resourceGoogleServiceAccountIamMemberRead(d, meta)
+}
+
+func resourceGoogleServiceAccountIamMemberRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	p, err := readServiceAccountIamPolicy(config, serviceAccount)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM member %q for role %q on service account %q", member, role, serviceAccount))
+	}
+
+	for _, b := range p.Bindings {
+		if b.Role == role && b.Condition == nil && stringInSlice(b.Members, member) {
+			d.Set("etag", p.Etag)
+			return nil
+		}
+	}
+
+	log.Printf("[DEBUG]: Member %q for role %q not found in policy for service account %q, removing from state file.\n", member, role, serviceAccount)
+	d.SetId("")
+	return nil
+}
+
+func resourceGoogleServiceAccountIamMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	err := serviceAccountIamPolicyReadModifyWrite(config, serviceAccount, func(p *iamPolicy) error {
+		p.Bindings = removeServiceAccountIamMember(p.Bindings, role, member)
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM member %q for role %q on service account %q", member, role, serviceAccount))
+	}
+
+	return nil
+}
+
+func addServiceAccountIamMember(bindings []*iamPolicyBinding, role, member string) []*iamPolicyBinding {
+	for _, b := range bindings {
+		if b.Role == role && b.Condition == nil {
+			if !stringInSlice(b.Members, member) {
+				b.Members = append(b.Members, member)
+			}
+			return bindings
+		}
+	}
+	return append(bindings, &iamPolicyBinding{
+		Role:    role,
+		Members: []string{member},
+	})
+}
+
+// removeServiceAccountIamMember removes member from the unconditional binding
+// for role, dropping the binding once it has no members left.
+func removeServiceAccountIamMember(bindings []*iamPolicyBinding, role, member string) []*iamPolicyBinding {
+	result := make([]*iamPolicyBinding, 0, len(bindings))
+	for _, b := range bindings {
+		if b.Role == role && b.Condition == nil {
+			members := make([]string, 0, len(b.Members))
+			for _, m := range b.Members {
+				if m != member {
+					members = append(members, m)
+				}
+			}
+			if len(members) == 0 {
+				continue
+			}
+			b.Members = members
+		}
+		result = append(result, b)
+	}
+	return result
+}
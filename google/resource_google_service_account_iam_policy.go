@@ -0,0 +1,208 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceGoogleServiceAccountIamPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleServiceAccountIamPolicyCreate,
+		Read:   resourceGoogleServiceAccountIamPolicyRead,
+		Update: resourceGoogleServiceAccountIamPolicyUpdate,
+		Delete: resourceGoogleServiceAccountIamPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"service_account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_data": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: jsonPolicyDiffSuppress,
+				ValidateFunc:     validateServiceAccountIamPolicy,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleServiceAccountIamPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+
+	if err := setServiceAccountIamPolicyData(d, config); err != nil {
+		return err
+	}
+
+	d.SetId(serviceAccount)
+	return resourceGoogleServiceAccountIamPolicyRead(d, meta)
+}
+
+func resourceGoogleServiceAccountIamPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+
+	policy, err := readServiceAccountIamPolicy(config, serviceAccount)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM policy for service account %q", serviceAccount))
+	}
+
+	d.Set("etag", policy.Etag)
+	d.Set("policy_data", marshalServiceAccountIamPolicy(policy))
+
+	return nil
+}
+
+func resourceGoogleServiceAccountIamPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.HasChange("policy_data") {
+		if err := setServiceAccountIamPolicyData(d, config); err != nil {
+			return err
+		}
+	}
+
+	return resourceGoogleServiceAccountIamPolicyRead(d, meta)
+}
+
+func resourceGoogleServiceAccountIamPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+
+	err := serviceAccountIamPolicyReadModifyWrite(config, serviceAccount, func(p *iamPolicy) error {
+		p.Bindings = nil
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM policy for service account %q", serviceAccount))
+	}
+
+	return nil
+}
+
+func setServiceAccountIamPolicyData(d *schema.ResourceData, config *Config) error {
+	serviceAccount := serviceAccountFQN(d.Get("service_account_id").(string))
+	policy, err := unmarshalServiceAccountIamPolicy(d.Get("policy_data").(string))
+	if err != nil {
+		return fmt.Errorf("'policy_data' is not valid for service account %q: %s", serviceAccount, err)
+	}
+
+	return serviceAccountIamPolicyReadModifyWrite(config, serviceAccount, func(p *iamPolicy) error {
+		p.Bindings = policy.Bindings
+		return nil
+	})
+}
+
+// marshalServiceAccountIamPolicy returns the policy_data of policy. Like the
+// google_iam_policy data source, it only includes the version for policies
+// with conditional bindings.
+func marshalServiceAccountIamPolicy(policy *iamPolicy) string {
+	p := &iamPolicy{
+		Bindings: policy.Bindings,
+	}
+	if iamPolicyHasConditions(p.Bindings) {
+		p.Version = iamConditionalPolicyVersion
+	}
+	pdBytes, _ := json.Marshal(p)
+	return string(pdBytes)
+}
+
+func unmarshalServiceAccountIamPolicy(policyData string) (*iamPolicy, error) {
+	policy := &iamPolicy{}
+	if err := json.Unmarshal([]byte(policyData), policy); err != nil {
+		return nil, fmt.Errorf("Could not unmarshal policy data %s:\n%s", policyData, err)
+	}
+	return policy, nil
+}
+
+func validateServiceAccountIamPolicy(i interface{}, k string) (s []string, es []error) {
+	_, err := unmarshalServiceAccountIamPolicy(i.(string))
+	if err != nil {
+		es = append(es, err)
+	}
+	return
+}
+
+// The vendored iam/v1 client has no conditions on its bindings and can't
+// request a policy version, so service account IAM policies are read and
+// written with the IAM JSON API directly.
+
+func readServiceAccountIamPolicy(config *Config, serviceAccount string) (*iamPolicy, error) {
+	params := url.Values{"options.requestedPolicyVersion": {fmt.Sprint(iamConditionalPolicyVersion)}}
+	u := config.clientIAM.BasePath + "v1/" + serviceAccount + ":getIamPolicy?" + params.Encode()
+	res := &iamPolicy{}
+	err := sendJsonRequest(config.client, config.clientIAM.UserAgent, "POST", u, nil, res)
+	return res, err
+}
+
+// writeServiceAccountIamPolicy sets the IAM policy of serviceAccount, raising
+// its version to 3 if any binding has a condition.
+func writeServiceAccountIamPolicy(config *Config, serviceAccount string, policy *iamPolicy) error {
+	if iamPolicyHasConditions(policy.Bindings) {
+		policy.Version = iamConditionalPolicyVersion
+	}
+	u := config.clientIAM.BasePath + "v1/" + serviceAccount + ":setIamPolicy"
+	body := map[string]interface{}{
+		"policy": policy,
+	}
+	return sendJsonRequest(config.client, config.clientIAM.UserAgent, "POST", u, body, nil)
+}
+
+type serviceAccountIamPolicyModifyFunc func(p *iamPolicy) error
+
+// serviceAccountIamPolicyReadModifyWrite applies modify to the current IAM
+// policy of serviceAccount. Changes made by the service account IAM
+// resources are serialized per service account, and the whole
+// read-modify-write is restarted if the policy was changed by someone else
+// in the meantime.
+func serviceAccountIamPolicyReadModifyWrite(config *Config, serviceAccount string, modify serviceAccountIamPolicyModifyFunc) error {
+	mutexKV.Lock(serviceAccountIamMutexKey(serviceAccount))
+	defer mutexKV.Unlock(serviceAccountIamMutexKey(serviceAccount))
+
+	backoff := time.Second
+	for {
+		log.Printf("[DEBUG]: Retrieving IAM policy for service account %q\n", serviceAccount)
+		p, err := readServiceAccountIamPolicy(config, serviceAccount)
+		if err != nil {
+			return err
+		}
+
+		if err := modify(p); err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG]: Setting IAM policy for service account %q to %+v\n", serviceAccount, p)
+		err = writeServiceAccountIamPolicy(config, serviceAccount, p)
+		if err == nil {
+			break
+		}
+		if isConflictError(err) {
+			log.Printf("[DEBUG]: Concurrent policy changes, restarting read-modify-write after %s\n", backoff)
+			time.Sleep(backoff)
+			backoff = backoff * 2
+			if backoff > 30*time.Second {
+				return fmt.Errorf("Error applying IAM policy to service account %q: too many concurrent policy changes", serviceAccount)
+			}
+			continue
+		}
+		return fmt.Errorf("Error applying IAM policy to service account %q: %s", serviceAccount, err)
+	}
+	log.Printf("[DEBUG]: Set IAM policy for service account %q\n", serviceAccount)
+
+	return nil
+}
+
+func serviceAccountIamMutexKey(serviceAccount string) string {
+	return fmt.Sprintf("google-service-account-iam-%s", serviceAccount)
+}
@@ -0,0 +1,221 @@
+package google
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestServiceAccountIamBindings(t *testing.T) {
+	t.Parallel()
+
+	bindings := func() []*iamPolicyBinding {
+		return []*iamPolicyBinding{
+			{Role: "roles/iam.serviceAccountActor", Members: []string{"user:a@example.com"}},
+			{Role: "roles/iam.serviceAccountUser", Members: []string{"user:a@example.com", "user:b@example.com"}},
+		}
+	}
+
+	actual := addServiceAccountIamMember(bindings(), "roles/iam.serviceAccountUser", "user:c@example.com")
+	expected := []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}
+	if !reflect.DeepEqual(actual[1].Members, expected) {
+		t.Errorf("Expected members %v after adding, got %v", expected, actual[1].Members)
+	}
+
+	actual = addServiceAccountIamMember(bindings(), "roles/iam.serviceAccountTokenCreator", "user:c@example.com")
+	if len(actual) != 3 || actual[2].Role != "roles/iam.serviceAccountTokenCreator" {
+		t.Errorf("Expected a new binding for roles/iam.serviceAccountTokenCreator, got %+v", actual)
+	}
+
+	actual = removeServiceAccountIamMember(bindings(), "roles/iam.serviceAccountUser", "user:a@example.com")
+	if !reflect.DeepEqual(actual[1].Members, []string{"user:b@example.com"}) {
+		t.Errorf("Expected user:a@example.com to be removed, got %v", actual[1].Members)
+	}
+
+	actual = removeServiceAccountIamMember(bindings(), "roles/iam.serviceAccountActor", "user:a@example.com")
+	if len(actual) != 1 || actual[0].Role != "roles/iam.serviceAccountUser" {
+		t.Errorf("Expected the emptied binding to be removed, got %+v", actual)
+	}
+
+	actual = setServiceAccountIamBinding(bindings(), &iamPolicyBinding{
+		Role:    "roles/iam.serviceAccountUser",
+		Members: []string{"user:c@example.com"},
+	})
+	if len(actual) != 2 || !reflect.DeepEqual(actual[1].Members, []string{"user:c@example.com"}) {
+		t.Errorf("Expected the binding to be replaced, got %+v", actual)
+	}
+
+	actual = setServiceAccountIamBinding(bindings(), &iamPolicyBinding{Role: "roles/iam.serviceAccountUser"})
+	if len(actual) != 1 {
+		t.Errorf("Expected a binding without members to be removed, got %+v", actual)
+	}
+
+	// Conditional bindings set through google_service_account_iam_policy are
+	// left alone by the binding and member resources.
+	conditional := append(bindings(), &iamPolicyBinding{
+		Role:      "roles/iam.serviceAccountUser",
+		Members:   []string{"user:c@example.com"},
+		Condition: &iamCondition{Title: "expires", Expression: `request.time < timestamp("2020-01-01T00:00:00Z")`},
+	})
+	actual = setServiceAccountIamBinding(conditional, &iamPolicyBinding{Role: "roles/iam.serviceAccountUser"})
+	if len(actual) != 2 || actual[1].Condition == nil {
+		t.Errorf("Expected only the unconditional binding to be removed, got %+v", actual)
+	}
+
+	actual = removeServiceAccountIamMember(conditional, "roles/iam.serviceAccountUser", "user:c@example.com")
+	if len(actual) != 3 || !reflect.DeepEqual(actual[2].Members, []string{"user:c@example.com"}) {
+		t.Errorf("Expected the conditional binding to be kept, got %+v", actual)
+	}
+}
+
+func TestAccServiceAccountIamBinding(t *testing.T) {
+	t.Parallel()
+
+	account := "tf-test-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountIamBinding(account, false),
+				Check: testAccCheckServiceAccountIam(account, "roles/iam.serviceAccountUser", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+			{
+				Config: testAccServiceAccountIamBinding(account, true),
+				Check: testAccCheckServiceAccountIam(account, "roles/iam.serviceAccountUser", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+					fmt.Sprintf("serviceAccount:%s-2@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+		},
+	})
+}
+
+func TestAccServiceAccountIamMember(t *testing.T) {
+	t.Parallel()
+
+	account := "tf-test-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountIamMember(account),
+				Check: testAccCheckServiceAccountIam(account, "roles/iam.serviceAccountUser", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+		},
+	})
+}
+
+func TestAccServiceAccountIamPolicy(t *testing.T) {
+	t.Parallel()
+
+	account := "tf-test-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountIamPolicy(account),
+				Check: testAccCheckServiceAccountIam(account, "roles/iam.serviceAccountUser", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+		},
+	})
+}
+
+func testAccCheckServiceAccountIam(account, role string, members []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Config)
+		serviceAccount := serviceAccountFQN(fmt.Sprintf("%s-worker@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()))
+		p, err := readServiceAccountIamPolicy(config, serviceAccount)
+		if err != nil {
+			return err
+		}
+
+		for _, b := range p.Bindings {
+			if b.Role != role || b.Condition != nil {
+				continue
+			}
+
+			sort.Strings(members)
+			sort.Strings(b.Members)
+			if reflect.DeepEqual(members, b.Members) {
+				return nil
+			}
+			return fmt.Errorf("Binding for role %q has members %v, expected %v", role, b.Members, members)
+		}
+
+		return fmt.Errorf("No binding for role %q on service account %q", role, serviceAccount)
+	}
+}
+
+func testAccServiceAccountIamServiceAccounts(account string) string {
+	return fmt.Sprintf(`
+resource "google_service_account" "worker" {
+	account_id   = "%s-worker"
+	display_name = "Service account IAM test worker"
+}
+
+resource "google_service_account" "test_1" {
+	account_id   = "%s-1"
+	display_name = "Service account IAM test 1"
+}
+
+resource "google_service_account" "test_2" {
+	account_id   = "%s-2"
+	display_name = "Service account IAM test 2"
+}
+`, account, account, account)
+}
+
+func testAccServiceAccountIamBinding(account string, both bool) string {
+	members := `"serviceAccount:${google_service_account.test_1.email}"`
+	if both {
+		members += `, "serviceAccount:${google_service_account.test_2.email}"`
+	}
+
+	return testAccServiceAccountIamServiceAccounts(account) + fmt.Sprintf(`
+resource "google_service_account_iam_binding" "binding" {
+	service_account_id = "${google_service_account.worker.name}"
+	role               = "roles/iam.serviceAccountUser"
+	members            = [%s]
+}
+`, members)
+}
+
+func testAccServiceAccountIamMember(account string) string {
+	return testAccServiceAccountIamServiceAccounts(account) + `
+resource "google_service_account_iam_member" "member" {
+	service_account_id = "${google_service_account.worker.email}"
+	role               = "roles/iam.serviceAccountUser"
+	member             = "serviceAccount:${google_service_account.test_1.email}"
+}
+`
+}
+
+func testAccServiceAccountIamPolicy(account string) string {
+	return testAccServiceAccountIamServiceAccounts(account) + `
+data "google_iam_policy" "policy" {
+	binding {
+		role    = "roles/iam.serviceAccountUser"
+		members = ["serviceAccount:${google_service_account.test_1.email}"]
+	}
+}
+
+resource "google_service_account_iam_policy" "policy" {
+	service_account_id = "${google_service_account.worker.name}"
+	policy_data        = "${data.google_iam_policy.policy.policy_data}"
+}
+`
+}
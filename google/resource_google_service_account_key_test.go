@@ -56,6 +56,63 @@ func TestAccGoogleServiceAccountKey_pgp(t *testing.T) {
 	})
 }
 
+// Test that changing keepers replaces the key
+func TestAccGoogleServiceAccountKey_rotation(t *testing.T) {
+	t.Parallel()
+
+	resourceName := "google_service_account_key.acceptance"
+	accountID := "a" + acctest.RandString(10)
+	displayName := "Terraform Test"
+	var firstKeyName string
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGoogleServiceAccountKey_rotation(accountID, displayName, "2018-01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleServiceAccountKeyExists(resourceName),
+					testAccStoreGoogleServiceAccountKeyName(resourceName, &firstKeyName),
+				),
+			},
+			resource.TestStep{
+				Config: testAccGoogleServiceAccountKey_rotation(accountID, displayName, "2018-02"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleServiceAccountKeyExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "private_key"),
+					testAccCheckGoogleServiceAccountKeyRotated(resourceName, &firstKeyName),
+				),
+			},
+		},
+	})
+}
+
+func testAccStoreGoogleServiceAccountKeyName(r string, name *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return fmt.Errorf("Not found: %s", r)
+		}
+
+		*name = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckGoogleServiceAccountKeyRotated(r string, oldName *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return fmt.Errorf("Not found: %s", r)
+		}
+
+		if rs.Primary.ID == *oldName {
+			return fmt.Errorf("Service account key %s was not rotated", *oldName)
+		}
+		return nil
+	}
+}
+
 func testAccCheckGoogleServiceAccountKeyExists(r string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -92,6 +149,27 @@ resource "google_service_account_key" "acceptance" {
 `, account, name)
 }
 
+func testAccGoogleServiceAccountKey_rotation(account, name, rotation string) string {
+	return fmt.Sprintf(`
+resource "google_service_account" "acceptance" {
+	account_id = "%s"
+	display_name = "%s"
+}
+
+resource "google_service_account_key" "acceptance" {
+	service_account_id = "${google_service_account.acceptance.id}"
+	public_key_type = "TYPE_X509_PEM_FILE"
+	keepers {
+		rotation = "%s"
+	}
+
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`, account, name, rotation)
+}
+
 func testAccGoogleServiceAccountKey_pgp(account, name string, key string) string {
 	return fmt.Sprintf(`
 resource "google_service_account" "acceptance" {
@@ -38,6 +38,10 @@ func resourceKmsCryptoKey() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateKmsCryptoKeyRotationPeriod,
 			},
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -116,6 +120,7 @@ func resourceKmsCryptoKeyRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("key_ring", cryptoKeyId.KeyRingId.terraformId())
 	d.Set("name", cryptoKeyId.Name)
 	d.Set("rotation_period", d.Get("rotation_period"))
+	d.Set("self_link", cryptoKeyId.cryptoKeyId())
 
 	d.SetId(cryptoKeyId.terraformId())
 
@@ -0,0 +1,140 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/logging/v2"
+)
+
+func resourceLoggingProjectExclusion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLoggingProjectExclusionCreate,
+		Read:   resourceLoggingProjectExclusionRead,
+		Update: resourceLoggingProjectExclusionUpdate,
+		Delete: resourceLoggingProjectExclusionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"filter": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceLoggingProjectExclusionCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	exclusion := &logging.LogExclusion{
+		Name:        d.Get("name").(string),
+		Filter:      d.Get("filter").(string),
+		Description: d.Get("description").(string),
+		Disabled:    d.Get("disabled").(bool),
+	}
+
+	parent := "projects/" + project
+	_, err = config.clientLogging.Projects.Exclusions.Create(parent, exclusion).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating logging exclusion %s in %s: %s", exclusion.Name, parent, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/exclusions/%s", parent, exclusion.Name))
+
+	return resourceLoggingProjectExclusionRead(d, meta)
+}
+
+var loggingProjectExclusionIdRegex = regexp.MustCompile("^projects/([^/]+)/exclusions/([^/]+)$")
+
+func resourceLoggingProjectExclusionRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	parts := loggingProjectExclusionIdRegex.FindStringSubmatch(d.Id())
+	if parts == nil {
+		return fmt.Errorf("Invalid logging exclusion id %q, expected projects/{project}/exclusions/{name}", d.Id())
+	}
+
+	exclusion, err := config.clientLogging.Projects.Exclusions.Get(d.Id()).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Project Logging Exclusion %s", d.Id()))
+	}
+
+	d.Set("project", parts[1])
+	d.Set("name", exclusion.Name)
+	d.Set("filter", exclusion.Filter)
+	d.Set("description", exclusion.Description)
+	d.Set("disabled", exclusion.Disabled)
+
+	return nil
+}
+
+func resourceLoggingProjectExclusionUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	exclusion := &logging.LogExclusion{
+		Filter:      d.Get("filter").(string),
+		Description: d.Get("description").(string),
+		Disabled:    d.Get("disabled").(bool),
+	}
+
+	var updateMask []string
+	for _, field := range []string{"filter", "description", "disabled"} {
+		if d.HasChange(field) {
+			updateMask = append(updateMask, field)
+		}
+	}
+	// Empty descriptions and false are valid updates.
+	exclusion.ForceSendFields = []string{"Description", "Disabled"}
+
+	_, err := config.clientLogging.Projects.Exclusions.Patch(d.Id(), exclusion).UpdateMask(strings.Join(updateMask, ",")).Do()
+	if err != nil {
+		return fmt.Errorf("Error updating logging exclusion %s: %s", d.Id(), err)
+	}
+
+	return resourceLoggingProjectExclusionRead(d, meta)
+}
+
+func resourceLoggingProjectExclusionDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	_, err := config.clientLogging.Projects.Exclusions.Delete(d.Id()).Do()
+	if err != nil {
+		return fmt.Errorf("Error deleting logging exclusion %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,70 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLoggingProjectExclusion_basic(t *testing.T) {
+	t.Parallel()
+
+	exclusionName := "tf-test-exclusion-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLoggingProjectExclusionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLoggingProjectExclusion_basic(exclusionName, "logName:\"dataproc.job.driver\" AND severity<WARNING", false),
+			},
+			{
+				ResourceName:      "google_logging_project_exclusion.basic",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccLoggingProjectExclusion_basic(exclusionName, "logName:\"yarn-userlogs\" AND severity<WARNING", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_logging_project_exclusion.basic", "disabled", "true"),
+				),
+			},
+			{
+				ResourceName:      "google_logging_project_exclusion.basic",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckLoggingProjectExclusionDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_logging_project_exclusion" {
+			continue
+		}
+
+		_, err := config.clientLogging.Projects.Exclusions.Get(rs.Primary.ID).Do()
+		if err == nil {
+			return fmt.Errorf("project exclusion %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccLoggingProjectExclusion_basic(name, filter string, disabled bool) string {
+	return fmt.Sprintf(`
+resource "google_logging_project_exclusion" "basic" {
+	name        = "%s"
+	description = "Excludes verbose Dataproc logs"
+	filter      = %q
+	disabled    = %t
+}`, name, filter, disabled)
+}
@@ -0,0 +1,504 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var monitoringAggregationSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		// The period time series are aligned over, e.g. "60s".
+		"alignment_period": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		// How each time series is aligned, e.g. ALIGN_MEAN or ALIGN_RATE.
+		"per_series_aligner": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		// How the aligned time series are combined, e.g. REDUCE_SUM.
+		"cross_series_reducer": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"group_by_fields": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	},
+}
+
+var monitoringTriggerSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"count": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+
+		"percent": {
+			Type:     schema.TypeFloat,
+			Optional: true,
+		},
+	},
+}
+
+func resourceMonitoringAlertPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMonitoringAlertPolicyCreate,
+		Read:   resourceMonitoringAlertPolicyRead,
+		Update: resourceMonitoringAlertPolicyUpdate,
+		Delete: resourceMonitoringAlertPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// How the conditions' results are combined into whether an
+			// incident is opened.
+			"combiner": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AND", "OR", "AND_WITH_MATCHING_RESOURCE"}, false),
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"conditions": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"display_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"condition_threshold": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"filter": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"comparison": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											"COMPARISON_GT", "COMPARISON_GE", "COMPARISON_LT",
+											"COMPARISON_LE", "COMPARISON_EQ", "COMPARISON_NE",
+										}, false),
+									},
+
+									"threshold_value": {
+										Type:     schema.TypeFloat,
+										Optional: true,
+									},
+
+									// How long the condition must hold, e.g. "300s".
+									"duration": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"aggregations": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     monitoringAggregationSchema,
+									},
+
+									// Makes the condition compare the ratio of the
+									// filter's time series to these.
+									"denominator_filter": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"denominator_aggregations": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     monitoringAggregationSchema,
+									},
+
+									"trigger": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem:     monitoringTriggerSchema,
+									},
+								},
+							},
+						},
+
+						"condition_absent": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"filter": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"duration": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"aggregations": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     monitoringAggregationSchema,
+									},
+
+									"trigger": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem:     monitoringTriggerSchema,
+									},
+								},
+							},
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			// The names of the notification channels incidents are sent to,
+			// in the form projects/{project}/notificationChannels/{id}.
+			"notification_channels": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"documentation": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"mime_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "text/markdown",
+						},
+					},
+				},
+			},
+
+			"user_labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMonitoringAlertPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	policy, err := expandMonitoringAlertPolicy(d)
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientMonitoring.CreateAlertPolicy(project, policy)
+	if err != nil {
+		return fmt.Errorf("Error creating alert policy %q: %s", policy.DisplayName, err)
+	}
+
+	d.SetId(res.Name)
+
+	return resourceMonitoringAlertPolicyRead(d, meta)
+}
+
+var monitoringAlertPolicyNameRegex = regexp.MustCompile("^projects/([^/]+)/alertPolicies/[^/]+$")
+
+func resourceMonitoringAlertPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	parts := monitoringAlertPolicyNameRegex.FindStringSubmatch(d.Id())
+	if parts == nil {
+		return fmt.Errorf("Invalid alert policy name %q, expected projects/{project}/alertPolicies/{id}", d.Id())
+	}
+
+	policy, err := config.clientMonitoring.GetAlertPolicy(d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Alert policy %q", d.Id()))
+	}
+
+	d.Set("project", parts[1])
+	d.Set("name", policy.Name)
+	d.Set("display_name", policy.DisplayName)
+	d.Set("combiner", policy.Combiner)
+	d.Set("enabled", policy.Enabled)
+	d.Set("notification_channels", policy.NotificationChannels)
+	d.Set("user_labels", policy.UserLabels)
+	if err := d.Set("conditions", flattenMonitoringConditions(policy.Conditions)); err != nil {
+		return fmt.Errorf("Error setting conditions: %s", err)
+	}
+	if err := d.Set("documentation", flattenMonitoringDocumentation(policy.Documentation)); err != nil {
+		return fmt.Errorf("Error setting documentation: %s", err)
+	}
+
+	return nil
+}
+
+func resourceMonitoringAlertPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	policy, err := expandMonitoringAlertPolicy(d)
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	for field, mask := range map[string]string{
+		"display_name":          "displayName",
+		"combiner":              "combiner",
+		"enabled":               "enabled",
+		"conditions":            "conditions",
+		"notification_channels": "notificationChannels",
+		"documentation":         "documentation",
+		"user_labels":           "userLabels",
+	} {
+		if d.HasChange(field) {
+			fields = append(fields, mask)
+		}
+	}
+
+	if len(fields) > 0 {
+		_, err := config.clientMonitoring.UpdateAlertPolicy(d.Id(), policy, strings.Join(fields, ","))
+		if err != nil {
+			return fmt.Errorf("Error updating alert policy %q: %s", d.Id(), err)
+		}
+	}
+
+	return resourceMonitoringAlertPolicyRead(d, meta)
+}
+
+func resourceMonitoringAlertPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if err := config.clientMonitoring.DeleteAlertPolicy(d.Id()); err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Alert policy %q", d.Id()))
+	}
+
+	return nil
+}
+
+func expandMonitoringAlertPolicy(d *schema.ResourceData) (*monitoringAlertPolicy, error) {
+	policy := &monitoringAlertPolicy{
+		DisplayName:          d.Get("display_name").(string),
+		Combiner:             d.Get("combiner").(string),
+		Enabled:              d.Get("enabled").(bool),
+		NotificationChannels: convertStringArr(d.Get("notification_channels").([]interface{})),
+		UserLabels:           convertStringMap(d.Get("user_labels").(map[string]interface{})),
+	}
+
+	for i, raw := range d.Get("conditions").([]interface{}) {
+		c := raw.(map[string]interface{})
+		condition := &monitoringCondition{
+			Name:        c["name"].(string),
+			DisplayName: c["display_name"].(string),
+		}
+
+		if l := c["condition_threshold"].([]interface{}); len(l) > 0 && l[0] != nil {
+			t := l[0].(map[string]interface{})
+			condition.ConditionThreshold = &monitoringMetricThreshold{
+				Filter:                  t["filter"].(string),
+				Comparison:              t["comparison"].(string),
+				ThresholdValue:          t["threshold_value"].(float64),
+				Duration:                t["duration"].(string),
+				Aggregations:            expandMonitoringAggregations(t["aggregations"].([]interface{})),
+				DenominatorFilter:       t["denominator_filter"].(string),
+				DenominatorAggregations: expandMonitoringAggregations(t["denominator_aggregations"].([]interface{})),
+				Trigger:                 expandMonitoringTrigger(t["trigger"].([]interface{})),
+			}
+		}
+
+		if l := c["condition_absent"].([]interface{}); len(l) > 0 && l[0] != nil {
+			a := l[0].(map[string]interface{})
+			condition.ConditionAbsent = &monitoringMetricAbsence{
+				Filter:       a["filter"].(string),
+				Duration:     a["duration"].(string),
+				Aggregations: expandMonitoringAggregations(a["aggregations"].([]interface{})),
+				Trigger:      expandMonitoringTrigger(a["trigger"].([]interface{})),
+			}
+		}
+
+		if (condition.ConditionThreshold == nil) == (condition.ConditionAbsent == nil) {
+			return nil, fmt.Errorf("conditions.%d: exactly one of condition_threshold or condition_absent must be set", i)
+		}
+
+		policy.Conditions = append(policy.Conditions, condition)
+	}
+
+	if l := d.Get("documentation").([]interface{}); len(l) > 0 && l[0] != nil {
+		doc := l[0].(map[string]interface{})
+		policy.Documentation = &monitoringDocumentation{
+			Content:  doc["content"].(string),
+			MimeType: doc["mime_type"].(string),
+		}
+	}
+
+	return policy, nil
+}
+
+func expandMonitoringAggregations(configured []interface{}) []*monitoringAggregation {
+	aggregations := make([]*monitoringAggregation, 0, len(configured))
+	for _, raw := range configured {
+		if raw == nil {
+			continue
+		}
+		a := raw.(map[string]interface{})
+		aggregations = append(aggregations, &monitoringAggregation{
+			AlignmentPeriod:    a["alignment_period"].(string),
+			PerSeriesAligner:   a["per_series_aligner"].(string),
+			CrossSeriesReducer: a["cross_series_reducer"].(string),
+			GroupByFields:      convertStringArr(a["group_by_fields"].([]interface{})),
+		})
+	}
+	return aggregations
+}
+
+func expandMonitoringTrigger(configured []interface{}) *monitoringTrigger {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+
+	t := configured[0].(map[string]interface{})
+	return &monitoringTrigger{
+		Count:   int64(t["count"].(int)),
+		Percent: t["percent"].(float64),
+	}
+}
+
+func flattenMonitoringConditions(conditions []*monitoringCondition) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(conditions))
+	for _, c := range conditions {
+		data := map[string]interface{}{
+			"name":         c.Name,
+			"display_name": c.DisplayName,
+		}
+
+		if t := c.ConditionThreshold; t != nil {
+			data["condition_threshold"] = []map[string]interface{}{
+				{
+					"filter":                   t.Filter,
+					"comparison":               t.Comparison,
+					"threshold_value":          t.ThresholdValue,
+					"duration":                 t.Duration,
+					"aggregations":             flattenMonitoringAggregations(t.Aggregations),
+					"denominator_filter":       t.DenominatorFilter,
+					"denominator_aggregations": flattenMonitoringAggregations(t.DenominatorAggregations),
+					"trigger":                  flattenMonitoringTrigger(t.Trigger),
+				},
+			}
+		}
+
+		if a := c.ConditionAbsent; a != nil {
+			data["condition_absent"] = []map[string]interface{}{
+				{
+					"filter":       a.Filter,
+					"duration":     a.Duration,
+					"aggregations": flattenMonitoringAggregations(a.Aggregations),
+					"trigger":      flattenMonitoringTrigger(a.Trigger),
+				},
+			}
+		}
+
+		result = append(result, data)
+	}
+	return result
+}
+
+func flattenMonitoringAggregations(aggregations []*monitoringAggregation) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(aggregations))
+	for _, a := range aggregations {
+		result = append(result, map[string]interface{}{
+			"alignment_period":     a.AlignmentPeriod,
+			"per_series_aligner":   a.PerSeriesAligner,
+			"cross_series_reducer": a.CrossSeriesReducer,
+			"group_by_fields":      a.GroupByFields,
+		})
+	}
+	return result
+}
+
+func flattenMonitoringTrigger(trigger *monitoringTrigger) []map[string]interface{} {
+	if trigger == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"count":   trigger.Count,
+			"percent": trigger.Percent,
+		},
+	}
+}
+
+func flattenMonitoringDocumentation(doc *monitoringDocumentation) []map[string]interface{} {
+	if doc == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"content":   doc.Content,
+			"mime_type": doc.MimeType,
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMonitoringAlertPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	policyName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringAlertPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMonitoringAlertPolicy_threshold(policyName, "OR", 0.5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("google_monitoring_alert_policy.policy", "name"),
+					resource.TestCheckResourceAttrSet("google_monitoring_alert_policy.policy", "conditions.0.name"),
+				),
+			},
+			{
+				ResourceName:      "google_monitoring_alert_policy.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMonitoringAlertPolicy_update(t *testing.T) {
+	t.Parallel()
+
+	policyName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringAlertPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMonitoringAlertPolicy_threshold(policyName, "OR", 0.5),
+			},
+			{
+				Config: testAccMonitoringAlertPolicy_threshold(policyName, "AND", 0.75),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_monitoring_alert_policy.policy", "combiner", "AND"),
+					resource.TestCheckResourceAttr("google_monitoring_alert_policy.policy", "conditions.0.condition_threshold.0.threshold_value", "0.75"),
+				),
+			},
+			{
+				Config: testAccMonitoringAlertPolicy_absent(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_monitoring_alert_policy.policy", "enabled", "false"),
+					resource.TestCheckResourceAttr("google_monitoring_alert_policy.policy", "conditions.0.condition_absent.0.duration", "3600s"),
+				),
+			},
+			{
+				ResourceName:      "google_monitoring_alert_policy.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckMonitoringAlertPolicyDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_monitoring_alert_policy" {
+			continue
+		}
+
+		if _, err := config.clientMonitoring.GetAlertPolicy(rs.Primary.ID); err == nil {
+			return fmt.Errorf("Alert policy %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccMonitoringAlertPolicy_threshold(name, combiner string, threshold float64) string {
+	return fmt.Sprintf(`
+resource "google_monitoring_alert_policy" "policy" {
+  display_name = "%s"
+  combiner     = "%s"
+
+  conditions {
+    display_name = "HDFS capacity"
+
+    condition_threshold {
+      filter          = "metric.type=\"dataproc.googleapis.com/cluster/hdfs/storage_utilization\" resource.type=\"cloud_dataproc_cluster\""
+      comparison      = "COMPARISON_GT"
+      threshold_value = %v
+      duration        = "300s"
+
+      aggregations {
+        alignment_period   = "60s"
+        per_series_aligner = "ALIGN_MEAN"
+      }
+
+      trigger {
+        count = 1
+      }
+    }
+  }
+
+  documentation {
+    content = "HDFS is filling up."
+  }
+
+  user_labels {
+    team = "data"
+  }
+}
+`, name, combiner, threshold)
+}
+
+func testAccMonitoringAlertPolicy_absent(name string) string {
+	return fmt.Sprintf(`
+resource "google_monitoring_alert_policy" "policy" {
+  display_name = "%s"
+  combiner     = "OR"
+  enabled      = false
+
+  conditions {
+    display_name = "YARN stopped reporting"
+
+    condition_absent {
+      filter   = "metric.type=\"dataproc.googleapis.com/cluster/yarn/nodemanagers\" resource.type=\"cloud_dataproc_cluster\""
+      duration = "3600s"
+
+      aggregations {
+        alignment_period     = "300s"
+        per_series_aligner   = "ALIGN_MEAN"
+        cross_series_reducer = "REDUCE_SUM"
+        group_by_fields      = ["resource.label.cluster_name"]
+      }
+    }
+  }
+}
+`, name)
+}
@@ -2,10 +2,11 @@ package google
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	"google.golang.org/api/pubsub/v1"
-	"regexp"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func resourcePubsubSubscription() *schema.Resource {
@@ -34,10 +35,42 @@ func resourcePubsubSubscription() *schema.Resource {
 			},
 
 			"ack_deadline_seconds": &schema.Schema{
-				Type:     schema.TypeInt,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(10, 600),
+			},
+
+			"message_retention_duration": &schema.Schema{
+				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
+			},
+
+			"retain_acked_messages": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"dead_letter_policy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dead_letter_topic": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"max_delivery_attempts": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntBetween(5, 100),
+						},
+					},
+				},
 			},
 
 			"project": &schema.Schema{
@@ -67,6 +100,25 @@ func resourcePubsubSubscription() *schema.Resource {
 							Type:     schema.TypeString,
 							Required: true,
 						},
+
+						"oidc_token": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"service_account_email": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"audience": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -92,14 +144,16 @@ func resourcePubsubSubscriptionCreate(d *schema.ResourceData, meta interface{})
 		ackDeadlineSeconds = int64(v.(int))
 	}
 
-	subscription := &pubsub.Subscription{
-		AckDeadlineSeconds: ackDeadlineSeconds,
-		Topic:              computed_topic_name,
-		PushConfig:         expandPubsubSubscriptionPushConfig(d.Get("push_config").([]interface{})),
+	subscription := &pubsubSubscription{
+		AckDeadlineSeconds:       ackDeadlineSeconds,
+		Topic:                    computed_topic_name,
+		PushConfig:               expandPubsubSubscriptionPushConfig(d.Get("push_config").([]interface{})),
+		MessageRetentionDuration: d.Get("message_retention_duration").(string),
+		RetainAckedMessages:      d.Get("retain_acked_messages").(bool),
+		DeadLetterPolicy:         expandPubsubSubscriptionDeadLetterPolicy(d.Get("dead_letter_policy").([]interface{})),
 	}
 
-	call := config.clientPubsub.Projects.Subscriptions.Create(name, subscription)
-	res, err := call.Do()
+	res, err := config.clientPubsubSubscriptions.CreateSubscription(name, subscription)
 	if err != nil {
 		return err
 	}
@@ -124,7 +178,7 @@ func resourcePubsubSubscriptionRead(d *schema.ResourceData, meta interface{}) er
 	config := meta.(*Config)
 
 	name := d.Id()
-	subscription, err := config.clientPubsub.Projects.Subscriptions.Get(name).Do()
+	subscription, err := config.clientPubsubSubscriptions.GetSubscription(name)
 	if err != nil {
 		return handleNotFoundError(err, d, fmt.Sprintf("Pubsub Subscription %q", name))
 	}
@@ -134,6 +188,9 @@ func resourcePubsubSubscriptionRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("ack_deadline_seconds", subscription.AckDeadlineSeconds)
 	d.Set("path", subscription.Name)
 	d.Set("push_config", flattenPubsubSubscriptionPushConfig(subscription.PushConfig))
+	d.Set("message_retention_duration", subscription.MessageRetentionDuration)
+	d.Set("retain_acked_messages", subscription.RetainAckedMessages)
+	d.Set("dead_letter_policy", flattenPubsubSubscriptionDeadLetterPolicy(subscription.DeadLetterPolicy))
 
 	return nil
 }
@@ -141,21 +198,42 @@ func resourcePubsubSubscriptionRead(d *schema.ResourceData, meta interface{}) er
 func resourcePubsubSubscriptionUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
-	d.Partial(true)
+	subscription := &pubsubSubscription{}
+	updateMask := []string{}
+
+	if d.HasChange("ack_deadline_seconds") {
+		subscription.AckDeadlineSeconds = int64(d.Get("ack_deadline_seconds").(int))
+		updateMask = append(updateMask, "ack_deadline_seconds")
+	}
 
 	if d.HasChange("push_config") {
-		_, err := config.clientPubsub.Projects.Subscriptions.ModifyPushConfig(d.Id(), &pubsub.ModifyPushConfigRequest{
-			PushConfig: expandPubsubSubscriptionPushConfig(d.Get("push_config").([]interface{})),
-		}).Do()
+		subscription.PushConfig = expandPubsubSubscriptionPushConfig(d.Get("push_config").([]interface{}))
+		updateMask = append(updateMask, "push_config")
+	}
+
+	if d.HasChange("message_retention_duration") {
+		subscription.MessageRetentionDuration = d.Get("message_retention_duration").(string)
+		updateMask = append(updateMask, "message_retention_duration")
+	}
+
+	if d.HasChange("retain_acked_messages") {
+		subscription.RetainAckedMessages = d.Get("retain_acked_messages").(bool)
+		updateMask = append(updateMask, "retain_acked_messages")
+	}
+
+	if d.HasChange("dead_letter_policy") {
+		subscription.DeadLetterPolicy = expandPubsubSubscriptionDeadLetterPolicy(d.Get("dead_letter_policy").([]interface{}))
+		updateMask = append(updateMask, "dead_letter_policy")
+	}
 
+	if len(updateMask) > 0 {
+		_, err := config.clientPubsubSubscriptions.UpdateSubscription(d.Id(), subscription, strings.Join(updateMask, ","))
 		if err != nil {
 			return fmt.Errorf("Error updating subscription '%s': %s", d.Get("name"), err)
 		}
 	}
 
-	d.Partial(false)
-
-	return nil
+	return resourcePubsubSubscriptionRead(d, meta)
 }
 
 func resourcePubsubSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
@@ -186,7 +264,7 @@ func resourcePubsubSubscriptionStateImporter(d *schema.ResourceData, meta interf
 	return []*schema.ResourceData{d}, nil
 }
 
-func flattenPubsubSubscriptionPushConfig(pushConfig *pubsub.PushConfig) []map[string]interface{} {
+func flattenPubsubSubscriptionPushConfig(pushConfig *pubsubPushConfig) []map[string]interface{} {
 	configs := make([]map[string]interface{}, 0, 1)
 
 	if pushConfig == nil || len(pushConfig.PushEndpoint) == 0 {
@@ -196,21 +274,74 @@ func flattenPubsubSubscriptionPushConfig(pushConfig *pubsub.PushConfig) []map[st
 	configs = append(configs, map[string]interface{}{
 		"push_endpoint": pushConfig.PushEndpoint,
 		"attributes":    pushConfig.Attributes,
+		"oidc_token":    flattenPubsubSubscriptionOidcToken(pushConfig.OidcToken),
 	})
 
 	return configs
 }
 
-func expandPubsubSubscriptionPushConfig(configured []interface{}) *pubsub.PushConfig {
+func expandPubsubSubscriptionPushConfig(configured []interface{}) *pubsubPushConfig {
 	if len(configured) == 0 {
 		// An empty `pushConfig` indicates that the Pub/Sub system should stop pushing messages
 		// from the given subscription and allow messages to be pulled and acknowledged.
-		return &pubsub.PushConfig{}
+		return &pubsubPushConfig{}
 	}
 
 	pushConfig := configured[0].(map[string]interface{})
-	return &pubsub.PushConfig{
+	return &pubsubPushConfig{
 		PushEndpoint: pushConfig["push_endpoint"].(string),
 		Attributes:   convertStringMap(pushConfig["attributes"].(map[string]interface{})),
+		OidcToken:    expandPubsubSubscriptionOidcToken(pushConfig["oidc_token"].([]interface{})),
+	}
+}
+
+func flattenPubsubSubscriptionOidcToken(oidcToken *pubsubOidcToken) []map[string]interface{} {
+	if oidcToken == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"service_account_email": oidcToken.ServiceAccountEmail,
+			"audience":              oidcToken.Audience,
+		},
+	}
+}
+
+func expandPubsubSubscriptionOidcToken(configured []interface{}) *pubsubOidcToken {
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+
+	oidcToken := configured[0].(map[string]interface{})
+	return &pubsubOidcToken{
+		ServiceAccountEmail: oidcToken["service_account_email"].(string),
+		Audience:            oidcToken["audience"].(string),
+	}
+}
+
+func flattenPubsubSubscriptionDeadLetterPolicy(policy *pubsubDeadLetterPolicy) []map[string]interface{} {
+	if policy == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"dead_letter_topic":     policy.DeadLetterTopic,
+			"max_delivery_attempts": policy.MaxDeliveryAttempts,
+		},
+	}
+}
+
+func expandPubsubSubscriptionDeadLetterPolicy(configured []interface{}) *pubsubDeadLetterPolicy {
+	// Leaving the policy out of an update removes it from the subscription.
+	if len(configured) == 0 || configured[0] == nil {
+		return nil
+	}
+
+	policy := configured[0].(map[string]interface{})
+	return &pubsubDeadLetterPolicy{
+		DeadLetterTopic:     policy["dead_letter_topic"].(string),
+		MaxDeliveryAttempts: int64(policy["max_delivery_attempts"].(int)),
 	}
 }
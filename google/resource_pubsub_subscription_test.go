@@ -32,6 +32,48 @@ func TestAccPubsubSubscription_basic(t *testing.T) {
 	})
 }
 
+func TestAccPubsubSubscription_update(t *testing.T) {
+	t.Parallel()
+
+	topic := fmt.Sprintf("tf-test-topic-%s", acctest.RandString(10))
+	subscription := fmt.Sprintf("tf-test-sub-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPubsubSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPubsubSubscription_basic(topic, subscription),
+			},
+			{
+				Config: testAccPubsubSubscription_full(topic, subscription),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPubsubSubscriptionExists(
+						"google_pubsub_subscription.foobar_sub"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foobar_sub", "ack_deadline_seconds", "30"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foobar_sub", "message_retention_duration", "1200s"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foobar_sub", "retain_acked_messages", "true"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foobar_sub", "dead_letter_policy.0.max_delivery_attempts", "10"),
+				),
+			},
+			{
+				ResourceName:      "google_pubsub_subscription.foobar_sub",
+				ImportStateId:     subscription,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPubsubSubscription_basic(topic, subscription),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foobar_sub", "ack_deadline_seconds", "20"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foobar_sub", "dead_letter_policy.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 // TODO: Add acceptance test for push delivery.
 //
 // Testing push endpoints is tricky for the following reason:
@@ -93,6 +135,30 @@ resource "google_pubsub_subscription" "foobar_sub" {
 }`, topic, subscription)
 }
 
+func testAccPubsubSubscription_full(topic, subscription string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "foobar_sub" {
+	name = "%s"
+}
+
+resource "google_pubsub_topic" "dead_letter" {
+	name = "%s-dead-letter"
+}
+
+resource "google_pubsub_subscription" "foobar_sub" {
+	name                       = "%s"
+	topic                      = "${google_pubsub_topic.foobar_sub.name}"
+	ack_deadline_seconds       = 30
+	message_retention_duration = "1200s"
+	retain_acked_messages      = true
+
+	dead_letter_policy {
+		dead_letter_topic     = "${google_pubsub_topic.dead_letter.id}"
+		max_delivery_attempts = 10
+	}
+}`, topic, topic, subscription)
+}
+
 func TestGetComputedTopicName(t *testing.T) {
 	type testData struct {
 		project  string
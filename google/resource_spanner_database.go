@@ -17,6 +17,7 @@ func resourceSpannerDatabase() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSpannerDatabaseCreate,
 		Read:   resourceSpannerDatabaseRead,
+		Update: resourceSpannerDatabaseUpdate,
 		Delete: resourceSpannerDatabaseDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSpannerDatabaseImportState,
@@ -63,10 +64,14 @@ func resourceSpannerDatabase() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// Only appending statements to ddl is supported, which runs them
+			// against the existing database; schema changes that already ran
+			// can't be undone. ForceNew can't depend on whether a change is an
+			// append without CustomizeDiff, which the vendored helper/schema
+			// lacks, so other changes fail in Update instead.
 			"ddl": &schema.Schema{
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
@@ -134,6 +139,46 @@ func resourceSpannerDatabaseRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+func resourceSpannerDatabaseUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	id, err := buildSpannerDatabaseId(d, config)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("ddl") {
+		o, n := d.GetChange("ddl")
+		oldDdl := convertStringArr(o.([]interface{}))
+		newDdl := convertStringArr(n.([]interface{}))
+
+		if len(newDdl) < len(oldDdl) {
+			return fmt.Errorf("Error, ddl statements of Spanner database %s can't be removed, only appended. Recreate the database to apply this change", id.databaseUri())
+		}
+		for i, statement := range oldDdl {
+			if newDdl[i] != statement {
+				return fmt.Errorf("Error, ddl statement %d of Spanner database %s can't be changed, only new statements can be appended. Recreate the database to apply this change", i, id.databaseUri())
+			}
+		}
+
+		op, err := config.clientSpanner.Projects.Instances.Databases.UpdateDdl(
+			id.databaseUri(), &spanner.UpdateDatabaseDdlRequest{
+				Statements: newDdl[len(oldDdl):],
+			}).Do()
+		if err != nil {
+			return fmt.Errorf("Error, failed to update ddl of Spanner database %s: %s", id.databaseUri(), err)
+		}
+
+		timeoutMins := int(d.Timeout(schema.TimeoutUpdate).Minutes())
+		err = spannerDatabaseOperationWait(config, op, "Updating Spanner database ddl", timeoutMins)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSpannerDatabaseRead(d, meta)
+}
+
 func resourceSpannerDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -131,6 +131,35 @@ func TestAccSpannerDatabase_basicWithInitialDDL(t *testing.T) {
 	})
 }
 
+func TestAccSpannerDatabase_appendDDL(t *testing.T) {
+	t.Parallel()
+
+	var db spanner.Database
+	rnd := acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckSpannerInstanceDestroy,
+			testAccCheckSpannerDatabaseDestroy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpannerDatabase_basicWithInitialDDL(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpannerDatabaseExists("google_spanner_database.basic", &db),
+				),
+			},
+			{
+				Config: testAccSpannerDatabase_appendedDDL(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSpannerDatabaseExists("google_spanner_database.basic", &db),
+					resource.TestCheckResourceAttr("google_spanner_database.basic", "ddl.#", "3"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSpannerDatabase_duplicateNameError(t *testing.T) {
 	t.Parallel()
 
@@ -262,6 +291,26 @@ resource "google_spanner_database" "basic" {
 `, rnd, rnd, rnd)
 }
 
+func testAccSpannerDatabase_appendedDDL(rnd string) string {
+	return fmt.Sprintf(`
+resource "google_spanner_instance" "basic" {
+  name          = "my-instance-%s"
+  config        = "regional-us-central1"
+  display_name  = "my-displayname-%s"
+  num_nodes     = 1
+}
+
+resource "google_spanner_database" "basic" {
+  instance      = "${google_spanner_instance.basic.name}"
+  name          = "my-db-%s"
+  ddl           =  [
+     "CREATE TABLE t1 (t1 INT64 NOT NULL,) PRIMARY KEY(t1)",
+     "CREATE TABLE t2 (t2 INT64 NOT NULL,) PRIMARY KEY(t2)",
+     "CREATE TABLE t3 (t3 INT64 NOT NULL,) PRIMARY KEY(t3)" ]
+}
+`, rnd, rnd, rnd)
+}
+
 func testAccSpannerDatabase_duplicateNameError_part1(rnd, dbName string) string {
 	return fmt.Sprintf(`
 resource "google_spanner_instance" "basic" {
@@ -1,6 +1,7 @@
 package google
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
@@ -73,6 +74,12 @@ func resourceSqlDatabaseInstance() *schema.Resource {
 							Optional: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
+						"availability_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringInSlice([]string{"ZONAL", "REGIONAL"}, false),
+						},
 						"backup_configuration": &schema.Schema{
 							Type:     schema.TypeList,
 							Optional: true,
@@ -156,6 +163,11 @@ func resourceSqlDatabaseInstance() *schema.Resource {
 										// Defaults differ between first and second gen instances
 										Computed: true,
 									},
+									"private_network": &schema.Schema{
+										Type:             schema.TypeString,
+										Optional:         true,
+										DiffSuppressFunc: compareSelfLinkRelativePaths,
+									},
 									"require_ssl": &schema.Schema{
 										Type:     schema.TypeBool,
 										Optional: true,
@@ -244,10 +256,19 @@ func resourceSqlDatabaseInstance() *schema.Resource {
 							Optional: true,
 							Computed: true,
 						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
 
+			"private_ip_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -455,6 +476,11 @@ func resourceSqlDatabaseInstanceCreate(d *schema.ResourceData, meta interface{})
 				settings.IpConfiguration.Ipv4Enabled = vp.(bool)
 			}
 
+			// Instances with a private IP may go without a public one.
+			if vp, okp := _ipConfiguration["private_network"]; okp && vp.(string) != "" {
+				settings.IpConfiguration.ForceSendFields = []string{"Ipv4Enabled"}
+			}
+
 			if vp, okp := _ipConfiguration["require_ssl"]; okp {
 				settings.IpConfiguration.RequireSsl = vp.(bool)
 			}
@@ -603,7 +629,8 @@ func resourceSqlDatabaseInstanceCreate(d *schema.ResourceData, meta interface{})
 		instance.MasterInstanceName = v.(string)
 	}
 
-	op, err := config.clientSqlAdmin.Instances.Insert(project, instance).Do()
+	op, err := sendSqlDatabaseInstanceRequest(config, "POST", config.clientSqlAdmin.BasePath+"projects/"+project+"/instances",
+		instance, expandSqlDatabaseInstanceExtraSettings(_settings))
 	if err != nil {
 		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 409 {
 			return fmt.Errorf("Error, the name %s is unavailable because it was used recently", instance.Name)
@@ -663,9 +690,7 @@ func resourceSqlDatabaseInstanceRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
-	instance, err := config.clientSqlAdmin.Instances.Get(project,
-		d.Id()).Do()
-
+	instance, extra, err := getSqlDatabaseInstance(config, project, d.Id())
 	if err != nil {
 		return handleNotFoundError(err, d, fmt.Sprintf("SQL Database Instance %q", d.Get("name").(string)))
 	}
@@ -675,7 +700,7 @@ func resourceSqlDatabaseInstanceRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("database_version", instance.DatabaseVersion)
 	d.Set("connection_name", instance.ConnectionName)
 
-	if err := d.Set("settings", flattenSettings(instance.Settings)); err != nil {
+	if err := d.Set("settings", flattenSettings(instance.Settings, extra)); err != nil {
 		log.Printf("[WARN] Failed to set SQL Database Instance Settings")
 	}
 	if err := d.Set("replica_configuration", flattenReplicaConfiguration(instance.ReplicaConfiguration)); err != nil {
@@ -685,6 +710,13 @@ func resourceSqlDatabaseInstanceRead(d *schema.ResourceData, meta interface{}) e
 		log.Printf("[WARN] Failed to set SQL Database Instance IP Addresses")
 	}
 
+	d.Set("private_ip_address", "")
+	for _, ip := range instance.IpAddresses {
+		if ip.Type == "PRIVATE" {
+			d.Set("private_ip_address", ip.IpAddress)
+		}
+	}
+
 	d.Set("master_instance_name", strings.TrimPrefix(instance.MasterInstanceName, project+":"))
 
 	d.Set("self_link", instance.SelfLink)
@@ -828,6 +860,10 @@ func resourceSqlDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 					settings.IpConfiguration.Ipv4Enabled = vp.(bool)
 				}
 
+				if vp, okp := _ipConfiguration["private_network"]; okp && vp.(string) != "" {
+					settings.IpConfiguration.ForceSendFields = []string{"Ipv4Enabled"}
+				}
+
 				if vp, okp := _ipConfiguration["require_ssl"]; okp {
 					settings.IpConfiguration.RequireSsl = vp.(bool)
 				}
@@ -943,7 +979,11 @@ func resourceSqlDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 
 	d.Partial(false)
 
-	op, err := config.clientSqlAdmin.Instances.Update(project, instance.Name, instance).Do()
+	// The settings the vendored client predates are always sent, since the
+	// update replaces the instance's settings.
+	_settings := d.Get("settings").([]interface{})[0].(map[string]interface{})
+	op, err := sendSqlDatabaseInstanceRequest(config, "PUT", config.clientSqlAdmin.BasePath+"projects/"+project+"/instances/"+instance.Name,
+		instance, expandSqlDatabaseInstanceExtraSettings(_settings))
 	if err != nil {
 		return fmt.Errorf("Error, failed to update instance %s: %s", instance.Name, err)
 	}
@@ -978,12 +1018,13 @@ func resourceSqlDatabaseInstanceDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func flattenSettings(settings *sqladmin.Settings) []map[string]interface{} {
+func flattenSettings(settings *sqladmin.Settings, extra *sqlDatabaseInstanceExtraSettings) []map[string]interface{} {
 	data := map[string]interface{}{
 		"version":                     settings.SettingsVersion,
 		"tier":                        settings.Tier,
 		"activation_policy":           settings.ActivationPolicy,
 		"authorized_gae_applications": settings.AuthorizedGaeApplications,
+		"availability_type":           extra.Settings.AvailabilityType,
 		"crash_safe_replication":      settings.CrashSafeReplicationEnabled,
 		"disk_autoresize":             settings.StorageAutoResize,
 		"disk_type":                   settings.DataDiskType,
@@ -1001,7 +1042,7 @@ func flattenSettings(settings *sqladmin.Settings) []map[string]interface{} {
 	}
 
 	if settings.IpConfiguration != nil {
-		data["ip_configuration"] = flattenIpConfiguration(settings.IpConfiguration)
+		data["ip_configuration"] = flattenIpConfiguration(settings.IpConfiguration, extra.Settings.IpConfiguration.PrivateNetwork)
 	}
 
 	if settings.LocationPreference != nil {
@@ -1039,10 +1080,11 @@ func flattenDatabaseFlags(databaseFlags []*sqladmin.DatabaseFlags) []map[string]
 	return flags
 }
 
-func flattenIpConfiguration(ipConfiguration *sqladmin.IpConfiguration) interface{} {
+func flattenIpConfiguration(ipConfiguration *sqladmin.IpConfiguration, privateNetwork string) interface{} {
 	data := map[string]interface{}{
-		"ipv4_enabled": ipConfiguration.Ipv4Enabled,
-		"require_ssl":  ipConfiguration.RequireSsl,
+		"ipv4_enabled":    ipConfiguration.Ipv4Enabled,
+		"private_network": privateNetwork,
+		"require_ssl":     ipConfiguration.RequireSsl,
 	}
 
 	if ipConfiguration.AuthorizedNetworks != nil {
@@ -1111,6 +1153,7 @@ func flattenIpAddresses(ipAddresses []*sqladmin.IpMapping) []map[string]interfac
 		data := map[string]interface{}{
 			"ip_address":     ip.IpAddress,
 			"time_to_retire": ip.TimeToRetire,
+			"type":           ip.Type,
 		}
 
 		ips = append(ips, data)
@@ -1119,6 +1162,90 @@ func flattenIpAddresses(ipAddresses []*sqladmin.IpMapping) []map[string]interfac
 	return ips
 }
 
+// sqlDatabaseInstanceExtraSettings holds the instance settings the vendored
+// sqladmin client predates: high availability and private IP.
+type sqlDatabaseInstanceExtraSettings struct {
+	Settings struct {
+		AvailabilityType string `json:"availabilityType,omitempty"`
+		IpConfiguration  struct {
+			PrivateNetwork string `json:"privateNetwork,omitempty"`
+		} `json:"ipConfiguration"`
+	} `json:"settings"`
+}
+
+func expandSqlDatabaseInstanceExtraSettings(_settings map[string]interface{}) *sqlDatabaseInstanceExtraSettings {
+	extra := &sqlDatabaseInstanceExtraSettings{}
+	extra.Settings.AvailabilityType = _settings["availability_type"].(string)
+
+	if _ipConfigurationList := _settings["ip_configuration"].([]interface{}); len(_ipConfigurationList) == 1 && _ipConfigurationList[0] != nil {
+		_ipConfiguration := _ipConfigurationList[0].(map[string]interface{})
+		extra.Settings.IpConfiguration.PrivateNetwork = _ipConfiguration["private_network"].(string)
+	}
+
+	return extra
+}
+
+// getSqlDatabaseInstance reads an instance along with the settings that
+// aren't in the vendored sqladmin client.
+func getSqlDatabaseInstance(config *Config, project, name string) (*sqladmin.DatabaseInstance, *sqlDatabaseInstanceExtraSettings, error) {
+	var raw json.RawMessage
+	err := sendJsonRequest(config.client, config.clientSqlAdmin.UserAgent, "GET", config.clientSqlAdmin.BasePath+"projects/"+project+"/instances/"+name, nil, &raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instance := &sqladmin.DatabaseInstance{}
+	if err := json.Unmarshal(raw, instance); err != nil {
+		return nil, nil, err
+	}
+	extra := &sqlDatabaseInstanceExtraSettings{}
+	if err := json.Unmarshal(raw, extra); err != nil {
+		return nil, nil, err
+	}
+
+	return instance, extra, nil
+}
+
+// sendSqlDatabaseInstanceRequest sends instance to url like the vendored
+// client's insert and update calls, adding the settings in extra.
+func sendSqlDatabaseInstanceRequest(config *Config, method, url string, instance *sqladmin.DatabaseInstance, extra *sqlDatabaseInstanceExtraSettings) (*sqladmin.Operation, error) {
+	b, err := json.Marshal(instance)
+	if err != nil {
+		return nil, err
+	}
+	body := make(map[string]interface{})
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+
+	settings, ok := body["settings"].(map[string]interface{})
+	if !ok {
+		settings = make(map[string]interface{})
+		body["settings"] = settings
+	}
+	if extra.Settings.AvailabilityType != "" {
+		settings["availabilityType"] = extra.Settings.AvailabilityType
+	}
+	if privateNetwork := extra.Settings.IpConfiguration.PrivateNetwork; privateNetwork != "" {
+		// The API takes the network's relative path, not its self link.
+		privateNetwork, err := getRelativePath(privateNetwork)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid private_network: %s", err)
+		}
+
+		ipConfiguration, ok := settings["ipConfiguration"].(map[string]interface{})
+		if !ok {
+			ipConfiguration = make(map[string]interface{})
+			settings["ipConfiguration"] = ipConfiguration
+		}
+		ipConfiguration["privateNetwork"] = privateNetwork
+	}
+
+	op := &sqladmin.Operation{}
+	err = sendJsonRequest(config.client, config.clientSqlAdmin.UserAgent, method, url, body, op)
+	return op, err
+}
+
 func instanceMutexKey(project, instance_name string) string {
 	return fmt.Sprintf("google-sql-database-instance-%s-%s", project, instance_name)
 }
@@ -10,6 +10,7 @@ package google
 import (
 	"fmt"
 	"log"
+	"os"
 	"strconv"
 	"strings"
 	"testing"
@@ -349,6 +350,81 @@ func TestAccGoogleSqlDatabaseInstance_maintenance(t *testing.T) {
 	})
 }
 
+func TestAccGoogleSqlDatabaseInstance_highAvailability(t *testing.T) {
+	t.Parallel()
+
+	var instance sqladmin.DatabaseInstance
+	instanceID := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleSqlDatabaseInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(
+					testGoogleSqlDatabaseInstance_availabilityType, instanceID, "ZONAL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleSqlDatabaseInstanceExists(
+						"google_sql_database_instance.instance", &instance),
+					resource.TestCheckResourceAttr(
+						"google_sql_database_instance.instance", "settings.0.availability_type", "ZONAL"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(
+					testGoogleSqlDatabaseInstance_availabilityType, instanceID, "REGIONAL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleSqlDatabaseInstanceExists(
+						"google_sql_database_instance.instance", &instance),
+					resource.TestCheckResourceAttr(
+						"google_sql_database_instance.instance", "settings.0.availability_type", "REGIONAL"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_sql_database_instance.instance",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Private IP needs a network peered with the Service Networking API, which
+// can't be set up with this provider, so one is passed in through
+// GOOGLE_SQL_PRIVATE_NETWORK, e.g. projects/my-project/global/networks/default.
+func TestAccGoogleSqlDatabaseInstance_privateIp(t *testing.T) {
+	t.Parallel()
+
+	skipIfEnvNotSet(t, "GOOGLE_SQL_PRIVATE_NETWORK")
+
+	instanceID := acctest.RandInt()
+	network := os.Getenv("GOOGLE_SQL_PRIVATE_NETWORK")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleSqlDatabaseInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(
+					testGoogleSqlDatabaseInstance_privateIp, instanceID, network),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"google_sql_database_instance.instance", "private_ip_address"),
+					resource.TestCheckResourceAttr(
+						"google_sql_database_instance.instance", "settings.0.ip_configuration.0.ipv4_enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_sql_database_instance.instance",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccGoogleSqlDatabaseInstance_settings_upgrade(t *testing.T) {
 	t.Parallel()
 
@@ -916,6 +992,41 @@ resource "google_sql_database_instance" "instance" {
 }
 `
 
+var testGoogleSqlDatabaseInstance_availabilityType = `
+resource "google_sql_database_instance" "instance" {
+	name = "tf-lw-%d"
+	region = "us-central1"
+	database_version = "MYSQL_5_7"
+
+	settings {
+		tier = "db-n1-standard-1"
+		availability_type = "%s"
+
+		backup_configuration {
+			enabled = true
+			binary_log_enabled = true
+		}
+	}
+}
+`
+
+var testGoogleSqlDatabaseInstance_privateIp = `
+resource "google_sql_database_instance" "instance" {
+	name = "tf-lw-%d"
+	region = "us-central1"
+	database_version = "MYSQL_5_7"
+
+	settings {
+		tier = "db-f1-micro"
+
+		ip_configuration {
+			ipv4_enabled = false
+			private_network = "%s"
+		}
+	}
+}
+`
+
 var testGoogleSqlDatabaseInstance_authNets_step1 = `
 resource "google_sql_database_instance" "instance" {
 	name = "tf-lw-%d"
@@ -6,11 +6,10 @@ import (
 	"fmt"
 	"log"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/storage/v1"
@@ -100,8 +99,9 @@ func resourceStorageBucket() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"type": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"Delete", "SetStorageClass"}, false),
 									},
 									"storage_class": {
 										Type:     schema.TypeString,
@@ -147,6 +147,73 @@ func resourceStorageBucket() *schema.Resource {
 				},
 			},
 
+			"encryption": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_kms_key_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"billing": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"requester_pays": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"retention_policy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"retention_period": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 3155760000),
+						},
+						"is_locked": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"logging": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"log_bucket": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"log_object_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"versioning": &schema.Schema{
 				Type:     schema.TypeList,
 				Optional: true,
@@ -228,6 +295,10 @@ func resourceStorageBucketCreate(d *schema.ResourceData, meta interface{}) error
 	bucket := d.Get("name").(string)
 	location := d.Get("location").(string)
 
+	if err := checkRequiredLabels(config, d.Get("labels").(map[string]interface{})); err != nil {
+		return fmt.Errorf("Error creating bucket %s: %s", bucket, err)
+	}
+
 	// Create a bucket, setting the acl, location and name.
 	sb := &storage.Bucket{
 		Name:     bucket,
@@ -247,6 +318,18 @@ func resourceStorageBucketCreate(d *schema.ResourceData, meta interface{}) error
 		sb.Versioning = expandBucketVersioning(v)
 	}
 
+	if v, ok := d.GetOk("encryption"); ok {
+		sb.Encryption = expandBucketEncryption(v)
+	}
+
+	if v, ok := d.GetOk("logging"); ok {
+		sb.Logging = expandBucketLogging(v)
+	}
+
+	if v, ok := d.GetOk("billing"); ok {
+		sb.Billing = expandBucketBilling(v)
+	}
+
 	if v, ok := d.GetOk("website"); ok {
 		websites := v.([]interface{})
 
@@ -286,6 +369,18 @@ func resourceStorageBucketCreate(d *schema.ResourceData, meta interface{}) error
 	log.Printf("[DEBUG] Created bucket %v at location %v\n\n", res.Name, res.SelfLink)
 
 	d.SetId(res.Id)
+
+	if _, ok := d.GetOk("retention_policy"); ok {
+		userProject, err := storageBucketUserProject(d, config)
+		if err != nil {
+			return err
+		}
+
+		if err := updateStorageBucketRetentionPolicy(d, config, userProject); err != nil {
+			return err
+		}
+	}
+
 	return resourceStorageBucketRead(d, meta)
 }
 
@@ -298,11 +393,52 @@ func resourceStorageBucketUpdate(d *schema.ResourceData, meta interface{}) error
 		if err := resourceGCSBucketLifecycleCreateOrUpdate(d, sb); err != nil {
 			return err
 		}
+
+		// Send an empty list of rules to remove the last ones.
+		if sb.Lifecycle == nil {
+			sb.Lifecycle = &storage.BucketLifecycle{
+				ForceSendFields: []string{"Rule"},
+			}
+		}
 	}
 
 	if d.HasChange("versioning") {
 		if v, ok := d.GetOk("versioning"); ok {
 			sb.Versioning = expandBucketVersioning(v)
+		} else {
+			// Removing the block suspends versioning, keeping existing
+			// noncurrent versions.
+			sb.Versioning = &storage.BucketVersioning{
+				Enabled:         false,
+				ForceSendFields: []string{"Enabled"},
+			}
+		}
+	}
+
+	if d.HasChange("encryption") {
+		if v, ok := d.GetOk("encryption"); ok {
+			sb.Encryption = expandBucketEncryption(v)
+		} else {
+			sb.NullFields = append(sb.NullFields, "Encryption")
+		}
+	}
+
+	if d.HasChange("billing") {
+		if v, ok := d.GetOk("billing"); ok {
+			sb.Billing = expandBucketBilling(v)
+		} else {
+			sb.Billing = &storage.BucketBilling{
+				RequesterPays:   false,
+				ForceSendFields: []string{"RequesterPays"},
+			}
+		}
+	}
+
+	if d.HasChange("logging") {
+		if v, ok := d.GetOk("logging"); ok {
+			sb.Logging = expandBucketLogging(v)
+		} else {
+			sb.NullFields = append(sb.NullFields, "Logging")
 		}
 	}
 
@@ -342,13 +478,22 @@ func resourceStorageBucketUpdate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if d.HasChange("labels") {
+		if err := checkRequiredLabels(config, d.Get("labels").(map[string]interface{})); err != nil {
+			return fmt.Errorf("Error updating bucket %s: %s", d.Get("name").(string), err)
+		}
+
 		sb.Labels = expandLabels(d)
 		if len(sb.Labels) == 0 {
 			sb.NullFields = append(sb.NullFields, "Labels")
 		}
 	}
 
-	res, err := config.clientStorage.Buckets.Patch(d.Get("name").(string), sb).Do()
+	userProject, err := storageBucketUserProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientStorage.Buckets.Patch(d.Get("name").(string), sb).Do(storageUserProject(userProject)...)
 
 	if err != nil {
 		return err
@@ -356,6 +501,12 @@ func resourceStorageBucketUpdate(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[DEBUG] Patched bucket %v at location %v\n\n", res.Name, res.SelfLink)
 
+	if d.HasChange("retention_policy") {
+		if err := updateStorageBucketRetentionPolicy(d, config, userProject); err != nil {
+			return err
+		}
+	}
+
 	// Assign the bucket ID as the resource ID
 	d.Set("self_link", res.SelfLink)
 	d.SetId(res.Id)
@@ -368,7 +519,12 @@ func resourceStorageBucketRead(d *schema.ResourceData, meta interface{}) error {
 
 	// Get the bucket and acl
 	bucket := d.Get("name").(string)
-	res, err := config.clientStorage.Buckets.Get(bucket).Do()
+	userProject, err := storageBucketUserProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientStorage.Buckets.Get(bucket).Do(storageUserProject(userProject)...)
 
 	if err != nil {
 		return handleNotFoundError(err, d, fmt.Sprintf("Storage Bucket %q", d.Get("name").(string)))
@@ -382,8 +538,25 @@ func resourceStorageBucketRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("storage_class", res.StorageClass)
 	d.Set("location", res.Location)
 	d.Set("cors", flattenCors(res.Cors))
-	d.Set("versioning", flattenBucketVersioning(res.Versioning))
+	// Versioning which was never enabled, or was suspended by removing the
+	// block, is the same as no versioning block.
+	if _, ok := d.GetOk("versioning"); !ok && (res.Versioning == nil || !res.Versioning.Enabled) {
+		d.Set("versioning", nil)
+	} else {
+		d.Set("versioning", flattenBucketVersioning(res.Versioning))
+	}
+	d.Set("encryption", flattenBucketEncryption(res.Encryption))
+	d.Set("logging", flattenBucketLogging(res.Logging))
+	d.Set("billing", flattenBucketBilling(res.Billing))
+	d.Set("lifecycle_rule", flattenBucketLifecycle(res.Lifecycle))
 	d.Set("labels", res.Labels)
+
+	retention, err := getStorageBucketRetention(config, bucket, userProject)
+	if err != nil {
+		return fmt.Errorf("Error reading retention policy of bucket %s: %s", bucket, err)
+	}
+	d.Set("retention_policy", flattenBucketRetentionPolicy(retention.RetentionPolicy))
+
 	d.SetId(res.Id)
 	return nil
 }
@@ -393,9 +566,13 @@ func resourceStorageBucketDelete(d *schema.ResourceData, meta interface{}) error
 
 	// Get the bucket
 	bucket := d.Get("name").(string)
+	userProject, err := storageBucketUserProject(d, config)
+	if err != nil {
+		return err
+	}
 
 	for {
-		res, err := config.clientStorage.Objects.List(bucket).Do()
+		res, err := config.clientStorage.Objects.List(bucket).Do(storageUserProject(userProject)...)
 		if err != nil {
 			fmt.Printf("Error Objects.List failed: %v", err)
 			return err
@@ -408,7 +585,7 @@ func resourceStorageBucketDelete(d *schema.ResourceData, meta interface{}) error
 
 				for _, object := range res.Items {
 					log.Printf("[DEBUG] Found %s", object.Name)
-					if err := config.clientStorage.Objects.Delete(bucket, object.Name).Do(); err != nil {
+					if err := config.clientStorage.Objects.Delete(bucket, object.Name).Do(storageUserProject(userProject)...); err != nil {
 						log.Fatalf("Error trying to delete object: %s %s\n\n", object.Name, err)
 					} else {
 						log.Printf("Object deleted: %s \n\n", object.Name)
@@ -425,17 +602,8 @@ func resourceStorageBucketDelete(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	// remove empty bucket
-	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
-		err := config.clientStorage.Buckets.Delete(bucket).Do()
-		if err == nil {
-			return nil
-		}
-		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 429 {
-			return resource.RetryableError(gerr)
-		}
-		return resource.NonRetryableError(err)
-	})
+	// remove empty bucket, rate limiting is retried by the provider's retryTransport
+	err = config.clientStorage.Buckets.Delete(bucket).Do(storageUserProject(userProject)...)
 	if err != nil {
 		fmt.Printf("Error deleting bucket %s: %v\n\n", bucket, err)
 		return err
@@ -507,6 +675,156 @@ func flattenBucketVersioning(bucketVersioning *storage.BucketVersioning) []map[s
 	return versionings
 }
 
+func expandBucketEncryption(configured interface{}) *storage.BucketEncryption {
+	encryptions := configured.([]interface{})
+	encryption := encryptions[0].(map[string]interface{})
+
+	return &storage.BucketEncryption{
+		DefaultKmsKeyName: encryption["default_kms_key_name"].(string),
+	}
+}
+
+func flattenBucketEncryption(bucketEncryption *storage.BucketEncryption) []map[string]interface{} {
+	encryptions := make([]map[string]interface{}, 0, 1)
+
+	if bucketEncryption == nil || bucketEncryption.DefaultKmsKeyName == "" {
+		return encryptions
+	}
+
+	encryption := map[string]interface{}{
+		"default_kms_key_name": bucketEncryption.DefaultKmsKeyName,
+	}
+	encryptions = append(encryptions, encryption)
+	return encryptions
+}
+
+func expandBucketLogging(configured interface{}) *storage.BucketLogging {
+	loggings := configured.([]interface{})
+	logging := loggings[0].(map[string]interface{})
+
+	return &storage.BucketLogging{
+		LogBucket:       logging["log_bucket"].(string),
+		LogObjectPrefix: logging["log_object_prefix"].(string),
+	}
+}
+
+func flattenBucketLogging(bucketLogging *storage.BucketLogging) []map[string]interface{} {
+	loggings := make([]map[string]interface{}, 0, 1)
+
+	if bucketLogging == nil {
+		return loggings
+	}
+
+	logging := map[string]interface{}{
+		"log_bucket":        bucketLogging.LogBucket,
+		"log_object_prefix": bucketLogging.LogObjectPrefix,
+	}
+	loggings = append(loggings, logging)
+	return loggings
+}
+
+func expandBucketBilling(configured interface{}) *storage.BucketBilling {
+	billings := configured.([]interface{})
+	billing := billings[0].(map[string]interface{})
+
+	return &storage.BucketBilling{
+		RequesterPays:   billing["requester_pays"].(bool),
+		ForceSendFields: []string{"RequesterPays"},
+	}
+}
+
+func flattenBucketBilling(bucketBilling *storage.BucketBilling) []map[string]interface{} {
+	billings := make([]map[string]interface{}, 0, 1)
+
+	if bucketBilling == nil {
+		return billings
+	}
+
+	billing := map[string]interface{}{
+		"requester_pays": bucketBilling.RequesterPays,
+	}
+	billings = append(billings, billing)
+	return billings
+}
+
+func expandBucketRetentionPolicy(configured interface{}) *storageBucketRetentionPolicy {
+	policies := configured.([]interface{})
+	policy := policies[0].(map[string]interface{})
+
+	return &storageBucketRetentionPolicy{
+		RetentionPeriod: int64(policy["retention_period"].(int)),
+	}
+}
+
+func flattenBucketRetentionPolicy(policy *storageBucketRetentionPolicy) []map[string]interface{} {
+	policies := make([]map[string]interface{}, 0, 1)
+
+	if policy == nil {
+		return policies
+	}
+
+	policies = append(policies, map[string]interface{}{
+		"retention_period": int(policy.RetentionPeriod),
+		"is_locked":        policy.IsLocked,
+	})
+	return policies
+}
+
+// updateStorageBucketRetentionPolicy sets the bucket's retention policy to
+// the configured one, locking it if is_locked has become true. Locking is
+// irreversible: a locked policy can't be removed, unlocked or shortened.
+func updateStorageBucketRetentionPolicy(d *schema.ResourceData, config *Config, userProject string) error {
+	bucket := d.Get("name").(string)
+
+	o, n := d.GetChange("retention_policy.0.is_locked")
+	if o.(bool) && !n.(bool) {
+		return fmt.Errorf("Error updating bucket %s: its retention policy is locked, and can't be unlocked or removed", bucket)
+	}
+
+	var policy *storageBucketRetentionPolicy
+	if v, ok := d.GetOk("retention_policy"); ok {
+		policy = expandBucketRetentionPolicy(v)
+	}
+
+	retention, err := setStorageBucketRetentionPolicy(config, bucket, userProject, policy)
+	if err != nil {
+		return fmt.Errorf("Error setting retention policy of bucket %s: %s", bucket, err)
+	}
+
+	if n.(bool) && !o.(bool) {
+		log.Printf("[DEBUG] Locking retention policy of bucket %s", bucket)
+		if err := lockStorageBucketRetentionPolicy(config, bucket, userProject, retention.Metageneration); err != nil {
+			return fmt.Errorf("Error locking retention policy of bucket %s: %s", bucket, err)
+		}
+	}
+
+	return nil
+}
+
+// storageBucketUserProject returns the project to bill for calls against the
+// bucket of d, which is the bucket's own project if it is (or is becoming,
+// or ceasing to be) requester pays, and none otherwise.
+func storageBucketUserProject(d *schema.ResourceData, config *Config) (string, error) {
+	o, n := d.GetChange("billing.0.requester_pays")
+	if !o.(bool) && !n.(bool) {
+		return "", nil
+	}
+	return getProject(d, config)
+}
+
+type storageUserProjectOption string
+
+func (p storageUserProjectOption) Get() (string, string) { return "userProject", string(p) }
+
+// storageUserProject returns the options setting the userProject billed for
+// a storage call, if any. Requester pays buckets reject calls without one.
+func storageUserProject(userProject string) []googleapi.CallOption {
+	if userProject == "" {
+		return nil
+	}
+	return []googleapi.CallOption{storageUserProjectOption(userProject)}
+}
+
 func resourceGCSBucketLifecycleCreateOrUpdate(d *schema.ResourceData, sb *storage.Bucket) error {
 	if v, ok := d.GetOk("lifecycle_rule"); ok {
 		lifecycle_rules := v.([]interface{})
@@ -582,6 +900,52 @@ func resourceGCSBucketLifecycleCreateOrUpdate(d *schema.ResourceData, sb *storag
 	return nil
 }
 
+func flattenBucketLifecycle(lifecycle *storage.BucketLifecycle) []map[string]interface{} {
+	if lifecycle == nil || lifecycle.Rule == nil {
+		return []map[string]interface{}{}
+	}
+
+	rules := make([]map[string]interface{}, 0, len(lifecycle.Rule))
+	for _, rule := range lifecycle.Rule {
+		rules = append(rules, map[string]interface{}{
+			"action":    schema.NewSet(resourceGCSBucketLifecycleRuleActionHash, []interface{}{flattenBucketLifecycleRuleAction(rule.Action)}),
+			"condition": schema.NewSet(resourceGCSBucketLifecycleRuleConditionHash, []interface{}{flattenBucketLifecycleRuleCondition(rule.Condition)}),
+		})
+	}
+
+	return rules
+}
+
+func flattenBucketLifecycleRuleAction(action *storage.BucketLifecycleRuleAction) map[string]interface{} {
+	if action == nil {
+		return map[string]interface{}{"type": "", "storage_class": ""}
+	}
+
+	return map[string]interface{}{
+		"type":          action.Type,
+		"storage_class": action.StorageClass,
+	}
+}
+
+func flattenBucketLifecycleRuleCondition(condition *storage.BucketLifecycleRuleCondition) map[string]interface{} {
+	if condition == nil {
+		return map[string]interface{}{}
+	}
+
+	result := map[string]interface{}{
+		"age":                   int(condition.Age),
+		"created_before":        condition.CreatedBefore,
+		"is_live":               false,
+		"matches_storage_class": convertStringArrToInterface(condition.MatchesStorageClass),
+		"num_newer_versions":    int(condition.NumNewerVersions),
+	}
+	if condition.IsLive != nil {
+		result["is_live"] = *condition.IsLive
+	}
+
+	return result
+}
+
 func resourceGCSBucketLifecycleRuleActionHash(v interface{}) int {
 	if v == nil {
 		return 0
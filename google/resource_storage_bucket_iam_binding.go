@@ -0,0 +1,165 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceStorageBucketIamBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageBucketIamBindingCreate,
+		Read:   resourceStorageBucketIamBindingRead,
+		Update: resourceStorageBucketIamBindingUpdate,
+		Delete: resourceStorageBucketIamBindingDelete,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"condition": iamConditionSchema(true),
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStorageBucketIamBindingCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+
+	binding := getStorageBucketIamBinding(d)
+	err := storageBucketIamPolicyReadModifyWrite(config, bucket, func(p *iamPolicy) error {
+		p.Bindings = setStorageBucketIamBinding(p.Bindings, binding)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(storageBucketIamBindingId(bucket, binding.Role, binding.Condition))
+	return resourceStorageBucketIamBindingRead(d, meta)
+}
+
+func resourceStorageBucketIamBindingRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+	role := d.Get("role").(string)
+	condition := expandIamCondition(d.Get("condition"))
+
+	p, err := getStorageBucketIamPolicy(config, bucket)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM binding for role %q on bucket %q", role, bucket))
+	}
+
+	var binding *iamPolicyBinding
+	for _, b := range p.Bindings {
+		if storageBucketIamBindingMatches(b, role, condition) {
+			binding = b
+			break
+		}
+	}
+	if binding == nil {
+		log.Printf("[DEBUG]: Binding for role %q not found in policy for bucket %q, removing from state file.\n", role, bucket)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("etag", p.Etag)
+	d.Set("members", binding.Members)
+	return nil
+}
+
+func resourceStorageBucketIamBindingUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+
+	binding := getStorageBucketIamBinding(d)
+	err := storageBucketIamPolicyReadModifyWrite(config, bucket, func(p *iamPolicy) error {
+		p.Bindings = setStorageBucketIamBinding(p.Bindings, binding)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceStorageBucketIamBindingRead(d, meta)
+}
+
+func resourceStorageBucketIamBindingDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+	role := d.Get("role").(string)
+	binding := &iamPolicyBinding{
+		Role:      role,
+		Condition: expandIamCondition(d.Get("condition")),
+	}
+
+	err := storageBucketIamPolicyReadModifyWrite(config, bucket, func(p *iamPolicy) error {
+		p.Bindings = setStorageBucketIamBinding(p.Bindings, binding)
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM binding for role %q on bucket %q", role, bucket))
+	}
+
+	return nil
+}
+
+func getStorageBucketIamBinding(d *schema.ResourceData) *iamPolicyBinding {
+	return &iamPolicyBinding{
+		Role:      d.Get("role").(string),
+		Members:   convertStringArr(d.Get("members").(*schema.Set).List()),
+		Condition: expandIamCondition(d.Get("condition")),
+	}
+}
+
+// storageBucketIamBindingId returns the ID of a binding resource, which has
+// the condition title appended for conditional bindings.
+func storageBucketIamBindingId(bucket, role string, condition *iamCondition) string {
+	id := bucket + "/" + role
+	if condition != nil {
+		id += "/" + condition.Title
+	}
+	return id
+}
+
+// storageBucketIamBindingMatches reports whether b is the binding for role
+// with the given condition. Bindings for the same role with different
+// conditions are distinct.
+func storageBucketIamBindingMatches(b *iamPolicyBinding, role string, condition *iamCondition) bool {
+	return b.Role == role && reflect.DeepEqual(b.Condition, condition)
+}
+
+// setStorageBucketIamBinding replaces the binding for the role and condition
+// of binding in bindings, removing it altogether if binding has no members.
+func setStorageBucketIamBinding(bindings []*iamPolicyBinding, binding *iamPolicyBinding) []*iamPolicyBinding {
+	result := make([]*iamPolicyBinding, 0, len(bindings)+1)
+	for _, b := range bindings {
+		if !storageBucketIamBindingMatches(b, binding.Role, binding.Condition) {
+			result = append(result, b)
+		}
+	}
+	if len(binding.Members) > 0 {
+		result = append(result, binding)
+	}
+	return result
+}
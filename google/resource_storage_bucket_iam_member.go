@@ -0,0 +1,138 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceStorageBucketIamMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageBucketIamMemberCreate,
+		Read:   resourceStorageBucketIamMemberRead,
+		Delete: resourceStorageBucketIamMemberDelete,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"member": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"condition": iamConditionSchema(true),
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStorageBucketIamMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+	condition := expandIamCondition(d.Get("condition"))
+
+	err := storageBucketIamPolicyReadModifyWrite(config, bucket, func(p *iamPolicy) error {
+		p.Bindings = addStorageBucketIamMember(p.Bindings, role, member, condition)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(storageBucketIamBindingId(bucket, role, condition) + "/" + member)
+	return resourceStorageBucketIamMemberRead(d, meta)
+}
+
+func resourceStorageBucketIamMemberRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+	condition := expandIamCondition(d.Get("condition"))
+
+	p, err := getStorageBucketIamPolicy(config, bucket)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM member %q for role %q on bucket %q", member, role, bucket))
+	}
+
+	for _, b := range p.Bindings {
+		if storageBucketIamBindingMatches(b, role, condition) && stringInSlice(b.Members, member) {
+			d.Set("etag", p.Etag)
+			return nil
+		}
+	}
+
+	log.Printf("[DEBUG]: Member %q for role %q not found in policy for bucket %q, removing from state file.\n", member, role, bucket)
+	d.SetId("")
+	return nil
+}
+
+func resourceStorageBucketIamMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+	condition := expandIamCondition(d.Get("condition"))
+
+	err := storageBucketIamPolicyReadModifyWrite(config, bucket, func(p *iamPolicy) error {
+		p.Bindings = removeStorageBucketIamMember(p.Bindings, role, member, condition)
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM member %q for role %q on bucket %q", member, role, bucket))
+	}
+
+	return nil
+}
+
+func addStorageBucketIamMember(bindings []*iamPolicyBinding, role, member string, condition *iamCondition) []*iamPolicyBinding {
+	for _, b := range bindings {
+		if storageBucketIamBindingMatches(b, role, condition) {
+			if !stringInSlice(b.Members, member) {
+				b.Members = append(b.Members, member)
+			}
+			return bindings
+		}
+	}
+	return append(bindings, &iamPolicyBinding{
+		Role:      role,
+		Members:   []string{member},
+		Condition: condition,
+	})
+}
+
+// removeStorageBucketIamMember removes member from the binding for role and
+// condition, dropping the binding once it has no members left.
+func removeStorageBucketIamMember(bindings []*iamPolicyBinding, role, member string, condition *iamCondition) []*iamPolicyBinding {
+	result := make([]*iamPolicyBinding, 0, len(bindings))
+	for _, b := range bindings {
+		if storageBucketIamBindingMatches(b, role, condition) {
+			members := make([]string, 0, len(b.Members))
+			for _, m := range b.Members {
+				if m != member {
+					members = append(members, m)
+				}
+			}
+			if len(members) == 0 {
+				continue
+			}
+			b.Members = members
+		}
+		result = append(result, b)
+	}
+	return result
+}
@@ -0,0 +1,218 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceStorageBucketIamPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageBucketIamPolicyCreate,
+		Read:   resourceStorageBucketIamPolicyRead,
+		Update: resourceStorageBucketIamPolicyUpdate,
+		Delete: resourceStorageBucketIamPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_data": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: jsonPolicyDiffSuppress,
+				ValidateFunc:     validateStorageBucketIamPolicy,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStorageBucketIamPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+
+	if err := setStorageBucketIamPolicyData(d, config); err != nil {
+		return err
+	}
+
+	d.SetId(bucket)
+	return resourceStorageBucketIamPolicyRead(d, meta)
+}
+
+func resourceStorageBucketIamPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+
+	policy, err := getStorageBucketIamPolicy(config, bucket)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM policy for bucket %q", bucket))
+	}
+
+	d.Set("etag", policy.Etag)
+	d.Set("policy_data", marshalStorageBucketIamPolicy(policy))
+
+	return nil
+}
+
+func resourceStorageBucketIamPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.HasChange("policy_data") {
+		if err := setStorageBucketIamPolicyData(d, config); err != nil {
+			return err
+		}
+	}
+
+	return resourceStorageBucketIamPolicyRead(d, meta)
+}
+
+func resourceStorageBucketIamPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bucket := d.Get("bucket").(string)
+
+	err := storageBucketIamPolicyReadModifyWrite(config, bucket, func(p *iamPolicy) error {
+		p.Bindings = nil
+		return nil
+	})
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IAM policy for bucket %q", bucket))
+	}
+
+	return nil
+}
+
+func setStorageBucketIamPolicyData(d *schema.ResourceData, config *Config) error {
+	bucket := d.Get("bucket").(string)
+	policy, err := unmarshalStorageBucketIamPolicy(d.Get("policy_data").(string))
+	if err != nil {
+		return fmt.Errorf("'policy_data' is not valid for bucket %q: %s", bucket, err)
+	}
+
+	return storageBucketIamPolicyReadModifyWrite(config, bucket, func(p *iamPolicy) error {
+		p.Bindings = policy.Bindings
+		return nil
+	})
+}
+
+// marshalStorageBucketIamPolicy returns the policy_data of policy. Like the
+// google_iam_policy data source, it only includes the version for policies
+// with conditional bindings.
+func marshalStorageBucketIamPolicy(policy *iamPolicy) string {
+	p := &iamPolicy{
+		Bindings: policy.Bindings,
+	}
+	if iamPolicyHasConditions(p.Bindings) {
+		p.Version = iamConditionalPolicyVersion
+	}
+	pdBytes, _ := json.Marshal(p)
+	return string(pdBytes)
+}
+
+func unmarshalStorageBucketIamPolicy(policyData string) (*iamPolicy, error) {
+	policy := &iamPolicy{}
+	if err := json.Unmarshal([]byte(policyData), policy); err != nil {
+		return nil, fmt.Errorf("Could not unmarshal policy data %s:\n%s", policyData, err)
+	}
+	return policy, nil
+}
+
+func validateStorageBucketIamPolicy(i interface{}, k string) (s []string, es []error) {
+	_, err := unmarshalStorageBucketIamPolicy(i.(string))
+	if err != nil {
+		es = append(es, err)
+	}
+	return
+}
+
+// The vendored storage/v1 client can neither request nor set an IAM policy
+// version, and conditional bindings need version 3, so bucket IAM policies are
+// read and written with the Cloud Storage JSON API directly.
+
+func storageBucketIamPolicyUrl(config *Config, bucket string, params url.Values) string {
+	return config.clientStorage.BasePath + "b/" + url.PathEscape(bucket) + "/iam?" + params.Encode()
+}
+
+func getStorageBucketIamPolicy(config *Config, bucket string) (*iamPolicy, error) {
+	u := storageBucketIamPolicyUrl(config, bucket, url.Values{"optionsRequestedPolicyVersion": {fmt.Sprint(iamConditionalPolicyVersion)}})
+	res := &iamPolicy{}
+	err := sendJsonRequest(config.client, config.clientStorage.UserAgent, "GET", u, nil, res)
+	return res, err
+}
+
+// setStorageBucketIamPolicy sets the IAM policy of bucket, raising its version
+// to 3 if any binding has a condition.
+func setStorageBucketIamPolicy(config *Config, bucket string, policy *iamPolicy) error {
+	if iamPolicyHasConditions(policy.Bindings) {
+		policy.Version = iamConditionalPolicyVersion
+	}
+	u := storageBucketIamPolicyUrl(config, bucket, url.Values{})
+	return sendJsonRequest(config.client, config.clientStorage.UserAgent, "PUT", u, policy, nil)
+}
+
+type storageBucketIamPolicyModifyFunc func(p *iamPolicy) error
+
+// storageBucketIamPolicyReadModifyWrite applies modify to the current IAM
+// policy of bucket. Changes made by the bucket IAM resources are serialized
+// per bucket, and the whole read-modify-write is restarted if the policy was
+// changed by someone else in the meantime.
+func storageBucketIamPolicyReadModifyWrite(config *Config, bucket string, modify storageBucketIamPolicyModifyFunc) error {
+	mutexKV.Lock(storageBucketIamMutexKey(bucket))
+	defer mutexKV.Unlock(storageBucketIamMutexKey(bucket))
+
+	backoff := time.Second
+	for {
+		log.Printf("[DEBUG]: Retrieving IAM policy for bucket %q\n", bucket)
+		p, err := getStorageBucketIamPolicy(config, bucket)
+		if err != nil {
+			return err
+		}
+
+		if err := modify(p); err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG]: Setting IAM policy for bucket %q to %+v\n", bucket, p)
+		err = setStorageBucketIamPolicy(config, bucket, p)
+		if err == nil {
+			break
+		}
+		if isStorageIamConflictError(err) {
+			log.Printf("[DEBUG]: Concurrent policy changes, restarting read-modify-write after %s\n", backoff)
+			time.Sleep(backoff)
+			backoff = backoff * 2
+			if backoff > 30*time.Second {
+				return fmt.Errorf("Error applying IAM policy to bucket %q: too many concurrent policy changes", bucket)
+			}
+			continue
+		}
+		return fmt.Errorf("Error applying IAM policy to bucket %q: %s", bucket, err)
+	}
+	log.Printf("[DEBUG]: Set IAM policy for bucket %q\n", bucket)
+
+	return nil
+}
+
+// isStorageIamConflictError reports whether err means the policy's etag was
+// stale. Cloud Storage answers 412 rather than 409 in that case.
+func isStorageIamConflictError(err error) bool {
+	if e, ok := err.(*googleapi.Error); ok && e.Code == http.StatusPreconditionFailed {
+		return true
+	}
+	return isConflictError(err)
+}
+
+func storageBucketIamMutexKey(bucket string) string {
+	return fmt.Sprintf("google-storage-bucket-iam-%s", bucket)
+}
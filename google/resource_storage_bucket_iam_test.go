@@ -0,0 +1,377 @@
+package google
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"google.golang.org/api/googleapi"
+)
+
+func TestStorageBucketIamBindings(t *testing.T) {
+	t.Parallel()
+
+	bindings := func() []*iamPolicyBinding {
+		return []*iamPolicyBinding{
+			{Role: "roles/storage.legacyBucketOwner", Members: []string{"projectOwner:my-project"}},
+			{Role: "roles/storage.objectAdmin", Members: []string{"user:a@example.com", "user:b@example.com"}},
+		}
+	}
+
+	actual := addStorageBucketIamMember(bindings(), "roles/storage.objectAdmin", "user:c@example.com", nil)
+	expected := []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}
+	if !reflect.DeepEqual(actual[1].Members, expected) {
+		t.Errorf("Expected members %v after adding, got %v", expected, actual[1].Members)
+	}
+
+	actual = addStorageBucketIamMember(bindings(), "roles/storage.objectViewer", "user:c@example.com", nil)
+	if len(actual) != 3 || actual[2].Role != "roles/storage.objectViewer" {
+		t.Errorf("Expected a new binding for roles/storage.objectViewer, got %+v", actual)
+	}
+
+	actual = removeStorageBucketIamMember(bindings(), "roles/storage.objectAdmin", "user:a@example.com", nil)
+	if !reflect.DeepEqual(actual[1].Members, []string{"user:b@example.com"}) {
+		t.Errorf("Expected user:a@example.com to be removed, got %v", actual[1].Members)
+	}
+
+	actual = removeStorageBucketIamMember(bindings(), "roles/storage.legacyBucketOwner", "projectOwner:my-project", nil)
+	if len(actual) != 1 || actual[0].Role != "roles/storage.objectAdmin" {
+		t.Errorf("Expected the emptied binding to be removed, got %+v", actual)
+	}
+
+	actual = setStorageBucketIamBinding(bindings(), &iamPolicyBinding{
+		Role:    "roles/storage.objectAdmin",
+		Members: []string{"user:c@example.com"},
+	})
+	if len(actual) != 2 || !reflect.DeepEqual(actual[1].Members, []string{"user:c@example.com"}) {
+		t.Errorf("Expected the binding to be replaced, got %+v", actual)
+	}
+
+	actual = setStorageBucketIamBinding(bindings(), &iamPolicyBinding{Role: "roles/storage.objectAdmin"})
+	if len(actual) != 1 {
+		t.Errorf("Expected a binding without members to be removed, got %+v", actual)
+	}
+
+	// Bindings for the same role with different conditions are distinct.
+	condition := &iamCondition{Title: "expires", Expression: `request.time < timestamp("2020-01-01T00:00:00Z")`}
+	actual = addStorageBucketIamMember(bindings(), "roles/storage.objectAdmin", "user:c@example.com", condition)
+	if len(actual) != 3 || !reflect.DeepEqual(actual[1].Members, []string{"user:a@example.com", "user:b@example.com"}) {
+		t.Errorf("Expected a new conditional binding, got %+v", actual)
+	}
+
+	conditional := append(bindings(), &iamPolicyBinding{
+		Role:      "roles/storage.objectAdmin",
+		Members:   []string{"user:c@example.com"},
+		Condition: &iamCondition{Title: "expires", Expression: condition.Expression},
+	})
+	actual = removeStorageBucketIamMember(conditional, "roles/storage.objectAdmin", "user:c@example.com", condition)
+	if len(actual) != 2 || len(actual[1].Members) != 2 {
+		t.Errorf("Expected only the conditional binding to be removed, got %+v", actual)
+	}
+}
+
+func TestStorageBucketIamPolicyVersion(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Bindings []*iamPolicyBinding
+		Expected string
+	}{
+		"unconditional": {
+			Bindings: []*iamPolicyBinding{
+				{Role: "roles/storage.objectAdmin", Members: []string{"user:a@example.com"}},
+			},
+			Expected: `{"bindings":[{"members":["user:a@example.com"],"role":"roles/storage.objectAdmin"}]}`,
+		},
+		"conditional": {
+			Bindings: []*iamPolicyBinding{
+				{
+					Role:      "roles/storage.objectAdmin",
+					Members:   []string{"user:a@example.com"},
+					Condition: &iamCondition{Title: "expires", Expression: "true"},
+				},
+			},
+			Expected: `{"bindings":[{"condition":{"expression":"true","title":"expires"},"members":["user:a@example.com"],"role":"roles/storage.objectAdmin"}],"version":3}`,
+		},
+	}
+
+	for tn, tc := range cases {
+		// The policy read from the API has a version and etag, only the
+		// version of conditional policies ends up in policy_data.
+		actual := marshalStorageBucketIamPolicy(&iamPolicy{Bindings: tc.Bindings, Etag: "CAE=", Version: 1})
+		if actual != tc.Expected {
+			t.Errorf("bad: %s, expected %s, got %s", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestAccStorageBucketIamBinding(t *testing.T) {
+	t.Parallel()
+
+	bucket := testBucketName()
+	account := "tf-test-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageBucketIamBinding(bucket, account, false),
+				Check: testAccCheckStorageBucketIam(bucket, "roles/storage.objectAdmin", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+			{
+				Config: testAccStorageBucketIamBinding(bucket, account, true),
+				Check: testAccCheckStorageBucketIam(bucket, "roles/storage.objectAdmin", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+					fmt.Sprintf("serviceAccount:%s-2@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+		},
+	})
+}
+
+func TestAccStorageBucketIamMember(t *testing.T) {
+	t.Parallel()
+
+	bucket := testBucketName()
+	account := "tf-test-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageBucketIamMember(bucket, account),
+				Check: testAccCheckStorageBucketIam(bucket, "roles/storage.objectAdmin", []string{
+					fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+				}),
+			},
+		},
+	})
+}
+
+func TestAccStorageBucketIamPolicy(t *testing.T) {
+	t.Parallel()
+
+	bucket := testBucketName()
+	account := "tf-test-" + acctest.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageBucketIamPolicy(bucket, account),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStorageBucketIam(bucket, "roles/storage.objectAdmin", []string{
+						fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+					}),
+					testAccCheckStorageBucketIam(bucket, "roles/storage.legacyBucketOwner", []string{
+						fmt.Sprintf("projectOwner:%s", getTestProjectFromEnv()),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageBucketIamBinding_condition(t *testing.T) {
+	t.Parallel()
+
+	bucket := testBucketName()
+	account := "tf-test-" + acctest.RandString(10)
+	condition := &iamCondition{
+		Title:      "expires_after_2099_12_31",
+		Expression: `request.time < timestamp("2100-01-01T00:00:00Z")`,
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageBucketIamDeleteUniformBucket(bucket),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testAccStorageBucketIamCreateUniformBucket(t, bucket),
+				Config:    testAccStorageBucketIamBinding_condition(bucket, account),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStorageBucketConditionalIam(bucket, "roles/storage.objectViewer", condition, []string{
+						fmt.Sprintf("serviceAccount:%s-1@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+					}),
+					testAccCheckStorageBucketConditionalIam(bucket, "roles/storage.objectAdmin", condition, []string{
+						fmt.Sprintf("serviceAccount:%s-2@%s.iam.gserviceaccount.com", account, getTestProjectFromEnv()),
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccStorageBucketIamCreateUniformBucket creates bucket with uniform
+// bucket-level access, which Cloud Storage requires for IAM Conditions and
+// google_storage_bucket can't enable yet.
+func testAccStorageBucketIamCreateUniformBucket(t *testing.T, bucket string) func() {
+	return func() {
+		config := testAccProvider.Meta().(*Config)
+		u := config.clientStorage.BasePath + "b?project=" + getTestProjectFromEnv()
+		body := map[string]interface{}{
+			"name": bucket,
+			"iamConfiguration": map[string]interface{}{
+				"uniformBucketLevelAccess": map[string]interface{}{
+					"enabled": true,
+				},
+			},
+		}
+		if err := sendJsonRequest(config.client, config.clientStorage.UserAgent, "POST", u, body, nil); err != nil {
+			t.Fatalf("Error creating bucket %q: %s", bucket, err)
+		}
+	}
+}
+
+func testAccStorageBucketIamDeleteUniformBucket(bucket string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Config)
+		err := config.clientStorage.Buckets.Delete(bucket).Do()
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == http.StatusNotFound {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Error deleting bucket %q: %s", bucket, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckStorageBucketIam(bucket, role string, members []string) resource.TestCheckFunc {
+	return testAccCheckStorageBucketConditionalIam(bucket, role, nil, members)
+}
+
+func testAccCheckStorageBucketConditionalIam(bucket, role string, condition *iamCondition, members []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Config)
+		p, err := getStorageBucketIamPolicy(config, bucket)
+		if err != nil {
+			return err
+		}
+
+		for _, b := range p.Bindings {
+			if !storageBucketIamBindingMatches(b, role, condition) {
+				continue
+			}
+
+			sort.Strings(members)
+			sort.Strings(b.Members)
+			if reflect.DeepEqual(members, b.Members) {
+				return nil
+			}
+			return fmt.Errorf("Binding for role %q has members %v, expected %v", role, b.Members, members)
+		}
+
+		return fmt.Errorf("No binding for role %q on bucket %q", role, bucket)
+	}
+}
+
+func testAccStorageBucketIamServiceAccounts(bucket, account string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+}
+
+resource "google_service_account" "test_1" {
+	account_id   = "%s-1"
+	display_name = "Bucket IAM test 1"
+}
+
+resource "google_service_account" "test_2" {
+	account_id   = "%s-2"
+	display_name = "Bucket IAM test 2"
+}
+`, bucket, account, account)
+}
+
+func testAccStorageBucketIamBinding(bucket, account string, both bool) string {
+	members := `"serviceAccount:${google_service_account.test_1.email}"`
+	if both {
+		members += `, "serviceAccount:${google_service_account.test_2.email}"`
+	}
+
+	return testAccStorageBucketIamServiceAccounts(bucket, account) + fmt.Sprintf(`
+resource "google_storage_bucket_iam_binding" "binding" {
+	bucket  = "${google_storage_bucket.bucket.name}"
+	role    = "roles/storage.objectAdmin"
+	members = [%s]
+}
+`, members)
+}
+
+func testAccStorageBucketIamMember(bucket, account string) string {
+	return testAccStorageBucketIamServiceAccounts(bucket, account) + `
+resource "google_storage_bucket_iam_member" "member" {
+	bucket = "${google_storage_bucket.bucket.name}"
+	role   = "roles/storage.objectAdmin"
+	member = "serviceAccount:${google_service_account.test_1.email}"
+}
+`
+}
+
+func testAccStorageBucketIamBinding_condition(bucket, account string) string {
+	return fmt.Sprintf(`
+resource "google_service_account" "test_1" {
+	account_id   = "%s-1"
+	display_name = "Bucket IAM test 1"
+}
+
+resource "google_service_account" "test_2" {
+	account_id   = "%s-2"
+	display_name = "Bucket IAM test 2"
+}
+
+resource "google_storage_bucket_iam_binding" "binding" {
+	bucket  = "%s"
+	role    = "roles/storage.objectViewer"
+	members = ["serviceAccount:${google_service_account.test_1.email}"]
+
+	condition {
+		title      = "expires_after_2099_12_31"
+		expression = "request.time < timestamp(\"2100-01-01T00:00:00Z\")"
+	}
+}
+
+resource "google_storage_bucket_iam_member" "member" {
+	bucket = "%s"
+	role   = "roles/storage.objectAdmin"
+	member = "serviceAccount:${google_service_account.test_2.email}"
+
+	condition {
+		title      = "expires_after_2099_12_31"
+		expression = "request.time < timestamp(\"2100-01-01T00:00:00Z\")"
+	}
+}
+`, account, account, bucket, bucket)
+}
+
+func testAccStorageBucketIamPolicy(bucket, account string) string {
+	return testAccStorageBucketIamServiceAccounts(bucket, account) + fmt.Sprintf(`
+data "google_iam_policy" "policy" {
+	binding {
+		role    = "roles/storage.objectAdmin"
+		members = ["serviceAccount:${google_service_account.test_1.email}"]
+	}
+
+	binding {
+		role    = "roles/storage.legacyBucketOwner"
+		members = ["projectOwner:%s"]
+	}
+}
+
+resource "google_storage_bucket_iam_policy" "policy" {
+	bucket      = "${google_storage_bucket.bucket.name}"
+	policy_data = "${data.google_iam_policy.policy.policy_data}"
+}
+`, getTestProjectFromEnv())
+}
@@ -2,8 +2,11 @@ package google
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/base64"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
 
@@ -80,6 +83,18 @@ func resourceStorageBucketObject() *schema.Resource {
 				Computed: true,
 			},
 
+			// detect_md5hash holds the MD5 hash of the object in GCS. Its diff
+			// is suppressed while that matches the hash of the local content
+			// or source file, so changing either, or the object changing
+			// remotely, forces the object to be uploaded again.
+			"detect_md5hash": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "different hash",
+				DiffSuppressFunc: storageObjectMd5DiffSuppress,
+			},
+
 			"predefined_acl": &schema.Schema{
 				Type:     schema.TypeString,
 				Removed:  "Please use resource \"storage_object_acl.predefined_acl\" instead.",
@@ -104,6 +119,39 @@ func resourceStorageBucketObject() *schema.Resource {
 	}
 }
 
+func storageObjectMd5DiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	localMd5Hash := ""
+	if v, ok := d.GetOk("source"); ok {
+		localMd5Hash = getFileMd5Hash(v.(string))
+	} else if v, ok := d.GetOk("content"); ok {
+		localMd5Hash = getContentMd5Hash([]byte(v.(string)))
+	}
+
+	// State from before detect_md5hash existed only has md5hash.
+	if old == "" {
+		old = d.Get("md5hash").(string)
+	}
+
+	// Without a local hash there's nothing to compare against, so leave the
+	// diff to the content and source fields themselves.
+	return localMd5Hash == "" || old == localMd5Hash
+}
+
+func getFileMd5Hash(filename string) string {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		log.Printf("[WARN] Failed to read source file %q. Cannot compute md5 hash for it.", filename)
+		return ""
+	}
+
+	return getContentMd5Hash(data)
+}
+
+func getContentMd5Hash(content []byte) string {
+	h := md5.Sum(content)
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
 func objectGetId(object *storage.Object) string {
 	return object.Bucket + "-" + object.Name
 }
@@ -183,6 +231,7 @@ func resourceStorageBucketObjectRead(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.Set("md5hash", res.Md5Hash)
+	d.Set("detect_md5hash", res.Md5Hash)
 	d.Set("crc32c", res.Crc32c)
 	d.Set("cache_control", res.CacheControl)
 	d.Set("content_disposition", res.ContentDisposition)
@@ -5,9 +5,11 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 
 	"google.golang.org/api/storage/v1"
@@ -46,6 +48,103 @@ func TestAccGoogleStorageObject_basic(t *testing.T) {
 	})
 }
 
+func TestAccGoogleStorageObject_recreate(t *testing.T) {
+	t.Parallel()
+
+	bucketName := testBucketName()
+	testFile, err := ioutil.TempFile("", "tf-gce-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(testFile.Name())
+
+	ioutil.WriteFile(testFile.Name(), []byte("data data data"), 0644)
+	updatedData := []byte("datum")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testGoogleStorageBucketsObjectSource(bucketName, testFile.Name()),
+				Check:  testAccCheckGoogleStorageObject(bucketName, objectName, getContentMd5Hash([]byte("data data data"))),
+			},
+			resource.TestStep{
+				PreConfig: func() {
+					ioutil.WriteFile(testFile.Name(), updatedData, 0644)
+				},
+				Config: testGoogleStorageBucketsObjectSource(bucketName, testFile.Name()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleStorageObject(bucketName, objectName, getContentMd5Hash(updatedData)),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket_object.object", "md5hash", getContentMd5Hash(updatedData)),
+				),
+			},
+		},
+	})
+}
+
+func TestStorageObjectMd5DiffSuppress(t *testing.T) {
+	testFile, err := ioutil.TempFile("", "tf-gce-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(testFile.Name())
+	ioutil.WriteFile(testFile.Name(), []byte("data data data"), 0644)
+
+	dataMd5 := getContentMd5Hash([]byte("data data data"))
+	contentMd5 := getContentMd5Hash([]byte(content))
+
+	cases := map[string]struct {
+		Raw      map[string]interface{}
+		Old      string
+		Suppress bool
+	}{
+		"source unchanged": {
+			Raw:      map[string]interface{}{"source": testFile.Name()},
+			Old:      dataMd5,
+			Suppress: true,
+		},
+		"source changed": {
+			Raw:      map[string]interface{}{"source": testFile.Name()},
+			Old:      contentMd5,
+			Suppress: false,
+		},
+		"content unchanged": {
+			Raw:      map[string]interface{}{"content": content},
+			Old:      contentMd5,
+			Suppress: true,
+		},
+		"content changed remotely": {
+			Raw:      map[string]interface{}{"content": content},
+			Old:      dataMd5,
+			Suppress: false,
+		},
+		"not yet created": {
+			Raw:      map[string]interface{}{"content": content},
+			Old:      "",
+			Suppress: false,
+		},
+		"state without detect_md5hash": {
+			Raw:      map[string]interface{}{"content": content, "md5hash": contentMd5},
+			Old:      "",
+			Suppress: true,
+		},
+		"missing source file": {
+			Raw:      map[string]interface{}{"source": testFile.Name() + "-missing"},
+			Old:      dataMd5,
+			Suppress: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceStorageBucketObject().Schema, tc.Raw)
+		if got := storageObjectMd5DiffSuppress("detect_md5hash", tc.Old, "different hash", d); got != tc.Suppress {
+			t.Errorf("%s: expected suppress to be %t, got %t", tn, tc.Suppress, got)
+		}
+	}
+}
+
 func TestAccGoogleStorageObject_content(t *testing.T) {
 	t.Parallel()
 
@@ -259,6 +358,20 @@ resource "google_storage_bucket_object" "object" {
 `, bucketName, objectName, tf.Name())
 }
 
+func testGoogleStorageBucketsObjectSource(bucketName, source string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+}
+
+resource "google_storage_bucket_object" "object" {
+	name = "%s"
+	bucket = "${google_storage_bucket.bucket.name}"
+	source = "%s"
+}
+`, bucketName, objectName, source)
+}
+
 func testGoogleStorageBucketsObject_optionalContentFields(
 	bucketName, disposition, encoding, language, content_type string) string {
 	return fmt.Sprintf(`
@@ -2,12 +2,19 @@ package google
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 
 	"google.golang.org/api/googleapi"
@@ -88,6 +95,55 @@ func TestAccStorageBucket_customAttributes(t *testing.T) {
 	})
 }
 
+func TestStorageBucketLifecycleRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceStorageBucket().Schema, map[string]interface{}{
+		"name": "bucket",
+		"lifecycle_rule": []interface{}{
+			map[string]interface{}{
+				"action": []interface{}{
+					map[string]interface{}{"type": "SetStorageClass", "storage_class": "NEARLINE"},
+				},
+				"condition": []interface{}{
+					map[string]interface{}{"age": 30, "matches_storage_class": []interface{}{"REGIONAL"}},
+				},
+			},
+			map[string]interface{}{
+				"action": []interface{}{
+					map[string]interface{}{"type": "Delete"},
+				},
+				"condition": []interface{}{
+					map[string]interface{}{"is_live": true, "num_newer_versions": 3},
+				},
+			},
+		},
+	})
+
+	sb := &storage.Bucket{}
+	if err := resourceGCSBucketLifecycleCreateOrUpdate(d, sb); err != nil {
+		t.Fatalf("Error expanding lifecycle rules: %s", err)
+	}
+
+	// Rules read back from the API must land on the same set elements as the
+	// configured ones, or every plan would show a diff.
+	flattened := flattenBucketLifecycle(sb.Lifecycle)
+	configured := d.Get("lifecycle_rule").([]interface{})
+	if len(flattened) != len(configured) {
+		t.Fatalf("Expected %d rules, got %d", len(configured), len(flattened))
+	}
+	for i, rule := range configured {
+		rule := rule.(map[string]interface{})
+		for _, k := range []string{"action", "condition"} {
+			expected := rule[k].(*schema.Set).List()[0]
+			actual := flattened[i][k].(*schema.Set).List()[0]
+			if rule[k].(*schema.Set).F(expected) != flattened[i][k].(*schema.Set).F(actual) {
+				t.Errorf("Rule %d: %s %#v read back as %#v", i, k, expected, actual)
+			}
+		}
+	}
+}
+
 func TestAccStorageBucket_lifecycleRules(t *testing.T) {
 	t.Parallel()
 
@@ -353,6 +409,226 @@ func TestAccStorageBucket_versioning(t *testing.T) {
 						"google_storage_bucket.bucket", "versioning.0.enabled", "true"),
 				),
 			},
+			resource.TestStep{
+				Config: testAccStorageBucket_basic(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName, &bucket),
+					testAccCheckStorageBucketVersioningSuspended(&bucket),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "versioning.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStorageBucketVersioningSuspended(bucket *storage.Bucket) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if bucket.Versioning != nil && bucket.Versioning.Enabled {
+			return fmt.Errorf("Expected versioning of bucket %s to be suspended", bucket.Name)
+		}
+		return nil
+	}
+}
+
+func TestAccStorageBucket_encryption(t *testing.T) {
+	t.Parallel()
+
+	skipIfEnvNotSet(t,
+		[]string{
+			"GOOGLE_ORG",
+			"GOOGLE_BILLING_ACCOUNT",
+		}...,
+	)
+
+	projectId := "terraform-" + acctest.RandString(10)
+	projectOrg := os.Getenv("GOOGLE_ORG")
+	projectBillingAccount := os.Getenv("GOOGLE_BILLING_ACCOUNT")
+	bucketName := fmt.Sprintf("tf-test-crypto-bucket-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageBucket_encryption(projectId, projectOrg, projectBillingAccount, bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"google_storage_bucket.bucket", "encryption.0.default_kms_key_name",
+						"google_kms_crypto_key.crypto_key", "self_link"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageBucket_logging(t *testing.T) {
+	t.Parallel()
+
+	var bucket storage.Bucket
+	bucketName := fmt.Sprintf("tf-test-acl-bucket-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageBucket_logging(bucketName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName, &bucket),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "logging.0.log_bucket", bucketName+"-logs"),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "logging.0.log_object_prefix", bucketName),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageBucket_logging(bucketName, "dataproc-staging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "logging.0.log_object_prefix", "dataproc-staging"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageBucket_loggingRemoved(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "logging.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageBucket_retentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	bucketName := fmt.Sprintf("tf-test-acl-bucket-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageBucket_retentionPolicy(bucketName, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "retention_policy.0.retention_period", "10"),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "retention_policy.0.is_locked", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageBucket_retentionPolicy(bucketName, 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "retention_policy.0.retention_period", "20"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageBucket_basic(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "retention_policy.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUpdateStorageBucketRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	var patch map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&patch)
+			fmt.Fprint(w, `{"metageneration": "4", "retentionPolicy": {"retentionPeriod": "86400"}}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := storage.New(server.Client())
+	if err != nil {
+		t.Fatalf("Error creating storage client: %s", err)
+	}
+	client.BasePath = server.URL + "/storage/v1/"
+	config := &Config{
+		client:        server.Client(),
+		clientStorage: client,
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceStorageBucket().Schema, map[string]interface{}{
+		"name": "audit-logs",
+		"retention_policy": []interface{}{
+			map[string]interface{}{
+				"retention_period": 86400,
+				"is_locked":        true,
+			},
+		},
+	})
+
+	if err := updateStorageBucketRetentionPolicy(d, config, "billing-project"); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	expectedPatch := map[string]interface{}{
+		"retentionPolicy": map[string]interface{}{"retentionPeriod": "86400"},
+	}
+	if !reflect.DeepEqual(patch, expectedPatch) {
+		t.Errorf("Expected patch %v, got %v", expectedPatch, patch)
+	}
+
+	expected := []string{
+		"PATCH /storage/v1/b/audit-logs?fields=metageneration%2CretentionPolicy&userProject=billing-project",
+		"POST /storage/v1/b/audit-logs/lockRetentionPolicy?ifMetagenerationMatch=4&userProject=billing-project",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestAccStorageBucket_requesterPays(t *testing.T) {
+	t.Parallel()
+
+	var bucket storage.Bucket
+	bucketName := fmt.Sprintf("tf-test-requester-bucket-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageBucket_requesterPays(bucketName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName, &bucket),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "billing.0.requester_pays", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageBucket_requesterPays(bucketName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "billing.0.requester_pays", "false"),
+				),
+			},
+			resource.TestStep{
+				// Destroying a requester pays bucket needs a user project too.
+				Config: testAccStorageBucket_requesterPays(bucketName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "billing.0.requester_pays", "true"),
+				),
+			},
 		},
 	})
 }
@@ -469,6 +745,27 @@ func TestAccStorageBucket_labels(t *testing.T) {
 	})
 }
 
+func TestStorageBucketRequiredLabels(t *testing.T) {
+	t.Parallel()
+
+	// No storage client, so this fails if an API call is attempted.
+	config := &Config{
+		Project:        "my-project",
+		RequiredLabels: []string{"cost-center", "team"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceStorageBucket().Schema, map[string]interface{}{
+		"name": "staging",
+		"labels": map[string]interface{}{
+			"team": "data",
+		},
+	})
+	err := resourceStorageBucketCreate(d, config)
+	if err == nil || !strings.Contains(err.Error(), "missing labels required by the provider configuration: cost-center") {
+		t.Fatalf("Expected missing label error, got %v", err)
+	}
+}
+
 func testAccCheckStorageBucketExists(n string, bucketName string, bucket *storage.Bucket) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -684,6 +981,129 @@ resource "google_storage_bucket" "bucket" {
 `, bucketName)
 }
 
+/*
+	The key ring can't be deleted, so the test runs in its own project.
+*/
+func testAccStorageBucket_encryption(projectId, projectOrg, projectBillingAccount, bucketName string) string {
+	return fmt.Sprintf(`
+resource "google_project" "acceptance" {
+	name            = "%s"
+	project_id      = "%s"
+	org_id          = "%s"
+	billing_account = "%s"
+}
+
+resource "google_project_services" "acceptance" {
+	project = "${google_project.acceptance.project_id}"
+
+	services = [
+	  "cloudkms.googleapis.com",
+	  "storage-api.googleapis.com",
+	]
+}
+
+data "google_storage_project_service_account" "gcs" {
+	project = "${google_project_services.acceptance.project}"
+}
+
+resource "google_project_iam_member" "gcs_encrypter" {
+	project = "${google_project_services.acceptance.project}"
+	role    = "roles/cloudkms.cryptoKeyEncrypterDecrypter"
+	member  = "serviceAccount:${data.google_storage_project_service_account.gcs.email_address}"
+}
+
+resource "google_kms_key_ring" "key_ring" {
+	project  = "${google_project_services.acceptance.project}"
+	name     = "tf-test-key-ring"
+	location = "us"
+}
+
+resource "google_kms_crypto_key" "crypto_key" {
+	name     = "tf-test-crypto-key"
+	key_ring = "${google_kms_key_ring.key_ring.id}"
+}
+
+resource "google_storage_bucket" "bucket" {
+	project = "${google_project_services.acceptance.project}"
+	name    = "%s"
+
+	encryption {
+		default_kms_key_name = "${google_kms_crypto_key.crypto_key.self_link}"
+	}
+
+	depends_on = ["google_project_iam_member.gcs_encrypter"]
+}
+`, projectId, projectId, projectOrg, projectBillingAccount, bucketName)
+}
+
+func testAccStorageBucket_logging(bucketName, logObjectPrefix string) string {
+	prefix := ""
+	if logObjectPrefix != "" {
+		prefix = fmt.Sprintf("log_object_prefix = %q", logObjectPrefix)
+	}
+
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "logs" {
+	name          = "%s-logs"
+	force_destroy = true
+}
+
+resource "google_storage_bucket_acl" "logs" {
+	bucket = "${google_storage_bucket.logs.name}"
+
+	role_entity = [
+		"WRITER:group-cloud-storage-analytics@google.com",
+	]
+}
+
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+
+	logging {
+		log_bucket = "${google_storage_bucket_acl.logs.bucket}"
+		%s
+	}
+}
+`, bucketName, bucketName, prefix)
+}
+
+func testAccStorageBucket_loggingRemoved(bucketName string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "logs" {
+	name          = "%s-logs"
+	force_destroy = true
+}
+
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+}
+`, bucketName, bucketName)
+}
+
+func testAccStorageBucket_retentionPolicy(bucketName string, retentionPeriod int) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+
+	retention_policy {
+		retention_period = %d
+	}
+}
+`, bucketName, retentionPeriod)
+}
+
+func testAccStorageBucket_requesterPays(bucketName string, requesterPays bool) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+
+	billing {
+		requester_pays = %t
+	}
+}
+`, bucketName, requesterPays)
+}
+
 func testAccStorageBucket_versioning(bucketName string) string {
 	return fmt.Sprintf(`
 resource "google_storage_bucket" "bucket" {
@@ -0,0 +1,165 @@
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"google.golang.org/api/storage/v1"
+)
+
+func resourceStorageDefaultObjectAccessControl() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageDefaultObjectAccessControlCreate,
+		Read:   resourceStorageDefaultObjectAccessControlRead,
+		Update: resourceStorageDefaultObjectAccessControlUpdate,
+		Delete: resourceStorageDefaultObjectAccessControlDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceStorageDefaultObjectAccessControlImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"entity": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"OWNER", "READER"}, false),
+			},
+
+			"domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"entity_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"project_team": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"team": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceStorageDefaultObjectAccessControlCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	bucket := d.Get("bucket").(string)
+	acl := &storage.ObjectAccessControl{
+		Entity: d.Get("entity").(string),
+		Role:   d.Get("role").(string),
+	}
+
+	_, err := config.clientStorage.DefaultObjectAccessControls.Insert(bucket, acl).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating default object ACL entry %s on bucket %s: %s", acl.Entity, bucket, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, acl.Entity))
+
+	return resourceStorageDefaultObjectAccessControlRead(d, meta)
+}
+
+func resourceStorageDefaultObjectAccessControlRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	bucket := d.Get("bucket").(string)
+	entity := d.Get("entity").(string)
+
+	res, err := config.clientStorage.DefaultObjectAccessControls.Get(bucket, entity).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Default Object ACL entry %q", d.Id()))
+	}
+
+	d.Set("role", res.Role)
+	d.Set("domain", res.Domain)
+	d.Set("email", res.Email)
+	d.Set("entity_id", res.EntityId)
+	d.Set("project_team", flattenObjectAccessControlProjectTeam(res.ProjectTeam))
+
+	return nil
+}
+
+func resourceStorageDefaultObjectAccessControlUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	bucket := d.Get("bucket").(string)
+	acl := &storage.ObjectAccessControl{
+		Role: d.Get("role").(string),
+	}
+
+	_, err := config.clientStorage.DefaultObjectAccessControls.Patch(bucket, d.Get("entity").(string), acl).Do()
+	if err != nil {
+		return fmt.Errorf("Error updating default object ACL entry %s: %s", d.Id(), err)
+	}
+
+	return resourceStorageDefaultObjectAccessControlRead(d, meta)
+}
+
+func resourceStorageDefaultObjectAccessControlDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	err := config.clientStorage.DefaultObjectAccessControls.Delete(d.Get("bucket").(string), d.Get("entity").(string)).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Default Object ACL entry %q", d.Id()))
+	}
+
+	return nil
+}
+
+func resourceStorageDefaultObjectAccessControlImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid default object ACL entry specifier %q, expected {bucket}/{entity}", d.Id())
+	}
+
+	d.Set("bucket", parts[0])
+	d.Set("entity", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenObjectAccessControlProjectTeam(team *storage.ObjectAccessControlProjectTeam) []map[string]interface{} {
+	if team == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"project_number": team.ProjectNumber,
+			"team":           team.Team,
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestStorageDefaultObjectAccessControlImport(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceStorageDefaultObjectAccessControl().Schema, map[string]interface{}{})
+	d.SetId("dataproc-output/group-analysts@example.com")
+
+	if _, err := resourceStorageDefaultObjectAccessControlImport(d, nil); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if bucket := d.Get("bucket").(string); bucket != "dataproc-output" {
+		t.Errorf("Expected bucket dataproc-output, got %s", bucket)
+	}
+	if entity := d.Get("entity").(string); entity != "group-analysts@example.com" {
+		t.Errorf("Expected entity group-analysts@example.com, got %s", entity)
+	}
+
+	for _, id := range []string{"dataproc-output", "/allUsers", "dataproc-output/"} {
+		d.SetId(id)
+		if _, err := resourceStorageDefaultObjectAccessControlImport(d, nil); err == nil {
+			t.Errorf("Expected an error importing %q", id)
+		}
+	}
+}
+
+func TestAccStorageDefaultObjectAccessControl_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := testBucketName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageDefaultObjectAccessControlDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageDefaultObjectAccessControl(bucketName, "READER"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_default_object_access_control.default", "role", "READER"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageDefaultObjectAccessControl(bucketName, "OWNER"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_default_object_access_control.default", "role", "OWNER"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_storage_default_object_access_control.default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccStorageDefaultObjectAccessControlDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_storage_default_object_access_control" {
+			continue
+		}
+
+		bucket := rs.Primary.Attributes["bucket"]
+		entity := rs.Primary.Attributes["entity"]
+
+		_, err := config.clientStorage.DefaultObjectAccessControls.Get(bucket, entity).Do()
+		if err == nil {
+			return fmt.Errorf("Default object ACL entry %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStorageDefaultObjectAccessControl(bucketName, role string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+}
+
+resource "google_storage_default_object_access_control" "default" {
+	bucket = "${google_storage_bucket.bucket.name}"
+	role   = "%s"
+	entity = "allAuthenticatedUsers"
+}
+`, bucketName, role)
+}
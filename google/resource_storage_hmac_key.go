@@ -0,0 +1,186 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceStorageHmacKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageHmacKeyCreate,
+		Read:   resourceStorageHmacKeyRead,
+		Update: resourceStorageHmacKeyUpdate,
+		Delete: resourceStorageHmacKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceStorageHmacKeyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"service_account_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ACTIVE",
+				ValidateFunc: validation.StringInSlice([]string{"ACTIVE", "INACTIVE"}, false),
+			},
+
+			"access_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStorageHmacKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	email := d.Get("service_account_email").(string)
+	res, err := config.clientStorageHmacKeys.Create(project, email)
+	if err != nil {
+		return fmt.Errorf("Error creating HMAC key for %s: %s", email, err)
+	}
+
+	log.Printf("[DEBUG] Created HMAC key %s for %s", res.Metadata.AccessId, email)
+	d.SetId(fmt.Sprintf("%s/%s", project, res.Metadata.AccessId))
+	// The secret is only ever returned here.
+	d.Set("secret", res.Secret)
+
+	// New keys are always active.
+	if state := d.Get("state").(string); state != res.Metadata.State {
+		_, err = config.clientStorageHmacKeys.Update(project, res.Metadata.AccessId, &storageHmacKeyMetadata{State: state})
+		if err != nil {
+			return fmt.Errorf("Error setting state of HMAC key %s to %s: %s", d.Id(), state, err)
+		}
+	}
+
+	return resourceStorageHmacKeyRead(d, meta)
+}
+
+func resourceStorageHmacKeyRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, accessId, err := parseStorageHmacKeyId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientStorageHmacKeys.Get(project, accessId)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("HMAC key %q", d.Id()))
+	}
+
+	// Deleted keys are still returned for a while.
+	if res.State == "DELETED" {
+		log.Printf("[WARN] Removing deleted HMAC key %q from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("project", res.ProjectId)
+	d.Set("service_account_email", res.ServiceAccountEmail)
+	d.Set("state", res.State)
+	d.Set("access_id", res.AccessId)
+	d.Set("time_created", res.TimeCreated)
+	d.Set("updated", res.Updated)
+
+	return nil
+}
+
+func resourceStorageHmacKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, accessId, err := parseStorageHmacKeyId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("state") {
+		state := d.Get("state").(string)
+		_, err = config.clientStorageHmacKeys.Update(project, accessId, &storageHmacKeyMetadata{State: state})
+		if err != nil {
+			return fmt.Errorf("Error setting state of HMAC key %s to %s: %s", d.Id(), state, err)
+		}
+	}
+
+	return resourceStorageHmacKeyRead(d, meta)
+}
+
+func resourceStorageHmacKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, accessId, err := parseStorageHmacKeyId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// Only inactive keys can be deleted.
+	if d.Get("state").(string) != "INACTIVE" {
+		_, err = config.clientStorageHmacKeys.Update(project, accessId, &storageHmacKeyMetadata{State: "INACTIVE"})
+		if err != nil {
+			return handleNotFoundError(err, d, fmt.Sprintf("HMAC key %q", d.Id()))
+		}
+	}
+
+	err = config.clientStorageHmacKeys.Delete(project, accessId)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("HMAC key %q", d.Id()))
+	}
+
+	log.Printf("[DEBUG] Deleted HMAC key %s", d.Id())
+	return nil
+}
+
+func resourceStorageHmacKeyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseStorageHmacKeyId(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseStorageHmacKeyId(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid HMAC key specifier %q, expected {project}/{access_id}", id)
+	}
+
+	return parts[0], parts[1], nil
+}
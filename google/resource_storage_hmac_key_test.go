@@ -0,0 +1,156 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestStorageHmacKeysClient(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	var update map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.Method {
+		case "POST":
+			fmt.Fprint(w, `{"metadata": {"accessId": "GOOG123", "state": "ACTIVE"}, "secret": "s3cr3t"}`)
+		case "PUT":
+			json.NewDecoder(r.Body).Decode(&update)
+			fmt.Fprint(w, `{"accessId": "GOOG123", "state": "INACTIVE"}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			fmt.Fprint(w, `{"accessId": "GOOG123", "state": "ACTIVE"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := &storageHmacKeysClient{
+		client:   server.Client(),
+		BasePath: server.URL + "/storage/v1/",
+	}
+
+	key, err := client.Create("my-project", "spark@my-project.iam.gserviceaccount.com")
+	if err != nil {
+		t.Fatalf("Error creating HMAC key: %s", err)
+	}
+	if key.Metadata.AccessId != "GOOG123" || key.Secret != "s3cr3t" {
+		t.Errorf("Unexpected HMAC key %+v", key)
+	}
+
+	if _, err := client.Get("my-project", "GOOG123"); err != nil {
+		t.Fatalf("Error getting HMAC key: %s", err)
+	}
+
+	if _, err := client.Update("my-project", "GOOG123", &storageHmacKeyMetadata{State: "INACTIVE"}); err != nil {
+		t.Fatalf("Error updating HMAC key: %s", err)
+	}
+	if expected := map[string]interface{}{"state": "INACTIVE"}; !reflect.DeepEqual(update, expected) {
+		t.Errorf("Expected update %v, got %v", expected, update)
+	}
+
+	if err := client.Delete("my-project", "GOOG123"); err != nil {
+		t.Fatalf("Error deleting HMAC key: %s", err)
+	}
+
+	expected := []string{
+		"POST /storage/v1/projects/my-project/hmacKeys?serviceAccountEmail=spark%40my-project.iam.gserviceaccount.com",
+		"GET /storage/v1/projects/my-project/hmacKeys/GOOG123",
+		"PUT /storage/v1/projects/my-project/hmacKeys/GOOG123",
+		"DELETE /storage/v1/projects/my-project/hmacKeys/GOOG123",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestParseStorageHmacKeyId(t *testing.T) {
+	t.Parallel()
+
+	project, accessId, err := parseStorageHmacKeyId("my-project/GOOG123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project != "my-project" || accessId != "GOOG123" {
+		t.Errorf("Expected my-project and GOOG123, got %s and %s", project, accessId)
+	}
+
+	for _, id := range []string{"GOOG123", "my-project/", "a/b/c"} {
+		if _, _, err := parseStorageHmacKeyId(id); err == nil {
+			t.Errorf("Expected an error parsing %q", id)
+		}
+	}
+}
+
+func TestAccStorageHmacKey_basic(t *testing.T) {
+	t.Parallel()
+
+	accountId := "tf-test-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageHmacKeyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageHmacKey(accountId, "ACTIVE"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("google_storage_hmac_key.key", "access_id"),
+					resource.TestCheckResourceAttrSet("google_storage_hmac_key.key", "secret"),
+					resource.TestCheckResourceAttr("google_storage_hmac_key.key", "state", "ACTIVE"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageHmacKey(accountId, "INACTIVE"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_storage_hmac_key.key", "state", "INACTIVE"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "google_storage_hmac_key.key",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+		},
+	})
+}
+
+func testAccStorageHmacKeyDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_storage_hmac_key" {
+			continue
+		}
+
+		key, err := config.clientStorageHmacKeys.Get(rs.Primary.Attributes["project"], rs.Primary.Attributes["access_id"])
+		if err == nil && key.State != "DELETED" {
+			return fmt.Errorf("HMAC key %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStorageHmacKey(accountId, state string) string {
+	return fmt.Sprintf(`
+resource "google_service_account" "spark" {
+	account_id = "%s"
+}
+
+resource "google_storage_hmac_key" "key" {
+	service_account_email = "${google_service_account.spark.email}"
+	state                 = "%s"
+}
+`, accountId, state)
+}
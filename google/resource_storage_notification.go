@@ -0,0 +1,157 @@
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"google.golang.org/api/storage/v1"
+)
+
+const pubsubTopicPrefix = "//pubsub.googleapis.com/"
+
+func resourceStorageNotification() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageNotificationCreate,
+		Read:   resourceStorageNotificationRead,
+		Delete: resourceStorageNotificationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"payload_format": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"JSON_API_V1", "NONE"}, false),
+			},
+
+			"topic": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: linkDiffSuppress,
+			},
+
+			"custom_attributes": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"event_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"OBJECT_FINALIZE", "OBJECT_METADATA_UPDATE", "OBJECT_DELETE", "OBJECT_ARCHIVE",
+					}, false),
+				},
+				Set: schema.HashString,
+			},
+
+			"object_name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStorageNotificationCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	bucket := d.Get("bucket").(string)
+	topic := d.Get("topic").(string)
+	if !strings.HasPrefix(topic, "projects/") {
+		// Topics given by name are in the provider project.
+		if config.Project == "" {
+			return fmt.Errorf("topic %q must be given as projects/{project}/topics/{name} when the provider has no project", topic)
+		}
+		topic = getComputedTopicName(config.Project, topic)
+	}
+
+	notification := &storage.Notification{
+		CustomAttributes: expandStringMap(d, "custom_attributes"),
+		EventTypes:       convertStringSet(d.Get("event_types").(*schema.Set)),
+		ObjectNamePrefix: d.Get("object_name_prefix").(string),
+		PayloadFormat:    d.Get("payload_format").(string),
+		Topic:            pubsubTopicPrefix + topic,
+	}
+
+	res, err := config.clientStorage.Notifications.Insert(bucket, notification).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating notification config for bucket %s: %v", bucket, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, res.Id))
+
+	return resourceStorageNotificationRead(d, meta)
+}
+
+func resourceStorageNotificationRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	bucket, notificationID, err := parseStorageNotificationId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientStorage.Notifications.Get(bucket, notificationID).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Notification configuration %s for bucket %s", notificationID, bucket))
+	}
+
+	d.Set("bucket", bucket)
+	d.Set("payload_format", res.PayloadFormat)
+	d.Set("topic", strings.TrimPrefix(res.Topic, pubsubTopicPrefix))
+	d.Set("object_name_prefix", res.ObjectNamePrefix)
+	d.Set("event_types", res.EventTypes)
+	d.Set("custom_attributes", res.CustomAttributes)
+	d.Set("self_link", res.SelfLink)
+
+	return nil
+}
+
+func resourceStorageNotificationDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	bucket, notificationID, err := parseStorageNotificationId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = config.clientStorage.Notifications.Delete(bucket, notificationID).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Notification configuration %s for bucket %s", notificationID, bucket))
+	}
+
+	return nil
+}
+
+// parseStorageNotificationId splits the {bucket}/{notification id} ID of a
+// google_storage_notification.
+func parseStorageNotificationId(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid storage notification specifier %q, expected {bucket}/{notification_id}", id)
+	}
+	return parts[0], parts[1], nil
+}
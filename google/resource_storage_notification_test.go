@@ -0,0 +1,117 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestParseStorageNotificationId(t *testing.T) {
+	t.Parallel()
+
+	bucket, id, err := parseStorageNotificationId("my-bucket/12")
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if bucket != "my-bucket" || id != "12" {
+		t.Errorf("Expected my-bucket and 12, got %q and %q", bucket, id)
+	}
+
+	for _, id := range []string{"my-bucket", "my-bucket/", "/12", "a/b/c"} {
+		if _, _, err := parseStorageNotificationId(id); err == nil {
+			t.Errorf("Expected an error for ID %q", id)
+		}
+	}
+}
+
+func TestAccStorageNotification_basic(t *testing.T) {
+	t.Parallel()
+
+	bucketName := fmt.Sprintf("tf-test-notification-%d", acctest.RandInt())
+	topicName := fmt.Sprintf("tf-test-topic-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageNotificationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageNotification_basic(bucketName, topicName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"google_storage_notification.notification", "topic",
+						fmt.Sprintf("projects/%s/topics/%s", getTestProjectFromEnv(), topicName)),
+					resource.TestCheckResourceAttr(
+						"google_storage_notification.notification", "event_types.#", "1"),
+					resource.TestCheckResourceAttr(
+						"google_storage_notification.notification", "object_name_prefix", "output/"),
+					resource.TestCheckResourceAttr(
+						"google_storage_notification.notification", "custom_attributes.pipeline", "dataproc"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_storage_notification.notification",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccStorageNotificationDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_storage_notification" {
+			continue
+		}
+
+		bucket, id, err := parseStorageNotificationId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = config.clientStorage.Notifications.Get(bucket, id).Do()
+		if err == nil {
+			return fmt.Errorf("Notification configuration %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStorageNotification_basic(bucketName, topicName string) string {
+	return fmt.Sprintf(`
+data "google_storage_project_service_account" "gcs_account" {}
+
+resource "google_pubsub_topic" "topic" {
+	name = "%s"
+}
+
+resource "google_project_iam_member" "publisher" {
+	role   = "roles/pubsub.publisher"
+	member = "serviceAccount:${data.google_storage_project_service_account.gcs_account.email_address}"
+}
+
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+}
+
+resource "google_storage_notification" "notification" {
+	bucket             = "${google_storage_bucket.bucket.name}"
+	payload_format     = "JSON_API_V1"
+	topic              = "${google_pubsub_topic.topic.name}"
+	event_types        = ["OBJECT_FINALIZE"]
+	object_name_prefix = "output/"
+
+	custom_attributes {
+		pipeline = "dataproc"
+	}
+
+	depends_on = ["google_project_iam_member.publisher"]
+}
+`, topicName, bucketName)
+}
@@ -0,0 +1,612 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceStorageTransferJob() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageTransferJobCreate,
+		Read:   resourceStorageTransferJobRead,
+		Update: resourceStorageTransferJobUpdate,
+		Delete: resourceStorageTransferJobDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceStorageTransferJobStateImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(0, 1024),
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"transfer_spec": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"gcs_data_sink": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem:     storageTransferGcsDataSchema(),
+						},
+
+						"gcs_data_source": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     storageTransferGcsDataSchema(),
+						},
+
+						"aws_s3_data_source": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"bucket_name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"aws_access_key": {
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"access_key_id": {
+													Type:      schema.TypeString,
+													Required:  true,
+													Sensitive: true,
+												},
+
+												"secret_access_key": {
+													Type:      schema.TypeString,
+													Required:  true,
+													Sensitive: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"object_conditions": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"min_time_elapsed_since_last_modification": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateDuration,
+									},
+
+									"max_time_elapsed_since_last_modification": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateDuration,
+									},
+
+									"include_prefixes": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									"exclude_prefixes": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+
+						"transfer_options": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"overwrite_objects_already_existing_in_sink": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+
+									"delete_objects_unique_in_sink": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+
+									"delete_objects_from_source_after_transfer": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"schedule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule_start_date": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem:     storageTransferDateSchema(),
+						},
+
+						"schedule_end_date": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     storageTransferDateSchema(),
+						},
+
+						"start_time_of_day": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"hours": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 23),
+									},
+
+									"minutes": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 59),
+									},
+
+									"seconds": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 60),
+									},
+
+									"nanos": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 999999999),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ENABLED",
+				ValidateFunc: validation.StringInSlice([]string{"ENABLED", "DISABLED"}, false),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modification_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"deletion_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func storageTransferGcsDataSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"bucket_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func storageTransferDateSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"year": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 9999),
+			},
+
+			"month": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 12),
+			},
+
+			"day": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 31),
+			},
+		},
+	}
+}
+
+func resourceStorageTransferJobCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	transferSpec, err := expandStorageTransferSpec(d.Get("transfer_spec").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	job := &storageTransferJob{
+		Description:  d.Get("description").(string),
+		ProjectId:    project,
+		Status:       d.Get("status").(string),
+		Schedule:     expandStorageTransferSchedule(d.Get("schedule").([]interface{})),
+		TransferSpec: transferSpec,
+	}
+
+	log.Printf("[DEBUG] Creating transfer job in project %s", project)
+	res, err := config.clientStorageTransfer.CreateTransferJob(job)
+	if err != nil {
+		return fmt.Errorf("Error creating transfer job: %s", err)
+	}
+
+	d.Set("name", res.Name)
+	d.SetId(res.Name)
+
+	return resourceStorageTransferJobRead(d, meta)
+}
+
+func resourceStorageTransferJobRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientStorageTransfer.GetTransferJob(d.Id(), project)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Transfer Job %q", d.Id()))
+	}
+
+	// Deleted jobs linger for a while before they're garbage collected.
+	if res.Status == "DELETED" {
+		log.Printf("[WARN] Removing deleted Transfer Job %q from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("project", res.ProjectId)
+	d.Set("name", res.Name)
+	d.Set("description", res.Description)
+	d.Set("status", res.Status)
+	d.Set("creation_time", res.CreationTime)
+	d.Set("last_modification_time", res.LastModificationTime)
+	d.Set("deletion_time", res.DeletionTime)
+	d.Set("schedule", flattenStorageTransferSchedule(res.Schedule))
+	d.Set("transfer_spec", flattenStorageTransferSpec(res.TransferSpec, d))
+
+	return nil
+}
+
+func resourceStorageTransferJobUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	job := &storageTransferJob{}
+	var fields []string
+
+	if d.HasChange("description") {
+		job.Description = d.Get("description").(string)
+		fields = append(fields, "description")
+	}
+
+	if d.HasChange("status") {
+		job.Status = d.Get("status").(string)
+		fields = append(fields, "status")
+	}
+
+	if d.HasChange("schedule") {
+		job.Schedule = expandStorageTransferSchedule(d.Get("schedule").([]interface{}))
+		fields = append(fields, "schedule")
+	}
+
+	if d.HasChange("transfer_spec") {
+		job.TransferSpec, err = expandStorageTransferSpec(d.Get("transfer_spec").([]interface{}))
+		if err != nil {
+			return err
+		}
+		fields = append(fields, "transfer_spec")
+	}
+
+	if len(fields) > 0 {
+		_, err = config.clientStorageTransfer.UpdateTransferJob(d.Id(), project, job, strings.Join(fields, ","))
+		if err != nil {
+			return fmt.Errorf("Error updating transfer job %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceStorageTransferJobRead(d, meta)
+}
+
+func resourceStorageTransferJobDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	// Transfer jobs can't be deleted outright, they're marked as such and
+	// garbage collected later.
+	job := &storageTransferJob{
+		Status: "DELETED",
+	}
+	_, err = config.clientStorageTransfer.UpdateTransferJob(d.Id(), project, job, "status")
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Transfer Job %q", d.Id()))
+	}
+
+	log.Printf("[DEBUG] Deleted transfer job %s", d.Id())
+	return nil
+}
+
+func resourceStorageTransferJobStateImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "transferJobs":
+		// In the provider project
+	case len(parts) == 3 && parts[1] == "transferJobs":
+		d.Set("project", parts[0])
+		d.SetId(strings.Join(parts[1:], "/"))
+	default:
+		return nil, fmt.Errorf("Invalid transfer job specifier %q, expected transferJobs/{id} or {project}/transferJobs/{id}", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandStorageTransferSpec(configured []interface{}) (*storageTransferSpec, error) {
+	spec := configured[0].(map[string]interface{})
+
+	transferSpec := &storageTransferSpec{
+		GcsDataSink: expandStorageTransferGcsData(spec["gcs_data_sink"].([]interface{})),
+	}
+
+	if v := spec["gcs_data_source"].([]interface{}); len(v) > 0 {
+		transferSpec.GcsDataSource = expandStorageTransferGcsData(v)
+	}
+
+	if v := spec["aws_s3_data_source"].([]interface{}); len(v) > 0 {
+		source := v[0].(map[string]interface{})
+		key := source["aws_access_key"].([]interface{})[0].(map[string]interface{})
+		transferSpec.AwsS3DataSource = &storageTransferAwsS3Data{
+			BucketName: source["bucket_name"].(string),
+			AwsAccessKey: &storageTransferAwsAccessKey{
+				AccessKeyId:     key["access_key_id"].(string),
+				SecretAccessKey: key["secret_access_key"].(string),
+			},
+		}
+	}
+
+	if (transferSpec.GcsDataSource == nil) == (transferSpec.AwsS3DataSource == nil) {
+		return nil, fmt.Errorf("exactly one of transfer_spec.0.gcs_data_source and transfer_spec.0.aws_s3_data_source must be set")
+	}
+
+	if v := spec["object_conditions"].([]interface{}); len(v) > 0 {
+		conditions := v[0].(map[string]interface{})
+		transferSpec.ObjectConditions = &storageTransferObjectConditions{
+			MinTimeElapsedSinceLastModification: conditions["min_time_elapsed_since_last_modification"].(string),
+			MaxTimeElapsedSinceLastModification: conditions["max_time_elapsed_since_last_modification"].(string),
+			IncludePrefixes:                     convertStringArr(conditions["include_prefixes"].([]interface{})),
+			ExcludePrefixes:                     convertStringArr(conditions["exclude_prefixes"].([]interface{})),
+		}
+	}
+
+	if v := spec["transfer_options"].([]interface{}); len(v) > 0 {
+		options := v[0].(map[string]interface{})
+		transferSpec.TransferOptions = &storageTransferOptions{
+			OverwriteObjectsAlreadyExistingInSink: options["overwrite_objects_already_existing_in_sink"].(bool),
+			DeleteObjectsUniqueInSink:             options["delete_objects_unique_in_sink"].(bool),
+			DeleteObjectsFromSourceAfterTransfer:  options["delete_objects_from_source_after_transfer"].(bool),
+		}
+	}
+
+	return transferSpec, nil
+}
+
+func expandStorageTransferGcsData(configured []interface{}) *storageTransferGcsData {
+	data := configured[0].(map[string]interface{})
+	return &storageTransferGcsData{
+		BucketName: data["bucket_name"].(string),
+	}
+}
+
+func flattenStorageTransferSpec(transferSpec *storageTransferSpec, d *schema.ResourceData) []map[string]interface{} {
+	if transferSpec == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{
+		"gcs_data_sink": flattenStorageTransferGcsData(transferSpec.GcsDataSink),
+	}
+
+	if transferSpec.GcsDataSource != nil {
+		spec["gcs_data_source"] = flattenStorageTransferGcsData(transferSpec.GcsDataSource)
+	}
+
+	if source := transferSpec.AwsS3DataSource; source != nil {
+		// The API never returns the secret access key, so keep the one in
+		// state.
+		key := map[string]interface{}{
+			"secret_access_key": d.Get("transfer_spec.0.aws_s3_data_source.0.aws_access_key.0.secret_access_key"),
+		}
+		if source.AwsAccessKey != nil {
+			key["access_key_id"] = source.AwsAccessKey.AccessKeyId
+		}
+		spec["aws_s3_data_source"] = []map[string]interface{}{
+			{
+				"bucket_name":    source.BucketName,
+				"aws_access_key": []map[string]interface{}{key},
+			},
+		}
+	}
+
+	if conditions := transferSpec.ObjectConditions; conditions != nil {
+		spec["object_conditions"] = []map[string]interface{}{
+			{
+				"min_time_elapsed_since_last_modification": conditions.MinTimeElapsedSinceLastModification,
+				"max_time_elapsed_since_last_modification": conditions.MaxTimeElapsedSinceLastModification,
+				"include_prefixes":                         conditions.IncludePrefixes,
+				"exclude_prefixes":                         conditions.ExcludePrefixes,
+			},
+		}
+	}
+
+	if options := transferSpec.TransferOptions; options != nil {
+		spec["transfer_options"] = []map[string]interface{}{
+			{
+				"overwrite_objects_already_existing_in_sink": options.OverwriteObjectsAlreadyExistingInSink,
+				"delete_objects_unique_in_sink":              options.DeleteObjectsUniqueInSink,
+				"delete_objects_from_source_after_transfer":  options.DeleteObjectsFromSourceAfterTransfer,
+			},
+		}
+	}
+
+	return []map[string]interface{}{spec}
+}
+
+func flattenStorageTransferGcsData(data *storageTransferGcsData) []map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"bucket_name": data.BucketName,
+		},
+	}
+}
+
+func expandStorageTransferSchedule(configured []interface{}) *storageTransferSchedule {
+	sched := configured[0].(map[string]interface{})
+
+	schedule := &storageTransferSchedule{
+		ScheduleStartDate: expandStorageTransferDate(sched["schedule_start_date"].([]interface{})),
+		ScheduleEndDate:   expandStorageTransferDate(sched["schedule_end_date"].([]interface{})),
+	}
+
+	if v := sched["start_time_of_day"].([]interface{}); len(v) > 0 {
+		t := v[0].(map[string]interface{})
+		schedule.StartTimeOfDay = &storageTransferTimeOfDay{
+			Hours:   t["hours"].(int),
+			Minutes: t["minutes"].(int),
+			Seconds: t["seconds"].(int),
+			Nanos:   t["nanos"].(int),
+		}
+	}
+
+	return schedule
+}
+
+func expandStorageTransferDate(configured []interface{}) *storageTransferDate {
+	if len(configured) == 0 {
+		return nil
+	}
+
+	date := configured[0].(map[string]interface{})
+	return &storageTransferDate{
+		Year:  date["year"].(int),
+		Month: date["month"].(int),
+		Day:   date["day"].(int),
+	}
+}
+
+func flattenStorageTransferSchedule(schedule *storageTransferSchedule) []map[string]interface{} {
+	if schedule == nil {
+		return nil
+	}
+
+	sched := map[string]interface{}{
+		"schedule_start_date": flattenStorageTransferDate(schedule.ScheduleStartDate),
+	}
+
+	if schedule.ScheduleEndDate != nil {
+		sched["schedule_end_date"] = flattenStorageTransferDate(schedule.ScheduleEndDate)
+	}
+
+	if t := schedule.StartTimeOfDay; t != nil {
+		sched["start_time_of_day"] = []map[string]interface{}{
+			{
+				"hours":   t.Hours,
+				"minutes": t.Minutes,
+				"seconds": t.Seconds,
+				"nanos":   t.Nanos,
+			},
+		}
+	}
+
+	return []map[string]interface{}{sched}
+}
+
+func flattenStorageTransferDate(date *storageTransferDate) []map[string]interface{} {
+	if date == nil {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"year":  date.Year,
+			"month": date.Month,
+			"day":   date.Day,
+		},
+	}
+}
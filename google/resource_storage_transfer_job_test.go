@@ -0,0 +1,318 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"google.golang.org/api/googleapi"
+)
+
+func TestStorageTransferJobRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"description": "Stage input data",
+		"transfer_spec": []interface{}{
+			map[string]interface{}{
+				"gcs_data_sink": []interface{}{
+					map[string]interface{}{"bucket_name": "staging"},
+				},
+				"aws_s3_data_source": []interface{}{
+					map[string]interface{}{
+						"bucket_name": "input",
+						"aws_access_key": []interface{}{
+							map[string]interface{}{
+								"access_key_id":     "AKIA",
+								"secret_access_key": "secret",
+							},
+						},
+					},
+				},
+				"object_conditions": []interface{}{
+					map[string]interface{}{
+						"max_time_elapsed_since_last_modification": "86400s",
+						"include_prefixes":                         []interface{}{"daily/"},
+					},
+				},
+				"transfer_options": []interface{}{
+					map[string]interface{}{
+						"overwrite_objects_already_existing_in_sink": true,
+					},
+				},
+			},
+		},
+		"schedule": []interface{}{
+			map[string]interface{}{
+				"schedule_start_date": []interface{}{
+					map[string]interface{}{"year": 2018, "month": 1, "day": 1},
+				},
+				"start_time_of_day": []interface{}{
+					map[string]interface{}{"hours": 2, "minutes": 30, "seconds": 0, "nanos": 0},
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceStorageTransferJob().Schema, raw)
+
+	spec, err := expandStorageTransferSpec(d.Get("transfer_spec").([]interface{}))
+	if err != nil {
+		t.Fatalf("Error expanding transfer_spec: %s", err)
+	}
+	if spec.AwsS3DataSource.AwsAccessKey.SecretAccessKey != "secret" {
+		t.Errorf("Expected the secret access key to be sent, got %+v", spec.AwsS3DataSource.AwsAccessKey)
+	}
+	schedule := expandStorageTransferSchedule(d.Get("schedule").([]interface{}))
+	if schedule.ScheduleEndDate != nil {
+		t.Errorf("Expected no schedule end date, got %+v", schedule.ScheduleEndDate)
+	}
+
+	// The API doesn't return the secret access key.
+	spec.AwsS3DataSource.AwsAccessKey.SecretAccessKey = ""
+	if err := d.Set("transfer_spec", flattenStorageTransferSpec(spec, d)); err != nil {
+		t.Fatalf("Error setting transfer_spec: %s", err)
+	}
+	if err := d.Set("schedule", flattenStorageTransferSchedule(schedule)); err != nil {
+		t.Fatalf("Error setting schedule: %s", err)
+	}
+
+	for k, v := range map[string]interface{}{
+		"transfer_spec.0.gcs_data_sink.0.bucket_name":                                   "staging",
+		"transfer_spec.0.aws_s3_data_source.0.aws_access_key.0.secret_access_key":       "secret",
+		"transfer_spec.0.object_conditions.0.include_prefixes":                          []interface{}{"daily/"},
+		"transfer_spec.0.transfer_options.0.overwrite_objects_already_existing_in_sink": true,
+		"schedule.0.schedule_start_date.0.year":                                         2018,
+		"schedule.0.start_time_of_day.0.minutes":                                        30,
+		"transfer_spec.0.object_conditions.0.max_time_elapsed_since_last_modification":  "86400s",
+		"transfer_spec.0.transfer_options.0.delete_objects_from_source_after_transfer":  false,
+	} {
+		if actual := d.Get(k); !reflect.DeepEqual(actual, v) {
+			t.Errorf("Expected %s to be %#v, got %#v", k, v, actual)
+		}
+	}
+}
+
+func TestStorageTransferJobSource(t *testing.T) {
+	t.Parallel()
+
+	for name, sources := range map[string]map[string]interface{}{
+		"none": {},
+		"both": {
+			"gcs_data_source": []interface{}{
+				map[string]interface{}{"bucket_name": "input"},
+			},
+			"aws_s3_data_source": []interface{}{
+				map[string]interface{}{
+					"bucket_name": "input",
+					"aws_access_key": []interface{}{
+						map[string]interface{}{"access_key_id": "AKIA", "secret_access_key": "secret"},
+					},
+				},
+			},
+		},
+	} {
+		spec := map[string]interface{}{
+			"gcs_data_sink": []interface{}{
+				map[string]interface{}{"bucket_name": "staging"},
+			},
+		}
+		for k, v := range sources {
+			spec[k] = v
+		}
+		d := schema.TestResourceDataRaw(t, resourceStorageTransferJob().Schema, map[string]interface{}{
+			"transfer_spec": []interface{}{spec},
+		})
+
+		if _, err := expandStorageTransferSpec(d.Get("transfer_spec").([]interface{})); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestStorageTransferClient(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	var patch map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&patch)
+		}
+		if r.URL.Path == "/v1/transferJobs/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "not found"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"name": "transferJobs/123", "projectId": "my-project", "status": "DISABLED"}`)
+	}))
+	defer server.Close()
+
+	client := &storageTransferClient{
+		client:   server.Client(),
+		BasePath: server.URL + "/",
+	}
+
+	job, err := client.GetTransferJob("transferJobs/123", "my-project")
+	if err != nil {
+		t.Fatalf("Error getting transfer job: %s", err)
+	}
+	if job.Name != "transferJobs/123" || job.Status != "DISABLED" {
+		t.Errorf("Unexpected transfer job %+v", job)
+	}
+
+	_, err = client.UpdateTransferJob("transferJobs/123", "my-project", &storageTransferJob{Status: "DISABLED"}, "status")
+	if err != nil {
+		t.Fatalf("Error updating transfer job: %s", err)
+	}
+	expectedPatch := map[string]interface{}{
+		"projectId":                  "my-project",
+		"transferJob":                map[string]interface{}{"status": "DISABLED"},
+		"updateTransferJobFieldMask": "status",
+	}
+	if !reflect.DeepEqual(patch, expectedPatch) {
+		t.Errorf("Expected patch %v, got %v", expectedPatch, patch)
+	}
+
+	_, err = client.GetTransferJob("transferJobs/missing", "my-project")
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != http.StatusNotFound {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+
+	expected := []string{
+		"GET /v1/transferJobs/123?projectId=my-project",
+		"PATCH /v1/transferJobs/123",
+		"GET /v1/transferJobs/missing?projectId=my-project",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestAccStorageTransferJob_basic(t *testing.T) {
+	t.Parallel()
+
+	suffix := acctest.RandString(10)
+	startDate := time.Now().UTC().AddDate(0, 0, 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccStorageTransferJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccStorageTransferJob_basic(suffix, "Stage input data", startDate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("google_storage_transfer_job.job", "name"),
+					resource.TestCheckResourceAttr("google_storage_transfer_job.job", "status", "ENABLED"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccStorageTransferJob_basic(suffix, "Stage input data nightly", startDate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_storage_transfer_job.job", "description", "Stage input data nightly"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "google_storage_transfer_job.job",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccStorageTransferJobDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_storage_transfer_job" {
+			continue
+		}
+
+		job, err := config.clientStorageTransfer.GetTransferJob(rs.Primary.ID, rs.Primary.Attributes["project"])
+		if err == nil && job.Status != "DELETED" {
+			return fmt.Errorf("Transfer job %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccStorageTransferJob_basic(suffix, description string, startDate time.Time) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "source" {
+	name          = "tf-test-transfer-source-%s"
+	force_destroy = true
+}
+
+resource "google_storage_bucket" "sink" {
+	name          = "tf-test-transfer-sink-%s"
+	force_destroy = true
+}
+
+data "google_storage_transfer_project_service_account" "transfer" {}
+
+resource "google_storage_bucket_iam_member" "source" {
+	bucket = "${google_storage_bucket.source.name}"
+	role   = "roles/storage.admin"
+	member = "serviceAccount:${data.google_storage_transfer_project_service_account.transfer.email}"
+}
+
+resource "google_storage_bucket_iam_member" "sink" {
+	bucket = "${google_storage_bucket.sink.name}"
+	role   = "roles/storage.admin"
+	member = "serviceAccount:${data.google_storage_transfer_project_service_account.transfer.email}"
+}
+
+resource "google_storage_transfer_job" "job" {
+	description = "%s"
+
+	transfer_spec {
+		gcs_data_source {
+			bucket_name = "${google_storage_bucket.source.name}"
+		}
+
+		gcs_data_sink {
+			bucket_name = "${google_storage_bucket.sink.name}"
+		}
+
+		object_conditions {
+			max_time_elapsed_since_last_modification = "86400s"
+		}
+
+		transfer_options {
+			delete_objects_unique_in_sink = false
+		}
+	}
+
+	schedule {
+		schedule_start_date {
+			year  = %d
+			month = %d
+			day   = %d
+		}
+
+		start_time_of_day {
+			hours   = 2
+			minutes = 30
+			seconds = 0
+			nanos   = 0
+		}
+	}
+
+	depends_on = [
+		"google_storage_bucket_iam_member.source",
+		"google_storage_bucket_iam_member.sink",
+	]
+}
+`, suffix, suffix, description, startDate.Year(), int(startDate.Month()), startDate.Day())
+}
@@ -0,0 +1,174 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryPolicy controls which failed API calls retryTransport retries, and
+// how long it waits in between.
+type retryPolicy struct {
+	// MaxRetries is the number of times a request is retried, 0 disables
+	// retries altogether.
+	MaxRetries int
+
+	// Backoff starts at InitialBackoff and doubles with every retry, up to
+	// MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// StatusCodes are the HTTP status codes worth retrying.
+	StatusCodes []int
+
+	// RetryRateLimitExceeded retries 403 responses with a rateLimitExceeded
+	// or userRateLimitExceeded reason, which is how most Google APIs report
+	// per-user quota being used up.
+	RetryRateLimitExceeded bool
+}
+
+// defaultRetryPolicy returns the policy used when the provider configuration
+// doesn't change any of the retry settings, and for Configs built without a
+// RetryPolicy, such as the sweepers'.
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		MaxRetries:             5,
+		InitialBackoff:         time.Second,
+		MaxBackoff:             30 * time.Second,
+		StatusCodes:            defaultRetryStatusCodes,
+		RetryRateLimitExceeded: true,
+	}
+}
+
+// retryTransport retries requests failing with a retryable status according
+// to its policy. Requests which aren't idempotent are only retried when the
+// response says they were rejected before being acted on (429 and rate
+// limiting), so that e.g. a create which timed out server side isn't sent
+// again.
+type retryTransport struct {
+	policy retryPolicy
+	base   http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.policy.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			// The body has been consumed by the previous attempt.
+			r = new(http.Request)
+			*r = *req
+			if req.Body != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if err != nil || attempt >= t.policy.MaxRetries || !t.canRetry(req) {
+			return resp, err
+		}
+
+		retry, err := t.shouldRetry(req, resp)
+		if err != nil || !retry {
+			return resp, err
+		}
+		resp.Body.Close()
+
+		log.Printf("[DEBUG] Retrying %s %s after %s, got status %d (attempt %d of %d)",
+			req.Method, req.URL, backoff, resp.StatusCode, attempt+1, t.policy.MaxRetries)
+		// Stop waiting as soon as the request is cancelled or times out.
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+		if backoff > t.policy.MaxBackoff {
+			backoff = t.policy.MaxBackoff
+		}
+	}
+}
+
+// canRetry reports whether req can be sent again.
+func (t *retryTransport) canRetry(req *http.Request) bool {
+	return req.Body == nil || req.GetBody != nil
+}
+
+// shouldRetry reports whether resp is a failure worth retrying req for. It
+// may read the body of resp, in which case it is replaced so the caller can
+// still read it.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response) (bool, error) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return intInSlice(t.policy.StatusCodes, resp.StatusCode), nil
+	}
+
+	if resp.StatusCode == http.StatusForbidden && t.policy.RetryRateLimitExceeded {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return false, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		return isRateLimitExceeded(body), nil
+	}
+
+	if !isIdempotent(req.Method) {
+		return false, nil
+	}
+	return intInSlice(t.policy.StatusCodes, resp.StatusCode), nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+func isRateLimitExceeded(body []byte) bool {
+	var resp struct {
+		Error struct {
+			Errors []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return false
+	}
+
+	for _, e := range resp.Error.Errors {
+		if e.Reason == "rateLimitExceeded" || e.Reason == "userRateLimitExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+func intInSlice(arr []int, i int) bool {
+	for _, v := range arr {
+		if v == i {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,193 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() retryPolicy {
+	return retryPolicy{
+		MaxRetries:             2,
+		InitialBackoff:         time.Millisecond,
+		MaxBackoff:             time.Millisecond,
+		StatusCodes:            defaultRetryStatusCodes,
+		RetryRateLimitExceeded: true,
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	rateLimited := `{"error": {"code": 403, "errors": [{"reason": "rateLimitExceeded"}]}}`
+	forbidden := `{"error": {"code": 403, "errors": [{"reason": "forbidden"}]}}`
+
+	cases := map[string]struct {
+		Method           string
+		Statuses         []int
+		Body             string
+		ExpectedAttempts int
+		ExpectedStatus   int
+	}{
+		"success": {
+			Method:           "GET",
+			Statuses:         []int{200},
+			ExpectedAttempts: 1,
+			ExpectedStatus:   200,
+		},
+		"get retried on 503": {
+			Method:           "GET",
+			Statuses:         []int{503, 200},
+			ExpectedAttempts: 2,
+			ExpectedStatus:   200,
+		},
+		"gives up after max retries": {
+			Method:           "DELETE",
+			Statuses:         []int{500, 500, 500, 200},
+			ExpectedAttempts: 3,
+			ExpectedStatus:   500,
+		},
+		"post not retried on 500": {
+			Method:           "POST",
+			Statuses:         []int{500, 200},
+			ExpectedAttempts: 1,
+			ExpectedStatus:   500,
+		},
+		"post retried on 429": {
+			Method:           "POST",
+			Statuses:         []int{429, 200},
+			ExpectedAttempts: 2,
+			ExpectedStatus:   200,
+		},
+		"post retried on rate limit exceeded": {
+			Method:           "POST",
+			Statuses:         []int{403, 200},
+			Body:             rateLimited,
+			ExpectedAttempts: 2,
+			ExpectedStatus:   200,
+		},
+		"other 403s not retried": {
+			Method:           "GET",
+			Statuses:         []int{403, 200},
+			Body:             forbidden,
+			ExpectedAttempts: 1,
+			ExpectedStatus:   403,
+		},
+		"404 not retried": {
+			Method:           "GET",
+			Statuses:         []int{404, 200},
+			ExpectedAttempts: 1,
+			ExpectedStatus:   404,
+		},
+	}
+
+	for tn, tc := range cases {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			if r.Method == "POST" && string(body) != "payload" {
+				t.Errorf("bad: %s, attempt %d sent body %q", tn, attempts, body)
+			}
+			w.WriteHeader(tc.Statuses[attempts])
+			if tc.Statuses[attempts] != 200 {
+				fmt.Fprint(w, tc.Body)
+			}
+			attempts++
+		}))
+
+		client := &http.Client{Transport: &retryTransport{
+			policy: testRetryPolicy(),
+			base:   http.DefaultTransport,
+		}}
+
+		var body *strings.Reader
+		if tc.Method == "POST" {
+			body = strings.NewReader("payload")
+		} else {
+			body = strings.NewReader("")
+		}
+		req, err := http.NewRequest(tc.Method, server.URL, body)
+		if err != nil {
+			t.Fatalf("bad: %s, %s", tn, err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("bad: %s, unexpected error: %s", tn, err)
+		}
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		server.Close()
+
+		if attempts != tc.ExpectedAttempts {
+			t.Errorf("bad: %s, expected %d attempts, got %d", tn, tc.ExpectedAttempts, attempts)
+		}
+		if resp.StatusCode != tc.ExpectedStatus {
+			t.Errorf("bad: %s, expected status %d, got %d", tn, tc.ExpectedStatus, resp.StatusCode)
+		}
+		if resp.StatusCode == 403 && string(respBody) != tc.Body {
+			t.Errorf("bad: %s, expected the response body to still be readable, got %q", tn, respBody)
+		}
+	}
+}
+
+func TestRetryTransport_cancelledDuringBackoff(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.InitialBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+	client := &http.Client{Transport: &retryTransport{policy: policy, base: http.DefaultTransport}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req.WithContext(ctx))
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("Expected the request to fail once its context timed out")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected the backoff to stop when the context timed out, waited %s", elapsed)
+	}
+}
+
+func TestRetryTransport_disabled(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxRetries = 0
+	client := &http.Client{Transport: &retryTransport{policy: policy, base: http.DefaultTransport}}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if attempts != 1 {
+		t.Fatalf("Expected 1 attempt, got %d", attempts)
+	}
+}
@@ -0,0 +1,47 @@
+package google
+
+import (
+	"log"
+	"sync"
+)
+
+// SemaphoreKV is a set of counting semaphores, one per key, each letting up
+// to size holders in at once. It's the counting equivalent of MutexKV, for
+// limiting concurrency rather than serializing.
+type SemaphoreKV struct {
+	lock  sync.Mutex
+	size  int
+	store map[string]chan struct{}
+}
+
+// NewSemaphoreKV returns a SemaphoreKV whose semaphores each have size slots.
+func NewSemaphoreKV(size int) *SemaphoreKV {
+	return &SemaphoreKV{
+		size:  size,
+		store: make(map[string]chan struct{}),
+	}
+}
+
+// Acquire takes a slot of the semaphore for key, blocking until one is free.
+func (s *SemaphoreKV) Acquire(key string) {
+	log.Printf("[DEBUG] Acquiring slot for %q", key)
+	s.get(key) <- struct{}{}
+	log.Printf("[DEBUG] Acquired slot for %q", key)
+}
+
+// Release gives back a slot taken with Acquire.
+func (s *SemaphoreKV) Release(key string) {
+	<-s.get(key)
+	log.Printf("[DEBUG] Released slot for %q", key)
+}
+
+func (s *SemaphoreKV) get(key string) chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	sem, ok := s.store[key]
+	if !ok {
+		sem = make(chan struct{}, s.size)
+		s.store[key] = sem
+	}
+	return sem
+}
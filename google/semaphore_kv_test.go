@@ -0,0 +1,59 @@
+package google
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSemaphoreKV(t *testing.T) {
+	t.Parallel()
+
+	s := NewSemaphoreKV(2)
+
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Acquire("us-central1")
+			defer s.Release("us-central1")
+
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+		}()
+	}
+
+	// Other keys have their own slots
+	done := make(chan struct{})
+	go func() {
+		s.Acquire("europe-west1")
+		s.Acquire("europe-west1")
+		s.Release("europe-west1")
+		s.Release("europe-west1")
+		close(done)
+	}()
+
+	wg.Wait()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected europe-west1 slots to be independent of us-central1")
+	}
+
+	if maxRunning != 2 {
+		t.Fatalf("Expected at most 2 concurrent holders, got %d", maxRunning)
+	}
+}
@@ -0,0 +1,85 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const serviceUsageBasePath = "https://serviceusage.googleapis.com/v1/"
+
+// serviceUsageClient is a minimal client for the Service Usage API, which has
+// no generated client in the vendored google.golang.org/api. Unlike Service
+// Management, it can refuse to disable a service that others depend on.
+type serviceUsageClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type serviceUsageOperation struct {
+	Name  string                      `json:"name,omitempty"`
+	Done  bool                        `json:"done,omitempty"`
+	Error *serviceUsageOperationError `json:"error,omitempty"`
+}
+
+type serviceUsageOperationError struct {
+	Code    int64  `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type serviceUsageDisableServiceRequest struct {
+	DisableDependentServices bool `json:"disableDependentServices,omitempty"`
+}
+
+// Disable disables service for project. Unless disableDependentServices is
+// set, the call fails if any enabled service depends on service.
+func (c *serviceUsageClient) Disable(project, service string, disableDependentServices bool) (*serviceUsageOperation, error) {
+	u := c.BasePath + "projects/" + project + "/services/" + service + ":disable"
+	op := &serviceUsageOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", u, &serviceUsageDisableServiceRequest{
+		DisableDependentServices: disableDependentServices,
+	}, op)
+	return op, err
+}
+
+func (c *serviceUsageClient) GetOperation(name string) (*serviceUsageOperation, error) {
+	op := &serviceUsageOperation{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.BasePath+name, nil, op)
+	return op, err
+}
+
+func serviceUsageOperationWait(config *Config, op *serviceUsageOperation, activity string) error {
+	state := &resource.StateChangeConf{
+		Pending: []string{"false"},
+		Target:  []string{"true"},
+		Refresh: func() (interface{}, string, error) {
+			if op.Done {
+				return op, "true", nil
+			}
+
+			var err error
+			op, err = config.clientServiceUsage.GetOperation(op.Name)
+			if err != nil {
+				return nil, "", err
+			}
+
+			log.Printf("[DEBUG] Got %v while polling for operation %s's 'done' status", op.Done, op.Name)
+			return op, fmt.Sprint(op.Done), nil
+		},
+		Delay:      time.Second,
+		Timeout:    10 * time.Minute,
+		MinTimeout: 2 * time.Second,
+	}
+	if _, err := state.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for %s: %s", activity, err)
+	}
+
+	if op.Error != nil {
+		return fmt.Errorf("Error code %v, message: %s", op.Error.Code, op.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package google
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/api/storage/v1"
+)
+
+const (
+	// storageBatchSize is the most calls the storage batch endpoint accepts
+	// in a single request.
+	storageBatchSize = 100
+
+	// storageBatchWorkers bounds the number of batch requests in flight.
+	storageBatchWorkers = 8
+)
+
+// deleteStorageObjects deletes objects from bucket, in batches sent by a
+// bounded pool of workers. Objects which are already gone are ignored. If set,
+// userProject is billed for the deletions.
+func deleteStorageObjects(config *Config, bucket, userProject string, objects []*storage.Object) error {
+	batches := make(chan []*storage.Object)
+	errs := make(chan error)
+
+	var wg sync.WaitGroup
+	for i := 0; i < storageBatchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if err := storageBatchDelete(config, bucket, userProject, batch); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for start := 0; start < len(objects); start += storageBatchSize {
+			end := start + storageBatchSize
+			if end > len(objects) {
+				end = len(objects)
+			}
+			batches <- objects[start:end]
+		}
+		close(batches)
+		wg.Wait()
+		close(errs)
+	}()
+
+	var result *multierror.Error
+	for err := range errs {
+		result = multierror.Append(result, err)
+	}
+	return result.ErrorOrNil()
+}
+
+// storageBatchDelete deletes up to storageBatchSize objects from bucket with
+// a single batch request. The generated client has no batch support, so the
+// multipart request is built by hand.
+func storageBatchDelete(config *Config, bucket, userProject string, objects []*storage.Object) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for i, object := range objects {
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"application/http"},
+			"Content-Id":   {strconv.Itoa(i)},
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(part, "DELETE %s HTTP/1.1\r\n\r\n", storageObjectPath(bucket, userProject, object))
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", storageBatchURL(config.clientStorage.BasePath), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+w.Boundary())
+	req.Header.Set("User-Agent", config.clientStorage.UserAgent)
+
+	resp, err := config.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Error deleting objects from bucket %s: batch request failed with status %d: %s", bucket, resp.StatusCode, b)
+	}
+
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("Error parsing batch response for bucket %s: %s", bucket, err)
+	}
+
+	var result *multierror.Error
+	r := multipart.NewReader(resp.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := r.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("Error reading batch response for bucket %s: %s", bucket, err)
+		}
+
+		// Responses are identified by "response-<Content-ID of the call>",
+		// falling back to their position.
+		idx := i
+		id := strings.Trim(part.Header.Get("Content-Id"), "<>")
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, "response-")); err == nil {
+			idx = n
+		}
+		if idx < 0 || idx >= len(objects) {
+			return fmt.Errorf("Error reading batch response for bucket %s: unexpected response %q", bucket, id)
+		}
+
+		partResp, err := http.ReadResponse(bufio.NewReader(part), nil)
+		if err != nil {
+			return fmt.Errorf("Error reading batch response for bucket %s: %s", bucket, err)
+		}
+		b, _ := ioutil.ReadAll(partResp.Body)
+		partResp.Body.Close()
+
+		if partResp.StatusCode >= 300 && partResp.StatusCode != http.StatusNotFound {
+			result = multierror.Append(result, fmt.Errorf("Error deleting object %s from bucket %s: status %d: %s",
+				objects[idx].Name, bucket, partResp.StatusCode, strings.TrimSpace(string(b))))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// storageBatchURL returns the batch endpoint matching the storage client's
+// basePath, e.g. https://www.googleapis.com/batch/storage/v1.
+func storageBatchURL(basePath string) string {
+	return strings.TrimSuffix(basePath, "storage/v1/") + "batch/storage/v1"
+}
+
+// storageObjectPath returns the path of object. In versioned buckets it
+// addresses the object's own generation, so noncurrent versions are deleted
+// rather than a delete marker being added to the live object.
+func storageObjectPath(bucket, userProject string, object *storage.Object) string {
+	path := fmt.Sprintf("/storage/v1/b/%s/o/%s", url.PathEscape(bucket), url.PathEscape(object.Name))
+
+	params := url.Values{}
+	if object.Generation != 0 {
+		params.Set("generation", strconv.FormatInt(object.Generation, 10))
+	}
+	if userProject != "" {
+		params.Set("userProject", userProject)
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return path
+}
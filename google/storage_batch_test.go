@@ -0,0 +1,210 @@
+package google
+
+import (
+	"bufio"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/storage/v1"
+)
+
+// storageBatchServer is a fake storage batch endpoint, answering every
+// DELETE with the status registered for its path and query, or 204.
+type storageBatchServer struct {
+	*httptest.Server
+
+	statuses map[string]int
+
+	mu      sync.Mutex
+	batches int
+	deleted []string
+}
+
+func newStorageBatchServer(t *testing.T, statuses map[string]int) *storageBatchServer {
+	s := &storageBatchServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/batch/storage/v1" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+			return
+		}
+
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("Error parsing batch Content-Type: %s", err)
+			return
+		}
+
+		// Read every call before answering, the request body can't be read
+		// once the response has started.
+		type batchCall struct {
+			id   string
+			path string
+		}
+		var calls []batchCall
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				break
+			}
+			call, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				t.Errorf("Error reading batched call: %s", err)
+				return
+			}
+			calls = append(calls, batchCall{part.Header.Get("Content-Id"), call.URL.RequestURI()})
+		}
+
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+		for _, call := range calls {
+			status, ok := s.statuses[call.path]
+			if !ok {
+				status = http.StatusNoContent
+			}
+			s.mu.Lock()
+			if status == http.StatusNoContent {
+				s.deleted = append(s.deleted, call.path)
+			}
+			s.mu.Unlock()
+
+			resp, _ := mw.CreatePart(textproto.MIMEHeader{
+				"Content-Type": {"application/http"},
+				"Content-Id":   {"<response-" + call.id + ">"},
+			})
+			fmt.Fprintf(resp, "HTTP/1.1 %d %s\r\nContent-Length: 0\r\n\r\n", status, http.StatusText(status))
+		}
+		mw.Close()
+
+		if len(calls) > storageBatchSize {
+			t.Errorf("Expected at most %d calls per batch, got %d", storageBatchSize, len(calls))
+		}
+		s.mu.Lock()
+		s.batches++
+		s.mu.Unlock()
+	}))
+	return s
+}
+
+func (s *storageBatchServer) Config(t *testing.T) *Config {
+	client, err := storage.New(s.Client())
+	if err != nil {
+		t.Fatalf("Error creating storage client: %s", err)
+	}
+	client.BasePath = s.URL + "/storage/v1/"
+
+	return &Config{
+		client:        s.Client(),
+		clientStorage: client,
+	}
+}
+
+func TestDeleteStorageObjects(t *testing.T) {
+	t.Parallel()
+
+	s := newStorageBatchServer(t, nil)
+	defer s.Close()
+
+	var objects []*storage.Object
+	for i := 0; i < 250; i++ {
+		objects = append(objects, &storage.Object{Name: fmt.Sprintf("google-cloud-dataproc-metainfo/%d", i)})
+	}
+
+	if err := deleteStorageObjects(s.Config(t), "bucket", "", objects); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if s.batches != 3 {
+		t.Errorf("Expected 3 batch requests, got %d", s.batches)
+	}
+	if len(s.deleted) != len(objects) {
+		t.Errorf("Expected %d objects to be deleted, got %d", len(objects), len(s.deleted))
+	}
+	for _, path := range s.deleted {
+		if !strings.HasPrefix(path, "/storage/v1/b/bucket/o/google-cloud-dataproc-metainfo%2F") {
+			t.Errorf("Expected object names to be escaped, got %s", path)
+		}
+	}
+}
+
+func TestDeleteStorageObjects_errors(t *testing.T) {
+	t.Parallel()
+
+	s := newStorageBatchServer(t, map[string]int{
+		"/storage/v1/b/bucket/o/gone":      http.StatusNotFound,
+		"/storage/v1/b/bucket/o/forbidden": http.StatusForbidden,
+	})
+	defer s.Close()
+
+	objects := []*storage.Object{{Name: "gone"}, {Name: "forbidden"}, {Name: "deleted"}}
+	err := deleteStorageObjects(s.Config(t), "bucket", "", objects)
+	if err == nil {
+		t.Fatal("Expected an error deleting a forbidden object")
+	}
+	if !strings.Contains(err.Error(), "Error deleting object forbidden from bucket bucket: status 403") {
+		t.Errorf("Expected the error to name the forbidden object, got: %s", err)
+	}
+	if strings.Contains(err.Error(), "gone") {
+		t.Errorf("Expected objects which are already gone to be ignored, got: %s", err)
+	}
+	if len(s.deleted) != 1 || s.deleted[0] != "/storage/v1/b/bucket/o/deleted" {
+		t.Errorf("Expected only the remaining object to be deleted, got %v", s.deleted)
+	}
+}
+
+func TestDeleteStorageObjects_versions(t *testing.T) {
+	t.Parallel()
+
+	s := newStorageBatchServer(t, nil)
+	defer s.Close()
+
+	objects := []*storage.Object{
+		{Name: "object", Generation: 1512000000000001},
+		{Name: "object", Generation: 1512000000000002},
+	}
+	if err := deleteStorageObjects(s.Config(t), "bucket", "", objects); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	sort.Strings(s.deleted)
+	expected := []string{
+		"/storage/v1/b/bucket/o/object?generation=1512000000000001",
+		"/storage/v1/b/bucket/o/object?generation=1512000000000002",
+	}
+	if !reflect.DeepEqual(s.deleted, expected) {
+		t.Errorf("Expected every version to be deleted, got %v", s.deleted)
+	}
+}
+
+func TestDeleteStorageObjects_userProject(t *testing.T) {
+	t.Parallel()
+
+	s := newStorageBatchServer(t, nil)
+	defer s.Close()
+
+	objects := []*storage.Object{
+		{Name: "a"},
+		{Name: "b", Generation: 1512000000000001},
+	}
+	if err := deleteStorageObjects(s.Config(t), "bucket", "billing-project", objects); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	sort.Strings(s.deleted)
+	expected := []string{
+		"/storage/v1/b/bucket/o/a?userProject=billing-project",
+		"/storage/v1/b/bucket/o/b?generation=1512000000000001&userProject=billing-project",
+	}
+	if !reflect.DeepEqual(s.deleted, expected) {
+		t.Errorf("Expected deletions to be billed to the user project, got %v", s.deleted)
+	}
+}
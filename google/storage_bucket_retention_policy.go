@@ -0,0 +1,57 @@
+package google
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// The vendored storage/v1 client predates bucket retention policies, so
+// they're read and written with the Cloud Storage JSON API directly.
+
+type storageBucketRetentionPolicy struct {
+	RetentionPeriod int64  `json:"retentionPeriod,string"`
+	IsLocked        bool   `json:"isLocked,omitempty"`
+	EffectiveTime   string `json:"effectiveTime,omitempty"`
+}
+
+type storageBucketRetention struct {
+	Metageneration  int64                         `json:"metageneration,string"`
+	RetentionPolicy *storageBucketRetentionPolicy `json:"retentionPolicy"`
+}
+
+func storageBucketRetentionUrl(config *Config, bucket, path, userProject string, params url.Values) string {
+	if userProject != "" {
+		params.Set("userProject", userProject)
+	}
+	return config.clientStorage.BasePath + "b/" + url.PathEscape(bucket) + path + "?" + params.Encode()
+}
+
+// getStorageBucketRetention returns the retention policy of bucket, which is
+// nil if it has none, along with the bucket's metageneration.
+func getStorageBucketRetention(config *Config, bucket, userProject string) (*storageBucketRetention, error) {
+	u := storageBucketRetentionUrl(config, bucket, "", userProject, url.Values{"fields": {"metageneration,retentionPolicy"}})
+	res := &storageBucketRetention{}
+	err := sendJsonRequest(config.client, config.clientStorage.UserAgent, "GET", u, nil, res)
+	return res, err
+}
+
+// setStorageBucketRetentionPolicy sets the retention policy of bucket, or
+// removes it if policy is nil.
+func setStorageBucketRetentionPolicy(config *Config, bucket, userProject string, policy *storageBucketRetentionPolicy) (*storageBucketRetention, error) {
+	u := storageBucketRetentionUrl(config, bucket, "", userProject, url.Values{"fields": {"metageneration,retentionPolicy"}})
+	body := map[string]interface{}{
+		"retentionPolicy": policy,
+	}
+	res := &storageBucketRetention{}
+	err := sendJsonRequest(config.client, config.clientStorage.UserAgent, "PATCH", u, body, res)
+	return res, err
+}
+
+// lockStorageBucketRetentionPolicy permanently locks the retention policy of
+// bucket. metageneration must match the bucket's current one, so that the
+// policy locked is the one last read.
+func lockStorageBucketRetentionPolicy(config *Config, bucket, userProject string, metageneration int64) error {
+	params := url.Values{"ifMetagenerationMatch": {strconv.FormatInt(metageneration, 10)}}
+	u := storageBucketRetentionUrl(config, bucket, "/lockRetentionPolicy", userProject, params)
+	return sendJsonRequest(config.client, config.clientStorage.UserAgent, "POST", u, nil, nil)
+}
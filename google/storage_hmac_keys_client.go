@@ -0,0 +1,60 @@
+package google
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// storageHmacKeysClient is a minimal client for the HMAC key calls of the
+// Cloud Storage JSON API, which the vendored storage/v1 client predates.
+type storageHmacKeysClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type storageHmacKey struct {
+	Metadata *storageHmacKeyMetadata `json:"metadata,omitempty"`
+	Secret   string                  `json:"secret,omitempty"`
+}
+
+type storageHmacKeyMetadata struct {
+	AccessId            string `json:"accessId,omitempty"`
+	Etag                string `json:"etag,omitempty"`
+	ProjectId           string `json:"projectId,omitempty"`
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+	State               string `json:"state,omitempty"`
+	TimeCreated         string `json:"timeCreated,omitempty"`
+	Updated             string `json:"updated,omitempty"`
+}
+
+// Create creates a new HMAC key for serviceAccountEmail. The response is the
+// only time its secret is returned.
+func (c *storageHmacKeysClient) Create(project, serviceAccountEmail string) (*storageHmacKey, error) {
+	u := c.BasePath + "projects/" + project + "/hmacKeys?" + url.Values{"serviceAccountEmail": {serviceAccountEmail}}.Encode()
+	res := &storageHmacKey{}
+	err := sendJsonRequest(c.client, c.UserAgent, "POST", u, nil, res)
+	return res, err
+}
+
+func (c *storageHmacKeysClient) Get(project, accessId string) (*storageHmacKeyMetadata, error) {
+	res := &storageHmacKeyMetadata{}
+	err := sendJsonRequest(c.client, c.UserAgent, "GET", c.keyUrl(project, accessId), nil, res)
+	return res, err
+}
+
+// Update sets the state of the key, the only field that can be changed.
+func (c *storageHmacKeysClient) Update(project, accessId string, metadata *storageHmacKeyMetadata) (*storageHmacKeyMetadata, error) {
+	res := &storageHmacKeyMetadata{}
+	err := sendJsonRequest(c.client, c.UserAgent, "PUT", c.keyUrl(project, accessId), metadata, res)
+	return res, err
+}
+
+// Delete deletes the key, which must be INACTIVE.
+func (c *storageHmacKeysClient) Delete(project, accessId string) error {
+	return sendJsonRequest(c.client, c.UserAgent, "DELETE", c.keyUrl(project, accessId), nil, nil)
+}
+
+func (c *storageHmacKeysClient) keyUrl(project, accessId string) string {
+	return c.BasePath + "projects/" + project + "/hmacKeys/" + accessId
+}
@@ -0,0 +1,128 @@
+package google
+
+import (
+	"net/http"
+	"net/url"
+)
+
+const storageTransferBasePath = "https://storagetransfer.googleapis.com/"
+
+// storageTransferClient is a minimal client for the Storage Transfer API v1,
+// which has no generated client in the vendored google.golang.org/api. It
+// only covers the transfer job calls google_storage_transfer_job needs.
+type storageTransferClient struct {
+	client    *http.Client
+	BasePath  string
+	UserAgent string
+}
+
+type storageTransferJob struct {
+	Name                 string                   `json:"name,omitempty"`
+	Description          string                   `json:"description,omitempty"`
+	ProjectId            string                   `json:"projectId,omitempty"`
+	TransferSpec         *storageTransferSpec     `json:"transferSpec,omitempty"`
+	Schedule             *storageTransferSchedule `json:"schedule,omitempty"`
+	Status               string                   `json:"status,omitempty"`
+	CreationTime         string                   `json:"creationTime,omitempty"`
+	LastModificationTime string                   `json:"lastModificationTime,omitempty"`
+	DeletionTime         string                   `json:"deletionTime,omitempty"`
+}
+
+type storageTransferSpec struct {
+	GcsDataSink      *storageTransferGcsData          `json:"gcsDataSink,omitempty"`
+	GcsDataSource    *storageTransferGcsData          `json:"gcsDataSource,omitempty"`
+	AwsS3DataSource  *storageTransferAwsS3Data        `json:"awsS3DataSource,omitempty"`
+	ObjectConditions *storageTransferObjectConditions `json:"objectConditions,omitempty"`
+	TransferOptions  *storageTransferOptions          `json:"transferOptions,omitempty"`
+}
+
+type storageTransferGcsData struct {
+	BucketName string `json:"bucketName"`
+}
+
+type storageTransferAwsS3Data struct {
+	BucketName   string                       `json:"bucketName"`
+	AwsAccessKey *storageTransferAwsAccessKey `json:"awsAccessKey,omitempty"`
+}
+
+type storageTransferAwsAccessKey struct {
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+}
+
+type storageTransferObjectConditions struct {
+	MinTimeElapsedSinceLastModification string   `json:"minTimeElapsedSinceLastModification,omitempty"`
+	MaxTimeElapsedSinceLastModification string   `json:"maxTimeElapsedSinceLastModification,omitempty"`
+	IncludePrefixes                     []string `json:"includePrefixes,omitempty"`
+	ExcludePrefixes                     []string `json:"excludePrefixes,omitempty"`
+}
+
+type storageTransferOptions struct {
+	OverwriteObjectsAlreadyExistingInSink bool `json:"overwriteObjectsAlreadyExistingInSink,omitempty"`
+	DeleteObjectsUniqueInSink             bool `json:"deleteObjectsUniqueInSink,omitempty"`
+	DeleteObjectsFromSourceAfterTransfer  bool `json:"deleteObjectsFromSourceAfterTransfer,omitempty"`
+}
+
+type storageTransferSchedule struct {
+	ScheduleStartDate *storageTransferDate      `json:"scheduleStartDate,omitempty"`
+	ScheduleEndDate   *storageTransferDate      `json:"scheduleEndDate,omitempty"`
+	StartTimeOfDay    *storageTransferTimeOfDay `json:"startTimeOfDay,omitempty"`
+}
+
+type storageTransferDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+type storageTransferTimeOfDay struct {
+	Hours   int `json:"hours"`
+	Minutes int `json:"minutes"`
+	Seconds int `json:"seconds"`
+	Nanos   int `json:"nanos"`
+}
+
+type storageTransferServiceAccount struct {
+	AccountEmail string `json:"accountEmail"`
+}
+
+// GetGoogleServiceAccount returns the service account Storage Transfer acts
+// as in project, which needs access to the buckets of its transfer jobs.
+func (c *storageTransferClient) GetGoogleServiceAccount(project string) (*storageTransferServiceAccount, error) {
+	res := &storageTransferServiceAccount{}
+	err := c.do("GET", "v1/googleServiceAccounts/"+project, nil, nil, res)
+	return res, err
+}
+
+func (c *storageTransferClient) CreateTransferJob(job *storageTransferJob) (*storageTransferJob, error) {
+	res := &storageTransferJob{}
+	err := c.do("POST", "v1/transferJobs", nil, job, res)
+	return res, err
+}
+
+func (c *storageTransferClient) GetTransferJob(name, project string) (*storageTransferJob, error) {
+	res := &storageTransferJob{}
+	err := c.do("GET", "v1/"+name, url.Values{"projectId": {project}}, nil, res)
+	return res, err
+}
+
+// UpdateTransferJob sets the fields of the job called name listed in
+// fieldMask to their values in job.
+func (c *storageTransferClient) UpdateTransferJob(name, project string, job *storageTransferJob, fieldMask string) (*storageTransferJob, error) {
+	req := map[string]interface{}{
+		"projectId":                  project,
+		"transferJob":                job,
+		"updateTransferJobFieldMask": fieldMask,
+	}
+	res := &storageTransferJob{}
+	err := c.do("PATCH", "v1/"+name, nil, req, res)
+	return res, err
+}
+
+func (c *storageTransferClient) do(method, path string, params url.Values, body, result interface{}) error {
+	u := c.BasePath + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	return sendJsonRequest(c.client, c.UserAgent, method, u, body, result)
+}
@@ -193,6 +193,16 @@ func getSubnetworkLink(d *schema.ResourceData, config *Config, subnetworkField,
 	return "", nil
 }
 
+// getProjectFromNetworkLink returns the project of a network self_link or
+// relative link, or "" if network is neither.
+func getProjectFromNetworkLink(network string) string {
+	r := regexp.MustCompile(fmt.Sprintf(globalLinkBasePattern, "networks"))
+	if parts := r.FindStringSubmatch(network); parts != nil {
+		return parts[1]
+	}
+	return ""
+}
+
 // getNetworkName reads the "network" field from the given resource data and if the value:
 // - is a resource URL, extracts the network name from the URL and returns it
 // - is the network name only (i.e not prefixed with http://www.googleapis.com/compute/...), is returned unchanged
@@ -400,3 +410,47 @@ func retryTime(retryFunc func() error, minutes int) error {
 		return resource.NonRetryableError(err)
 	})
 }
+
+func stringInSlice(arr []string, str string) bool {
+	for _, i := range arr {
+		if i == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// skipRefresh reports whether the refresh of an existing resource should be
+// skipped because it carries the terraform-refresh=skip label and the provider
+// is configured to honor it. The resource has to have a labels field. Only
+// the Read of the resource may call it, the reads at the end of Create and
+// Update must always happen.
+func skipRefresh(d *schema.ResourceData, config *Config) bool {
+	if !config.HonorSkipRefreshLabel {
+		return false
+	}
+
+	labels := d.Get("labels").(map[string]interface{})
+	if v, ok := labels["terraform-refresh"]; ok && v.(string) == "skip" {
+		log.Printf("[DEBUG] Skipping refresh of %s, it is labelled terraform-refresh=skip", d.Id())
+		return true
+	}
+	return false
+}
+
+// checkRequiredLabels returns an error listing the provider's required_labels
+// missing from labels.
+func checkRequiredLabels(config *Config, labels map[string]interface{}) error {
+	var missing []string
+	for _, label := range config.RequiredLabels {
+		if v, ok := labels[label]; !ok || v.(string) == "" {
+			missing = append(missing, label)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing labels required by the provider configuration: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
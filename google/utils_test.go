@@ -136,3 +136,47 @@ func TestRfc3339TimeDiffSuppress(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckRequiredLabels(t *testing.T) {
+	config := &Config{
+		RequiredLabels: []string{"cost-center", "owner"},
+	}
+
+	cases := map[string]struct {
+		Labels        map[string]interface{}
+		ExpectedError string
+	}{
+		"all present": {
+			Labels: map[string]interface{}{"cost-center": "1234", "owner": "data-eng", "env": "prod"},
+		},
+		"one missing": {
+			Labels:        map[string]interface{}{"cost-center": "1234"},
+			ExpectedError: "missing labels required by the provider configuration: owner",
+		},
+		"empty value": {
+			Labels:        map[string]interface{}{"cost-center": "", "owner": "data-eng"},
+			ExpectedError: "missing labels required by the provider configuration: cost-center",
+		},
+		"none": {
+			Labels:        map[string]interface{}{},
+			ExpectedError: "missing labels required by the provider configuration: cost-center, owner",
+		},
+	}
+
+	for tn, tc := range cases {
+		err := checkRequiredLabels(config, tc.Labels)
+		if tc.ExpectedError == "" {
+			if err != nil {
+				t.Errorf("bad: %s, unexpected error: %s", tn, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.ExpectedError {
+			t.Errorf("bad: %s, expected error %q, got %v", tn, tc.ExpectedError, err)
+		}
+	}
+
+	if err := checkRequiredLabels(&Config{}, map[string]interface{}{}); err != nil {
+		t.Errorf("bad: unexpected error without required labels: %s", err)
+	}
+}
@@ -29,6 +29,11 @@ func validateGCPName(v interface{}, k string) (ws []string, errors []error) {
 	return validateRegexp(re)(v, k)
 }
 
+func validateIAMCustomRoleID(v interface{}, k string) (ws []string, errors []error) {
+	re := `^[a-zA-Z0-9_\.]{3,64}$`
+	return validateRegexp(re)(v, k)
+}
+
 func validateRegexp(re string) schema.SchemaValidateFunc {
 	return func(v interface{}, k string) (ws []string, errors []error) {
 		value := v.(string)
@@ -79,3 +84,29 @@ func validateRFC3339Time(v interface{}, k string) (warnings []string, errors []e
 	}
 	return
 }
+
+var durationRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,9})?s$`)
+
+// validateDuration checks that v is a duration in seconds, with up to nine
+// fractional digits and ending with 's', as in Google APIs, e.g. "3.5s".
+func validateDuration(v interface{}, k string) (warnings []string, errors []error) {
+	duration := v.(string)
+	if !durationRegexp.MatchString(duration) {
+		errors = append(errors, fmt.Errorf("%q (%q) must be a duration in seconds with up to nine fractional digits, terminated by 's', e.g. \"3.5s\"", k, duration))
+	}
+	return
+}
+
+var subnetworkNameRegexp = regexp.MustCompile("^" + SubnetworkRegex + "$")
+var subnetworkLinkRegexp = regexp.MustCompile(SubnetworkLinkRegex)
+
+// validateSubnetworkNameOrLink checks that v is either the name of a
+// subnetwork, or its self_link or relative link, which can be in another
+// project, e.g. a Shared VPC host project.
+func validateSubnetworkNameOrLink(v interface{}, k string) (warnings []string, errors []error) {
+	subnetwork := v.(string)
+	if !subnetworkNameRegexp.MatchString(subnetwork) && !subnetworkLinkRegexp.MatchString(subnetwork) {
+		errors = append(errors, fmt.Errorf("%q (%q) must be a subnetwork name, or a link of the form projects/{project}/regions/{region}/subnetworks/{name}", k, subnetwork))
+	}
+	return
+}
@@ -3,6 +3,7 @@ package google
 import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
+	"strings"
 	"testing"
 )
 
@@ -29,6 +30,27 @@ func TestValidateGCPName(t *testing.T) {
 	}
 }
 
+func TestValidateIAMCustomRoleID(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "dataprocSubmitter"},
+		{TestName: "with numbers", Value: "dataprocSubmitter2"},
+		{TestName: "with dots and underscores", Value: "dataproc.job_submitter"},
+		{TestName: "short", Value: "foo"},
+
+		// With errors
+		{TestName: "empty", Value: "", ExpectError: true},
+		{TestName: "too short", Value: "fo", ExpectError: true},
+		{TestName: "has a hyphen", Value: "dataproc-submitter", ExpectError: true},
+		{TestName: "too long", Value: strings.Repeat("a", 65), ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateIAMCustomRoleID)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate IAM custom role IDs: %v", es)
+	}
+}
+
 func TestValidateRFC1918Network(t *testing.T) {
 	x := []RFC1918NetworkTestCase{
 		// No errors
@@ -74,6 +96,45 @@ func TestValidateRFC3339Time(t *testing.T) {
 	}
 }
 
+func TestValidateDuration(t *testing.T) {
+	cases := []StringValidationTestCase{
+		// No errors
+		{TestName: "seconds", Value: "2592000s"},
+		{TestName: "fractional seconds", Value: "3.5s"},
+		{TestName: "nanoseconds", Value: "0.000000001s"},
+
+		// With errors
+		{TestName: "no unit", Value: "3600", ExpectError: true},
+		{TestName: "other unit", Value: "1h", ExpectError: true},
+		{TestName: "too precise", Value: "0.0000000001s", ExpectError: true},
+		{TestName: "negative", Value: "-1s", ExpectError: true},
+	}
+
+	es := testStringValidationCases(cases, validateDuration)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate durations: %v", es)
+	}
+}
+
+func TestValidateSubnetworkNameOrLink(t *testing.T) {
+	cases := []StringValidationTestCase{
+		// No errors
+		{TestName: "name", Value: "dataproc"},
+		{TestName: "relative link", Value: "projects/host-project/regions/us-central1/subnetworks/dataproc"},
+		{TestName: "self link", Value: "https://www.googleapis.com/compute/v1/projects/host-project/regions/us-central1/subnetworks/dataproc"},
+
+		// With errors
+		{TestName: "uppercase name", Value: "Dataproc", ExpectError: true},
+		{TestName: "missing region", Value: "projects/host-project/subnetworks/dataproc", ExpectError: true},
+		{TestName: "network link", Value: "projects/host-project/global/networks/shared", ExpectError: true},
+	}
+
+	es := testStringValidationCases(cases, validateSubnetworkNameOrLink)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate subnetworks: %v", es)
+	}
+}
+
 type StringValidationTestCase struct {
 	TestName    string
 	Value       string